@@ -5,6 +5,7 @@ import (
 	"runtime/debug"
 	"strings"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
 	wrsp "github.com/tepleton/tepleton/wrsp/types"
@@ -25,6 +26,10 @@ import (
 // and to avoid affecting the Merkle root.
 var dbHeaderKey = []byte("header")
 
+// Key to store the consensus params in the main store, so that
+// they are known when the app restarts after InitChain.
+var mainConsensusParamsKey = []byte("consensus_params")
+
 // Enum mode for app.runTx
 type runTxMode uint8
 
@@ -40,13 +45,14 @@ const (
 // The WRSP application
 type BaseApp struct {
 	// initialized on creation
-	Logger     log.Logger
-	name       string               // application name from wrsp.Info
-	cdc        *wire.Codec          // Amino codec
-	db         dbm.DB               // common DB backend
-	cms        sdk.CommitMultiStore // Main (uncached) state
-	router     Router               // handle any kind of message
-	codespacer *sdk.Codespacer      // handle module codespacing
+	Logger      log.Logger
+	name        string               // application name from wrsp.Info
+	cdc         *wire.Codec          // Amino codec
+	db          dbm.DB               // common DB backend
+	cms         sdk.CommitMultiStore // Main (uncached) state
+	router      Router               // handle any kind of message
+	queryRouter QueryRouter          // router for redirecting query calls
+	codespacer  *sdk.Codespacer      // handle module codespacing
 
 	// must be set
 	txDecoder   sdk.TxDecoder   // unmarshal []byte into sdk.Tx
@@ -58,6 +64,11 @@ type BaseApp struct {
 	endBlocker       sdk.EndBlocker   // logic to run after all txs, and to determine valset changes
 	addrPeerFilter   sdk.PeerFilter   // filter peers by address and port
 	pubkeyPeerFilter sdk.PeerFilter   // filter peers by public key
+	minimumGasPrices sdk.GasPrices    // node-local gas prices txs must pay for in CheckTx
+
+	// set on loading the latest version, and in InitChain and EndBlock
+	mainKey         sdk.StoreKey          // main store, holding the consensus params
+	consensusParams *wrsp.ConsensusParams // may be nil, with no block gas limit
 
 	//--------------------
 	// Volatile
@@ -71,21 +82,25 @@ type BaseApp struct {
 
 var _ wrsp.Application = (*BaseApp)(nil)
 
-// Create and name new BaseApp
+// Create and name new BaseApp, applying the given options to it
 // NOTE: The db is used to store the version number for now.
-func NewBaseApp(name string, cdc *wire.Codec, logger log.Logger, db dbm.DB) *BaseApp {
+func NewBaseApp(name string, cdc *wire.Codec, logger log.Logger, db dbm.DB, options ...func(*BaseApp)) *BaseApp {
 	app := &BaseApp{
-		Logger:     logger,
-		name:       name,
-		cdc:        cdc,
-		db:         db,
-		cms:        store.NewCommitMultiStore(db),
-		router:     NewRouter(),
-		codespacer: sdk.NewCodespacer(),
-		txDecoder:  defaultTxDecoder(cdc),
+		Logger:      logger,
+		name:        name,
+		cdc:         cdc,
+		db:          db,
+		cms:         store.NewCommitMultiStore(db),
+		router:      NewRouter(),
+		queryRouter: NewQueryRouter(),
+		codespacer:  sdk.NewCodespacer(),
+		txDecoder:   defaultTxDecoder(cdc),
 	}
 	// Register the undefined & root codespaces, which should not be used by any modules
 	app.codespacer.RegisterOrPanic(sdk.CodespaceRoot)
+	for _, option := range options {
+		option(app)
+	}
 	return app
 }
 
@@ -161,6 +176,25 @@ func (app *BaseApp) SetPubKeyPeerFilter(pf sdk.PeerFilter) {
 }
 func (app *BaseApp) Router() Router { return app.router }
 
+// QueryRouter returns the QueryRouter of a BaseApp.
+func (app *BaseApp) QueryRouter() QueryRouter { return app.queryRouter }
+
+// Set which old versions of the stores are kept. Only affects the stores
+// loaded afterwards, so it must be set before loading a version.
+func (app *BaseApp) SetPruning(pruning sdk.PruningOptions) {
+	app.cms.SetPruning(pruning)
+}
+
+// Set the node-local minimum gas prices. Transactions whose fee doesn't pay
+// for their gas at these prices are rejected by CheckTx, but not by DeliverTx,
+// as the prices are not part of the consensus.
+func (app *BaseApp) SetMinimumGasPrices(prices sdk.GasPrices) {
+	app.minimumGasPrices = prices
+	if app.checkState != nil {
+		app.checkState.ctx = app.checkState.ctx.WithMinimumGasPrices(prices)
+	}
+}
+
 // load latest application version
 func (app *BaseApp) LoadLatestVersion(mainKey sdk.StoreKey) error {
 	err := app.cms.LoadLatestVersion()
@@ -198,6 +232,18 @@ func (app *BaseApp) initFromStore(mainKey sdk.StoreKey) error {
 	if main == nil {
 		return errors.New("baseapp expects MultiStore with 'main' KVStore")
 	}
+	app.mainKey = mainKey
+
+	// load the consensus params stored in InitChain
+	consensusParamsBytes := main.Get(mainConsensusParamsKey)
+	if consensusParamsBytes != nil {
+		var consensusParams wrsp.ConsensusParams
+		err := proto.Unmarshal(consensusParamsBytes, &consensusParams)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse consensus params")
+		}
+		app.consensusParams = &consensusParams
+	}
 
 	// XXX: Do we really need the header? What does it have that we want
 	// here that's not already in the CommitID ? If an app wants to have it,
@@ -252,8 +298,10 @@ func (st *state) CacheMultiStore() sdk.CacheMultiStore {
 func (app *BaseApp) setCheckState(header wrsp.Header) {
 	ms := app.cms.CacheMultiStore()
 	app.checkState = &state{
-		ms:  ms,
-		ctx: sdk.NewContext(ms, header, true, app.Logger),
+		ms: ms,
+		ctx: sdk.NewContext(ms, header, true, app.Logger).
+			WithMinimumGasPrices(app.minimumGasPrices).
+			WithBlockGasMeter(app.newBlockGasMeter()),
 	}
 }
 
@@ -265,6 +313,51 @@ func (app *BaseApp) setDeliverState(header wrsp.Header) {
 	}
 }
 
+// store the consensus params in the main store of the deliver state
+func (app *BaseApp) setConsensusParams(consensusParams *wrsp.ConsensusParams) {
+	bz, err := proto.Marshal(consensusParams)
+	if err != nil {
+		panic(err)
+	}
+	app.deliverState.ms.GetKVStore(app.mainKey).Set(mainConsensusParamsKey, bz)
+	app.consensusParams = consensusParams
+}
+
+// apply the updates of the consensus params returned by EndBlock,
+// in which the params left unchanged are nil
+func (app *BaseApp) updateConsensusParams(updates *wrsp.ConsensusParams) {
+	consensusParams := &wrsp.ConsensusParams{}
+	if app.consensusParams != nil {
+		*consensusParams = *app.consensusParams
+	}
+	if updates.BlockSize != nil {
+		consensusParams.BlockSize = updates.BlockSize
+	}
+	if updates.TxSize != nil {
+		consensusParams.TxSize = updates.TxSize
+	}
+	if updates.BlockGossip != nil {
+		consensusParams.BlockGossip = updates.BlockGossip
+	}
+	app.setConsensusParams(consensusParams)
+}
+
+// the block gas limit from the consensus params, or 0 if there is none
+func (app *BaseApp) getMaximumBlockGas() int64 {
+	if app.consensusParams == nil || app.consensusParams.BlockSize == nil {
+		return 0
+	}
+	return app.consensusParams.BlockSize.MaxGas
+}
+
+// a meter for the gas of all the txs of a block, up to the block gas limit
+func (app *BaseApp) newBlockGasMeter() sdk.GasMeter {
+	if maxGas := app.getMaximumBlockGas(); maxGas > 0 {
+		return sdk.NewGasMeter(maxGas)
+	}
+	return sdk.NewInfiniteGasMeter()
+}
+
 //______________________________________________________________________________
 
 // WRSP
@@ -291,6 +384,9 @@ func (app *BaseApp) SetOption(req wrsp.RequestSetOption) (res wrsp.ResponseSetOp
 func (app *BaseApp) InitChain(req wrsp.RequestInitChain) (res wrsp.ResponseInitChain) {
 	// Initialize the deliver state and check state with ChainID and run initChain
 	app.setDeliverState(wrsp.Header{ChainID: req.ChainId})
+	if req.ConsensusParams != nil {
+		app.setConsensusParams(req.ConsensusParams)
+	}
 	app.setCheckState(wrsp.Header{ChainID: req.ChainId})
 
 	if app.initChainer == nil {
@@ -363,6 +459,10 @@ func (app *BaseApp) Query(req wrsp.RequestQuery) (res wrsp.ResponseQuery) {
 		req.Path = "/" + strings.Join(path[1:], "/")
 		return queryable.Query(req)
 	}
+	// "/custom" prefix for the queriers of the modules
+	if len(path) >= 2 && path[0] == "custom" {
+		return app.handleQueryCustom(path, req)
+	}
 	// "/p2p" prefix for p2p queries
 	if len(path) >= 4 && path[0] == "p2p" {
 		if path[1] == "filter" {
@@ -378,6 +478,38 @@ func (app *BaseApp) Query(req wrsp.RequestQuery) (res wrsp.ResponseQuery) {
 	return sdk.ErrUnknownRequest(msg).QueryResult()
 }
 
+// route a custom query to the querier of its module, run against the state
+// committed at the requested height, the latest one by default
+func (app *BaseApp) handleQueryCustom(path []string, req wrsp.RequestQuery) (res wrsp.ResponseQuery) {
+	querier := app.queryRouter.Route(path[1])
+	if querier == nil {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("no custom querier found for route %s", path[1])).QueryResult()
+	}
+
+	height := req.Height
+	if height == 0 {
+		height = app.LastBlockHeight()
+	}
+	if height > app.LastBlockHeight() {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("height %d is not committed yet", height)).QueryResult()
+	}
+	cacheMS, err := app.cms.CacheMultiStoreWithVersion(height)
+	if err != nil {
+		return sdk.ErrInternal(fmt.Sprintf("failed to load state at height %d: %v", height, err)).QueryResult()
+	}
+	ctx := sdk.NewContext(cacheMS, wrsp.Header{Height: height}, true, app.Logger)
+
+	resBytes, queryErr := querier(ctx, path[2:], req)
+	if queryErr != nil {
+		return queryErr.QueryResult()
+	}
+	return wrsp.ResponseQuery{
+		Code:   uint32(sdk.WRSPCodeOK),
+		Value:  resBytes,
+		Height: height,
+	}
+}
+
 // Implements WRSP
 func (app *BaseApp) BeginBlock(req wrsp.RequestBeginBlock) (res wrsp.ResponseBeginBlock) {
 	// Initialize the DeliverTx state.
@@ -392,6 +524,8 @@ func (app *BaseApp) BeginBlock(req wrsp.RequestBeginBlock) (res wrsp.ResponseBeg
 		// by InitChain. Context is now updated with Header information.
 		app.deliverState.ctx = app.deliverState.ctx.WithBlockHeader(req.Header)
 	}
+	// the gas of the txs of the block is limited by the consensus params
+	app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(app.newBlockGasMeter())
 	if app.beginBlocker != nil {
 		res = app.beginBlocker(app.deliverState.ctx, req)
 	}
@@ -457,7 +591,10 @@ func (app *BaseApp) Check(tx sdk.Tx) (result sdk.Result) {
 
 // nolint - full tx execution
 func (app *BaseApp) Simulate(tx sdk.Tx) (result sdk.Result) {
-	return app.runTx(runTxModeSimulate, nil, tx)
+	result = app.runTx(runTxModeSimulate, nil, tx)
+	// report the fee CheckTx requires for a tx declaring the gas used
+	result.FeeRequired = app.minimumGasPrices.RequiredFees(result.GasUsed)
+	return result
 }
 
 // nolint
@@ -506,6 +643,22 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 		ctx = ctx.WithSigningValidators(app.signedValidators)
 	}
 
+	// Count the gas consumed by a delivered tx in the block gas meter,
+	// whatever its outcome. Successful txs are counted before being written,
+	// so that they are rejected if they overflow the block gas limit.
+	blockGasConsumed := false
+	if mode == runTxModeDeliver {
+		if ctx.BlockGasMeter().IsOutOfGas() {
+			return sdk.ErrBlockGasOverflow("no gas left in the block").Result()
+		}
+		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		defer func() {
+			if !blockGasConsumed {
+				consumeBlockGas(ctx)
+			}
+		}()
+	}
+
 	// Simulate a DeliverTx for gas calculation
 	if mode == runTxModeSimulate {
 		ctx = ctx.WithIsCheckTx(false)
@@ -514,12 +667,13 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 	// Run the ante handler.
 	if app.anteHandler != nil {
 		newCtx, result, abort := app.anteHandler(ctx, tx)
-		if abort {
-			return result
-		}
 		if !newCtx.IsZero() {
+			// keep the gas meter of the tx, even if it aborts
 			ctx = newCtx
 		}
+		if abort {
+			return result
+		}
 	}
 
 	// Get the correct cache
@@ -572,6 +726,14 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 		}
 	}
 
+	if mode == runTxModeDeliver {
+		blockGasConsumed = true
+		if !consumeBlockGas(ctx) {
+			return sdk.ErrBlockGasOverflow(fmt.Sprintf("tx gas %d overflows the block gas limit %d",
+				ctx.GasMeter().GasConsumed(), ctx.BlockGasMeter().Limit())).Result()
+		}
+	}
+
 	// If not a simulated run and result was successful, write to app.checkState.ms or app.deliverState.ms
 	// Only update state if all messages pass.
 	if mode != runTxModeSimulate && result.IsOK() {
@@ -583,11 +745,30 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 	return finalResult
 }
 
+// consumeBlockGas adds the gas consumed by a tx to the block gas meter,
+// returning false if it overflows the block gas limit
+func consumeBlockGas(ctx sdk.Context) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, isOutOfGas := r.(sdk.ErrorOutOfGas); !isOutOfGas {
+				panic(r)
+			}
+			ok = false
+		}
+	}()
+	ctx.BlockGasMeter().ConsumeGas(ctx.GasMeter().GasConsumed(), "block gas meter")
+	return true
+}
+
 // Implements WRSP
 func (app *BaseApp) EndBlock(req wrsp.RequestEndBlock) (res wrsp.ResponseEndBlock) {
 	if app.endBlocker != nil {
 		res = app.endBlocker(app.deliverState.ctx, req)
 	}
+	// the updated block gas limit applies from the next block
+	if res.ConsensusParamUpdates != nil {
+		app.updateConsensusParams(res.ConsensusParamUpdates)
+	}
 	return
 }
 
@@ -26,12 +26,12 @@ func defaultLogger() log.Logger {
 	return log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "sdk/app")
 }
 
-func newBaseApp(name string) *BaseApp {
+func newBaseApp(name string, options ...func(*BaseApp)) *BaseApp {
 	logger := defaultLogger()
 	db := dbm.NewMemDB()
 	codec := wire.NewCodec()
 	auth.RegisterBaseAccount(codec)
-	return NewBaseApp(name, codec, logger, db)
+	return NewBaseApp(name, codec, logger, db, options...)
 }
 
 func TestMountStores(t *testing.T) {
@@ -414,6 +414,43 @@ func TestSimulateTx(t *testing.T) {
 	}
 }
 
+// Test that the minimum gas prices are set in CheckTx only, and that
+// simulations report the fee they require.
+func TestMinimumGasPrices(t *testing.T) {
+	app := newBaseApp(t.Name(), SetMinimumGasPrices("0.25atom"))
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
+	require.Nil(t, err)
+
+	minGasPrices := sdk.GasPrices{{Denom: "atom", Amount: sdk.NewRat(1, 4)}}
+	var anteGasPrices sdk.GasPrices
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		anteGasPrices = ctx.MinimumGasPrices()
+		return
+	})
+	app.Router().AddRoute(msgType, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		ctx.GasMeter().ConsumeGas(10, "test")
+		return sdk.Result{}
+	})
+	tx := testUpdatePowerTx{}
+
+	app.InitChain(wrsp.RequestInitChain{})
+	app.BeginBlock(wrsp.RequestBeginBlock{Header: wrsp.Header{Height: 1}})
+
+	result := app.Check(tx)
+	require.Equal(t, sdk.WRSPCodeOK, result.Code, result.Log)
+	require.Equal(t, minGasPrices.String(), anteGasPrices.String())
+
+	result = app.Deliver(tx)
+	require.Equal(t, sdk.WRSPCodeOK, result.Code, result.Log)
+	require.Nil(t, anteGasPrices)
+
+	result = app.Simulate(tx)
+	require.Equal(t, sdk.WRSPCodeOK, result.Code, result.Log)
+	require.True(t, result.FeeRequired.IsEqual(sdk.Coins{sdk.NewCoin("atom", (result.GasUsed+3)/4)}), result.FeeRequired.String())
+}
+
 func TestRunInvalidTransaction(t *testing.T) {
 	// Initialize an app for testing
 	app := newBaseApp(t.Name())
@@ -466,6 +503,53 @@ func TestTxGasLimits(t *testing.T) {
 	app.Commit()
 }
 
+// Test that the txs of a block are rejected once they overflow the block gas
+// limit from the consensus params, which are kept across restarts
+func TestBlockGasLimit(t *testing.T) {
+	logger := defaultLogger()
+	db := dbm.NewMemDB()
+	app := NewBaseApp(t.Name(), nil, logger, db)
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
+	require.Nil(t, err)
+
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		newCtx = ctx.WithGasMeter(sdk.NewGasMeter(50))
+		return
+	})
+	app.Router().AddRoute(msgType, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		ctx.GasMeter().ConsumeGas(30, "counter")
+		return sdk.Result{}
+	})
+	tx := testUpdatePowerTx{} // doesn't matter
+
+	app.InitChain(wrsp.RequestInitChain{
+		ConsensusParams: &wrsp.ConsensusParams{BlockSize: &wrsp.BlockSize{MaxGas: 100}},
+	})
+	for height := int64(1); height <= 2; height++ {
+		app.BeginBlock(wrsp.RequestBeginBlock{Header: wrsp.Header{Height: height}})
+		for i := 0; i < 3; i++ {
+			res := app.Deliver(tx)
+			require.True(t, res.IsOK(), res.Log)
+		}
+		// 120 gas overflows the limit, and the block has no gas left
+		for i := 0; i < 2; i++ {
+			res := app.Deliver(tx)
+			require.Equal(t, sdk.ToWRSPCode(sdk.CodespaceRoot, sdk.CodeBlockGasOverflow), res.Code, res.Log)
+		}
+		app.EndBlock(wrsp.RequestEndBlock{})
+		app.Commit()
+	}
+
+	// the limit is loaded from the main store on restart
+	app = NewBaseApp(t.Name(), nil, logger, db)
+	app.MountStoresIAVL(capKey)
+	err = app.LoadLatestVersion(capKey)
+	require.Nil(t, err)
+	require.Equal(t, int64(100), app.getMaximumBlockGas())
+}
+
 // Test that we can only query from the latest committed state.
 func TestQuery(t *testing.T) {
 	app := newBaseApp(t.Name())
@@ -513,6 +597,54 @@ func TestQuery(t *testing.T) {
 	require.Equal(t, value, res.Value)
 }
 
+// Test that custom queries are routed to the queriers of the modules, and run
+// against the state committed at the requested height.
+func TestCustomQuery(t *testing.T) {
+	app := newBaseApp(t.Name())
+
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
+	require.Nil(t, err)
+
+	key := []byte("hello")
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx) (newCtx sdk.Context, res sdk.Result, abort bool) { return })
+	app.Router().AddRoute(msgType, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		store := ctx.KVStore(capKey)
+		store.Set(key, []byte(fmt.Sprintf("height %d", ctx.BlockHeight())))
+		return sdk.Result{}
+	})
+	app.QueryRouter().AddRoute("test", func(ctx sdk.Context, path []string, req wrsp.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) != 1 || path[0] != "value" {
+			return nil, sdk.ErrUnknownRequest("unknown test query")
+		}
+		return ctx.KVStore(capKey).Get(key), nil
+	})
+
+	for height := int64(1); height <= 2; height++ {
+		app.BeginBlock(wrsp.RequestBeginBlock{Header: wrsp.Header{Height: height}})
+		app.Deliver(testUpdatePowerTx{})
+		app.EndBlock(wrsp.RequestEndBlock{})
+		app.Commit()
+	}
+
+	// the latest state by default, or the state at a past height
+	res := app.Query(wrsp.RequestQuery{Path: "/custom/test/value"})
+	require.Equal(t, uint32(sdk.WRSPCodeOK), res.Code)
+	require.Equal(t, []byte("height 2"), res.Value)
+	require.Equal(t, int64(2), res.Height)
+	res = app.Query(wrsp.RequestQuery{Path: "/custom/test/value", Height: 1})
+	require.Equal(t, []byte("height 1"), res.Value)
+
+	// uncommitted height, unknown querier or path
+	res = app.Query(wrsp.RequestQuery{Path: "/custom/test/value", Height: 3})
+	require.NotEqual(t, uint32(sdk.WRSPCodeOK), res.Code)
+	res = app.Query(wrsp.RequestQuery{Path: "/custom/other/value"})
+	require.NotEqual(t, uint32(sdk.WRSPCodeOK), res.Code)
+	res = app.Query(wrsp.RequestQuery{Path: "/custom/test/other"})
+	require.NotEqual(t, uint32(sdk.WRSPCodeOK), res.Code)
+}
+
 // Test p2p filter queries
 func TestP2PQuery(t *testing.T) {
 	app := newBaseApp(t.Name())
@@ -0,0 +1,31 @@
+package baseapp
+
+import (
+	"fmt"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// File for storing in-package BaseApp optional functions,
+// for options that need access to non-exported fields of the BaseApp
+
+// SetMinimumGasPrices returns an option that sets the minimum gas prices of
+// the app from a string such as "0.025steak,0.1photino"
+func SetMinimumGasPrices(gasPricesStr string) func(*BaseApp) {
+	gasPrices, err := sdk.ParseGasPrices(gasPricesStr)
+	if err != nil {
+		panic(fmt.Sprintf("invalid minimum gas prices: %v", err))
+	}
+	return func(bap *BaseApp) { bap.SetMinimumGasPrices(gasPrices) }
+}
+
+// SetPruning returns an option that sets the pruning of the stores from a
+// named strategy: "nothing", "everything", "default", or "custom" using the
+// given numbers of versions to keep
+func SetPruning(strategy string, keepRecent, keepEvery int64) func(*BaseApp) {
+	pruning, err := sdk.NewPruningOptions(strategy, keepRecent, keepEvery)
+	if err != nil {
+		panic(fmt.Sprintf("invalid pruning options: %v", err))
+	}
+	return func(bap *BaseApp) { bap.SetPruning(pruning) }
+}
@@ -0,0 +1,41 @@
+package baseapp
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// QueryRouter provides queriers for each module
+type QueryRouter interface {
+	AddRoute(r string, q sdk.Querier) (rtr QueryRouter)
+	Route(path string) (q sdk.Querier)
+}
+
+type queryRouter struct {
+	routes map[string]sdk.Querier
+}
+
+// nolint
+// NewQueryRouter - create new query router
+func NewQueryRouter() *queryRouter {
+	return &queryRouter{
+		routes: make(map[string]sdk.Querier),
+	}
+}
+
+// AddRoute - add the querier of a module, reached through the query paths
+// "/custom/<r>/...". Panics if the module already has a querier.
+func (rtr *queryRouter) AddRoute(r string, q sdk.Querier) QueryRouter {
+	if !isAlpha(r) {
+		panic("route expressions can only contain alphanumeric characters")
+	}
+	if _, ok := rtr.routes[r]; ok {
+		panic("route has already been initialized")
+	}
+	rtr.routes[r] = q
+	return rtr
+}
+
+// Route - the querier of a module, nil if none
+func (rtr *queryRouter) Route(path string) (q sdk.Querier) {
+	return rtr.routes[path]
+}
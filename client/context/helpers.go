@@ -7,8 +7,10 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/tepleton/tepleton-sdk/crypto/multisig"
 	"github.com/tepleton/tepleton-sdk/wire"
 	"github.com/tepleton/tepleton-sdk/x/auth"
+	"github.com/tepleton/tepleton/crypto"
 	cmn "github.com/tepleton/tepleton/libs/common"
 	rpcclient "github.com/tepleton/tepleton/rpc/client"
 	ctypes "github.com/tepleton/tepleton/rpc/core/types"
@@ -65,11 +67,41 @@ func (ctx CoreContext) Query(path string) (res []byte, err error) {
 	return ctx.query(path, nil)
 }
 
+// QueryWithData queries the connected node with the provided path and data,
+// such as the JSON params of a custom module query
+func (ctx CoreContext) QueryWithData(path string, data []byte) (res []byte, err error) {
+	return ctx.query(path, data)
+}
+
 // QueryStore from Tendermint with the provided key and storename
 func (ctx CoreContext) QueryStore(key cmn.HexBytes, storeName string) (res []byte, err error) {
 	return ctx.queryStore(key, storeName, "key")
 }
 
+// QueryStoreWithProof from Tendermint with the provided key and storename,
+// returning the proof of the value against the app hash at the returned height
+func (ctx CoreContext) QueryStoreWithProof(key cmn.HexBytes, storeName string) (res []byte, proof []byte, height int64, err error) {
+	node, err := ctx.GetNode()
+	if err != nil {
+		return res, proof, height, err
+	}
+
+	path := fmt.Sprintf("/store/%s/key", storeName)
+	opts := rpcclient.WRSPQueryOptions{
+		Height:  ctx.Height,
+		Trusted: false,
+	}
+	result, err := node.WRSPQueryWithOptions(path, key, opts)
+	if err != nil {
+		return res, proof, height, err
+	}
+	resp := result.Response
+	if resp.Code != uint32(0) {
+		return res, proof, height, errors.Errorf("query failed: (%d) %s", resp.Code, resp.Log)
+	}
+	return resp.Value, resp.Proof, resp.Height, nil
+}
+
 // Query from Tendermint with the provided storename and subspace
 func (ctx CoreContext) QuerySubspace(cdc *wire.Codec, subspace []byte, storeName string) (res []sdk.KVPair, err error) {
 	resRaw, err := ctx.queryStore(subspace, storeName, "subspace")
@@ -129,61 +161,142 @@ func (ctx CoreContext) GetFromAddress() (from sdk.Address, err error) {
 	return info.GetPubKey().Address(), nil
 }
 
-// sign and build the transaction from the msg
-func (ctx CoreContext) SignAndBuild(name, passphrase string, msgs []sdk.Msg, cdc *wire.Codec) ([]byte, error) {
-
-	// build the Sign Messsage from the Standard Message
+// build the message to sign from the msgs and the context
+func (ctx CoreContext) BuildSignMsg(msgs []sdk.Msg) (auth.StdSignMsg, error) {
 	chainID := ctx.ChainID
 	if chainID == "" {
-		return nil, errors.Errorf("chain ID required but not specified")
+		return auth.StdSignMsg{}, errors.Errorf("chain ID required but not specified")
 	}
-	accnum := ctx.AccountNumber
-	sequence := ctx.Sequence
-	memo := ctx.Memo
 
 	fee := sdk.Coin{}
 	if ctx.Fee != "" {
 		parsedFee, err := sdk.ParseCoin(ctx.Fee)
 		if err != nil {
-			return nil, err
+			return auth.StdSignMsg{}, err
 		}
 		fee = parsedFee
 	}
+	stdFee := auth.NewStdFee(ctx.Gas, fee) // TODO run simulate to estimate gas?
 
-	signMsg := auth.StdSignMsg{
+	if ctx.FeeGranter != "" {
+		granter, err := sdk.GetAccAddressBech32(ctx.FeeGranter)
+		if err != nil {
+			return auth.StdSignMsg{}, err
+		}
+		stdFee.Granter = granter
+	}
+
+	return auth.StdSignMsg{
 		ChainID:       chainID,
-		AccountNumber: accnum,
-		Sequence:      sequence,
+		AccountNumber: ctx.AccountNumber,
+		Sequence:      ctx.Sequence,
 		Msgs:          msgs,
-		Memo:          memo,
-		Fee:           auth.NewStdFee(ctx.Gas, fee), // TODO run simulate to estimate gas?
-	}
+		Memo:          ctx.Memo,
+		Fee:           stdFee,
+	}, nil
+}
 
+// sign the message with the named key of the keybase
+func signStdSignMsg(name, passphrase string, signMsg auth.StdSignMsg) (auth.StdSignature, error) {
 	keybase, err := keys.GetKeyBase()
+	if err != nil {
+		return auth.StdSignature{}, err
+	}
+
+	sig, pubkey, err := keybase.Sign(name, passphrase, signMsg.Bytes())
+	if err != nil {
+		return auth.StdSignature{}, err
+	}
+	return auth.StdSignature{
+		PubKey:        pubkey,
+		Signature:     sig,
+		AccountNumber: signMsg.AccountNumber,
+		Sequence:      signMsg.Sequence,
+	}, nil
+}
+
+// sign and build the transaction from the msg
+func (ctx CoreContext) SignAndBuild(name, passphrase string, msgs []sdk.Msg, cdc *wire.Codec) ([]byte, error) {
+
+	// build the Sign Messsage from the Standard Message
+	signMsg, err := ctx.BuildSignMsg(msgs)
 	if err != nil {
 		return nil, err
 	}
 
 	// sign and build
-	bz := signMsg.Bytes()
-
-	sig, pubkey, err := keybase.Sign(name, passphrase, bz)
+	sig, err := signStdSignMsg(name, passphrase, signMsg)
 	if err != nil {
 		return nil, err
 	}
-	sigs := []auth.StdSignature{{
-		PubKey:        pubkey,
-		Signature:     sig,
-		AccountNumber: accnum,
-		Sequence:      sequence,
-	}}
+	sigs := []auth.StdSignature{sig}
 
 	// marshal bytes
-	tx := auth.NewStdTx(signMsg.Msgs, signMsg.Fee, sigs, memo)
+	tx := auth.NewStdTx(signMsg.Msgs, signMsg.Fee, sigs, signMsg.Memo)
 
 	return cdc.MarshalBinary(tx)
 }
 
+// build the unsigned transaction from the msgs, to be signed offline
+func (ctx CoreContext) BuildUnsignedStdTx(msgs []sdk.Msg) (auth.StdTx, error) {
+	signMsg, err := ctx.BuildSignMsg(msgs)
+	if err != nil {
+		return auth.StdTx{}, err
+	}
+	return auth.NewStdTx(signMsg.Msgs, signMsg.Fee, nil, signMsg.Memo), nil
+}
+
+// SignStdTx signs a transaction built offline with the named key, using the
+// chain ID, account number and sequence of the context. The signature can be
+// combined with the signatures of the other keys of a threshold public key.
+func (ctx CoreContext) SignStdTx(name, passphrase string, stdTx auth.StdTx) (auth.StdSignature, error) {
+	if ctx.ChainID == "" {
+		return auth.StdSignature{}, errors.Errorf("chain ID required but not specified")
+	}
+	signMsg := auth.StdSignMsg{
+		ChainID:       ctx.ChainID,
+		AccountNumber: ctx.AccountNumber,
+		Sequence:      ctx.Sequence,
+		Msgs:          stdTx.GetMsgs(),
+		Memo:          stdTx.GetMemo(),
+		Fee:           stdTx.Fee,
+	}
+	return signStdSignMsg(name, passphrase, signMsg)
+}
+
+// CombineSignatures combines the signatures of the keys of a threshold public
+// key, made by SignStdTx, into the signature of the threshold public key
+func CombineSignatures(pubkey crypto.PubKey, sigs []auth.StdSignature) (auth.StdSignature, error) {
+	multisigPub, ok := pubkey.(multisig.PubKeyMultisigThreshold)
+	if !ok {
+		return auth.StdSignature{}, errors.Errorf("%s is not a threshold public key", pubkey.Address())
+	}
+	if len(sigs) == 0 {
+		return auth.StdSignature{}, errors.Errorf("no signature to combine")
+	}
+
+	multisigSig := multisig.NewMultisig(len(multisigPub.PubKeys))
+	for _, sig := range sigs {
+		if sig.AccountNumber != sigs[0].AccountNumber || sig.Sequence != sigs[0].Sequence {
+			return auth.StdSignature{}, errors.Errorf("signatures made for different account numbers or sequences")
+		}
+		err := multisigSig.AddSignatureFromPubKey(sig.Signature, sig.PubKey, multisigPub.PubKeys)
+		if err != nil {
+			return auth.StdSignature{}, err
+		}
+	}
+	if len(multisigSig.Sigs) < int(multisigPub.K) {
+		return auth.StdSignature{}, errors.Errorf("%d signatures, at least %d required", len(multisigSig.Sigs), multisigPub.K)
+	}
+
+	return auth.StdSignature{
+		PubKey:        multisigPub,
+		Signature:     *multisigSig,
+		AccountNumber: sigs[0].AccountNumber,
+		Sequence:      sigs[0].Sequence,
+	}, nil
+}
+
 // sign and build the transaction from the msg
 func (ctx CoreContext) ensureSignBuild(name string, msgs []sdk.Msg, cdc *wire.Codec) (tyBytes []byte, err error) {
 	ctx, err = EnsureAccountNumber(ctx)
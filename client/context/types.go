@@ -12,6 +12,7 @@ type CoreContext struct {
 	Height          int64
 	Gas             int64
 	Fee             string
+	FeeGranter      string
 	TrustNode       bool
 	NodeURI         string
 	FromAddressName string
@@ -48,6 +49,12 @@ func (c CoreContext) WithFee(fee string) CoreContext {
 	return c
 }
 
+// WithFeeGranter - return a copy of the context with an updated fee granter
+func (c CoreContext) WithFeeGranter(feeGranter string) CoreContext {
+	c.FeeGranter = feeGranter
+	return c
+}
+
 // WithTrustNode - return a copy of the context with an updated TrustNode flag
 func (c CoreContext) WithTrustNode(trustNode bool) CoreContext {
 	c.TrustNode = trustNode
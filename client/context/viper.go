@@ -32,6 +32,7 @@ func NewCoreContextFromViper() CoreContext {
 		Height:          viper.GetInt64(client.FlagHeight),
 		Gas:             viper.GetInt64(client.FlagGas),
 		Fee:             viper.GetString(client.FlagFee),
+		FeeGranter:      viper.GetString(client.FlagFeeGranter),
 		TrustNode:       viper.GetBool(client.FlagTrustNode),
 		FromAddressName: viper.GetString(client.FlagName),
 		NodeURI:         nodeURI,
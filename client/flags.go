@@ -15,6 +15,7 @@ const (
 	FlagSequence      = "sequence"
 	FlagMemo          = "memo"
 	FlagFee           = "fee"
+	FlagFeeGranter    = "fee-granter"
 )
 
 // LineBreak can be included in a command list to provide a blank line
@@ -42,6 +43,7 @@ func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 		c.Flags().Int64(FlagSequence, 0, "Sequence number to sign the tx")
 		c.Flags().String(FlagMemo, "", "Memo to send along with transaction")
 		c.Flags().String(FlagFee, "", "Fee to pay along with transaction")
+		c.Flags().String(FlagFeeGranter, "", "Address of the account paying the fee from the fee allowance it granted to the signer")
 		c.Flags().String(FlagChainID, "", "Chain ID of tepleton node")
 		c.Flags().String(FlagNode, "tcp://localhost:26657", "<host>:<port> to tepleton rpc interface for this chain")
 		c.Flags().Bool(FlagUseLedger, false, "Use a connected Ledger device")
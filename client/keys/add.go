@@ -14,7 +14,9 @@ import (
 
 	ccrypto "github.com/tepleton/tepleton-sdk/crypto"
 	"github.com/tepleton/tepleton-sdk/crypto/keys"
+	"github.com/tepleton/tepleton-sdk/crypto/multisig"
 
+	"github.com/tepleton/tepleton/crypto"
 	"github.com/tepleton/tepleton/libs/cli"
 )
 
@@ -25,6 +27,9 @@ const (
 	flagDryRun   = "dry-run"
 	flagAccount  = "account"
 	flagIndex    = "index"
+
+	flagMultisig          = "multisig"
+	flagMultisigThreshold = "multisig-threshold"
 )
 
 func addKeyCommand() *cobra.Command {
@@ -33,7 +38,9 @@ func addKeyCommand() *cobra.Command {
 		Short: "Create a new key, or import from seed",
 		Long: `Add a public/private key pair to the key store.
 If you select --seed/-s you can recover a key from the seed
-phrase, otherwise, a new key will be generated.`,
+phrase, otherwise, a new key will be generated.
+If you select --multisig, the public keys of the given keys are combined
+into a k-of-n threshold public key stored as an offline key.`,
 		RunE: runAddCmd,
 	}
 	cmd.Flags().StringP(flagType, "t", "secp256k1", "Type of private key (secp256k1|ed25519)")
@@ -43,9 +50,35 @@ phrase, otherwise, a new key will be generated.`,
 	cmd.Flags().Bool(flagDryRun, false, "Perform action, but don't add key to local keystore")
 	cmd.Flags().Uint32(flagAccount, 0, "Account number for HD derivation")
 	cmd.Flags().Uint32(flagIndex, 0, "Index number for HD derivation")
+	cmd.Flags().StringSlice(flagMultisig, nil, "Store a k-of-n threshold public key made of the given comma separated keys")
+	cmd.Flags().Int(flagMultisigThreshold, 1, "Number of signatures required by the threshold public key")
 	return cmd
 }
 
+// store an offline threshold public key made of the public keys of other keys
+func addMultisigKey(kb keys.Keybase, name string, keyNames []string, threshold int) error {
+	if threshold <= 0 || threshold > len(keyNames) {
+		return errors.Errorf("threshold must be between 1 and %d", len(keyNames))
+	}
+	pubkeys := make([]crypto.PubKey, len(keyNames))
+	for i, keyName := range keyNames {
+		info, err := kb.Get(keyName)
+		if err != nil {
+			return err
+		}
+		pubkeys[i] = info.GetPubKey()
+	}
+	pk := multisig.NewPubKeyMultisigThreshold(threshold, pubkeys)
+	info, err := kb.CreateOffline(name, pk)
+	if err != nil {
+		return err
+	}
+	// there is no seed phrase to print
+	viper.Set(flagNoBackup, true)
+	printCreate(info, "")
+	return nil
+}
+
 func runAddCmd(cmd *cobra.Command, args []string) error {
 	var kb keys.Keybase
 	var err error
@@ -77,6 +110,11 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		multisigKeys := viper.GetStringSlice(flagMultisig)
+		if len(multisigKeys) != 0 {
+			return addMultisigKey(kb, name, multisigKeys, viper.GetInt(flagMultisigThreshold))
+		}
+
 		// ask for a password when generating a local key
 		if !viper.GetBool(client.FlagUseLedger) {
 			pass, err = client.GetCheckPassword(
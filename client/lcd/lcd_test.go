@@ -23,6 +23,7 @@ import (
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	"github.com/tepleton/tepleton-sdk/wire"
 	"github.com/tepleton/tepleton-sdk/x/auth"
+	distributionrest "github.com/tepleton/tepleton-sdk/x/distribution/client/rest"
 	"github.com/tepleton/tepleton-sdk/x/gov"
 	"github.com/tepleton/tepleton-sdk/x/slashing"
 	"github.com/tepleton/tepleton-sdk/x/stake"
@@ -401,6 +402,20 @@ func TestBonding(t *testing.T) {
 	// query validator
 	bond := getDelegation(t, port, addr, validator1Owner)
 	require.Equal(t, "60/1", bond.Shares.String())
+	bondHeight := resultTx.Height
+
+	// query the listings of the delegator and of the validator
+	delegations := getDelegatorDelegations(t, port, addr, "")
+	require.Len(t, delegations, 1)
+	require.Equal(t, sdk.MustBech32ifyVal(validator1Owner), delegations[0].ValidatorAddr)
+	require.Equal(t, "60/1", delegations[0].Shares.String())
+	validatorDelegations := getValidatorDelegations(t, port, validator1Owner)
+	require.Contains(t, validatorDelegations, delegations[0])
+
+	// the rewards of the delegator, if any were allocated yet, are with the validator
+	for _, rewards := range getDelegatorRewards(t, port, addr) {
+		require.Equal(t, sdk.MustBech32ifyVal(validator1Owner), rewards.ValidatorAddr)
+	}
 
 	//////////////////////
 	// testing unbonding
@@ -413,6 +428,15 @@ func TestBonding(t *testing.T) {
 	bond = getDelegation(t, port, addr, validator1Owner)
 	require.Equal(t, "30/1", bond.Shares.String())
 
+	// the unbonding is listed for the delegator, while a past height still shows the full delegation
+	unbondings := getDelegatorUnbondingDelegations(t, port, addr)
+	require.Len(t, unbondings, 1)
+	require.Equal(t, sdk.MustBech32ifyVal(validator1Owner), unbondings[0].ValidatorAddr)
+	require.Equal(t, int64(30), unbondings[0].Balance.Amount.Int64())
+	delegations = getDelegatorDelegations(t, port, addr, fmt.Sprintf("?height=%d", bondHeight))
+	require.Len(t, delegations, 1)
+	require.Equal(t, "60/1", delegations[0].Shares.String())
+
 	// check if tx was committed
 	require.Equal(t, uint32(0), resultTx.CheckTx.Code)
 	require.Equal(t, uint32(0), resultTx.DeliverTx.Code)
@@ -718,6 +742,49 @@ func getDelegation(t *testing.T, port string, delegatorAddr, validatorAddr sdk.A
 	return bond
 }
 
+func getDelegatorDelegations(t *testing.T, port string, delegatorAddr sdk.Address, query string) []stakerest.DelegationOutput {
+	delegatorAddrBech := sdk.MustBech32ifyAcc(delegatorAddr)
+	res, body := Request(t, port, "GET", "/stake/delegators/"+delegatorAddrBech+"/delegations"+query, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var delegations []stakerest.DelegationOutput
+	err := cdc.UnmarshalJSON([]byte(body), &delegations)
+	require.Nil(t, err)
+	return delegations
+}
+
+func getDelegatorRewards(t *testing.T, port string, delegatorAddr sdk.Address) []distributionrest.DelegationRewardsOutput {
+	delegatorAddrBech := sdk.MustBech32ifyAcc(delegatorAddr)
+	res, body := Request(t, port, "GET", "/distribution/delegators/"+delegatorAddrBech+"/rewards", nil)
+	if res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var rewards []distributionrest.DelegationRewardsOutput
+	err := cdc.UnmarshalJSON([]byte(body), &rewards)
+	require.Nil(t, err)
+	return rewards
+}
+
+func getDelegatorUnbondingDelegations(t *testing.T, port string, delegatorAddr sdk.Address) []stakerest.UnbondingDelegationOutput {
+	delegatorAddrBech := sdk.MustBech32ifyAcc(delegatorAddr)
+	res, body := Request(t, port, "GET", "/stake/delegators/"+delegatorAddrBech+"/unbonding_delegations", nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var ubds []stakerest.UnbondingDelegationOutput
+	err := cdc.UnmarshalJSON([]byte(body), &ubds)
+	require.Nil(t, err)
+	return ubds
+}
+
+func getValidatorDelegations(t *testing.T, port string, validatorAddr sdk.Address) []stakerest.DelegationOutput {
+	validatorAddrBech := sdk.MustBech32ifyVal(validatorAddr)
+	res, body := Request(t, port, "GET", "/stake/validators/"+validatorAddrBech+"/delegations", nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var delegations []stakerest.DelegationOutput
+	err := cdc.UnmarshalJSON([]byte(body), &delegations)
+	require.Nil(t, err)
+	return delegations
+}
+
 func doDelegate(t *testing.T, port, seed, name, password string, delegatorAddr, validatorAddr sdk.Address) (resultTx ctypes.ResultBroadcastTxCommit) {
 	// get the account to get the sequence
 	acc := getAccount(t, port, delegatorAddr)
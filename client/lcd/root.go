@@ -20,6 +20,7 @@ import (
 	"github.com/tepleton/tepleton-sdk/wire"
 	auth "github.com/tepleton/tepleton-sdk/x/auth/client/rest"
 	bank "github.com/tepleton/tepleton-sdk/x/bank/client/rest"
+	distribution "github.com/tepleton/tepleton-sdk/x/distribution/client/rest"
 	gov "github.com/tepleton/tepleton-sdk/x/gov/client/rest"
 	ibc "github.com/tepleton/tepleton-sdk/x/ibc/client/rest"
 	slashing "github.com/tepleton/tepleton-sdk/x/slashing/client/rest"
@@ -86,6 +87,7 @@ func createHandler(cdc *wire.Codec) http.Handler {
 	ibc.RegisterRoutes(ctx, r, cdc, kb)
 	stake.RegisterRoutes(ctx, r, cdc, kb)
 	slashing.RegisterRoutes(ctx, r, cdc, kb)
+	distribution.RegisterRoutes(ctx, r, cdc)
 	gov.RegisterRoutes(ctx, r, cdc)
 	return r
 }
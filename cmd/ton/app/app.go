@@ -14,11 +14,16 @@ import (
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	"github.com/tepleton/tepleton-sdk/wire"
 	"github.com/tepleton/tepleton-sdk/x/auth"
+	"github.com/tepleton/tepleton-sdk/x/authz"
 	"github.com/tepleton/tepleton-sdk/x/bank"
+	"github.com/tepleton/tepleton-sdk/x/distribution"
+	"github.com/tepleton/tepleton-sdk/x/feegrant"
 	"github.com/tepleton/tepleton-sdk/x/gov"
 	"github.com/tepleton/tepleton-sdk/x/ibc"
+	"github.com/tepleton/tepleton-sdk/x/params"
 	"github.com/tepleton/tepleton-sdk/x/slashing"
 	"github.com/tepleton/tepleton-sdk/x/stake"
+	"github.com/tepleton/tepleton-sdk/x/upgrade"
 )
 
 const (
@@ -42,8 +47,13 @@ type GaiaApp struct {
 	keyIBC           *sdk.KVStoreKey
 	keyStake         *sdk.KVStoreKey
 	keySlashing      *sdk.KVStoreKey
+	keyDistribution  *sdk.KVStoreKey
 	keyGov           *sdk.KVStoreKey
 	keyFeeCollection *sdk.KVStoreKey
+	keyParams        *sdk.KVStoreKey
+	keyUpgrade       *sdk.KVStoreKey
+	keyFeeGrant      *sdk.KVStoreKey
+	keyAuthz         *sdk.KVStoreKey
 
 	// Manage getting and setting accounts
 	accountMapper       auth.AccountMapper
@@ -52,23 +62,33 @@ type GaiaApp struct {
 	ibcMapper           ibc.Mapper
 	stakeKeeper         stake.Keeper
 	slashingKeeper      slashing.Keeper
+	distributionKeeper  distribution.Keeper
 	govKeeper           gov.Keeper
+	paramsKeeper        params.Keeper
+	upgradeKeeper       upgrade.Keeper
+	feeGrantKeeper      feegrant.Keeper
+	authzKeeper         authz.Keeper
 }
 
-func NewGaiaApp(logger log.Logger, db dbm.DB) *GaiaApp {
+func NewGaiaApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.BaseApp)) *GaiaApp {
 	cdc := MakeCodec()
 
 	// create your application object
 	var app = &GaiaApp{
-		BaseApp:          bam.NewBaseApp(appName, cdc, logger, db),
+		BaseApp:          bam.NewBaseApp(appName, cdc, logger, db, baseAppOptions...),
 		cdc:              cdc,
 		keyMain:          sdk.NewKVStoreKey("main"),
 		keyAccount:       sdk.NewKVStoreKey("acc"),
 		keyIBC:           sdk.NewKVStoreKey("ibc"),
 		keyStake:         sdk.NewKVStoreKey("stake"),
 		keySlashing:      sdk.NewKVStoreKey("slashing"),
+		keyDistribution:  sdk.NewKVStoreKey("distribution"),
 		keyGov:           sdk.NewKVStoreKey("gov"),
 		keyFeeCollection: sdk.NewKVStoreKey("fee"),
+		keyParams:        sdk.NewKVStoreKey("params"),
+		keyUpgrade:       sdk.NewKVStoreKey("upgrade"),
+		keyFeeGrant:      sdk.NewKVStoreKey("feegrant"),
+		keyAuthz:         sdk.NewKVStoreKey("authz"),
 	}
 
 	// define the accountMapper
@@ -80,26 +100,48 @@ func NewGaiaApp(logger log.Logger, db dbm.DB) *GaiaApp {
 
 	// add handlers
 	app.coinKeeper = bank.NewKeeper(app.accountMapper)
-	app.ibcMapper = ibc.NewMapper(app.cdc, app.keyIBC, app.RegisterCodespace(ibc.DefaultCodespace))
-	app.stakeKeeper = stake.NewKeeper(app.cdc, app.keyStake, app.coinKeeper, app.RegisterCodespace(stake.DefaultCodespace))
-	app.slashingKeeper = slashing.NewKeeper(app.cdc, app.keySlashing, app.stakeKeeper, app.RegisterCodespace(slashing.DefaultCodespace))
-	app.govKeeper = gov.NewKeeper(app.cdc, app.keyGov, app.coinKeeper, app.stakeKeeper, app.RegisterCodespace(gov.DefaultCodespace))
+	app.paramsKeeper = params.NewKeeper(app.cdc, app.keyParams)
+	app.ibcMapper = ibc.NewMapper(app.cdc, app.keyIBC, app.RegisterCodespace(ibc.DefaultCodespace)).WithParams(app.paramsKeeper.Setter())
+	app.upgradeKeeper = upgrade.NewKeeper(app.cdc, app.keyUpgrade)
 	app.feeCollectionKeeper = auth.NewFeeCollectionKeeper(app.cdc, app.keyFeeCollection)
+	stakeKeeper := stake.NewKeeper(app.cdc, app.keyStake, app.coinKeeper, app.paramsKeeper.Setter(), app.RegisterCodespace(stake.DefaultCodespace))
+	app.distributionKeeper = distribution.NewKeeper(app.cdc, app.keyDistribution, app.coinKeeper, app.feeCollectionKeeper, stakeKeeper, app.paramsKeeper.Getter(), app.RegisterCodespace(distribution.DefaultCodespace))
+	// the distribution keeper settles the rewards of delegations before their shares change
+	app.stakeKeeper = stakeKeeper.WithHooks(app.distributionKeeper.Hooks())
+	app.slashingKeeper = slashing.NewKeeper(app.cdc, app.keySlashing, app.stakeKeeper, app.paramsKeeper.Getter(), app.RegisterCodespace(slashing.DefaultCodespace))
+	app.feeGrantKeeper = feegrant.NewKeeper(app.cdc, app.keyFeeGrant, app.RegisterCodespace(feegrant.DefaultCodespace))
+	// the authz keeper executes msgs on behalf of granters through the app router
+	app.authzKeeper = authz.NewKeeper(app.cdc, app.keyAuthz, app.Router(), app.RegisterCodespace(authz.DefaultCodespace))
+	app.govKeeper = gov.NewKeeper(app.cdc, app.keyGov, app.paramsKeeper.Setter(), app.coinKeeper, app.stakeKeeper, app.upgradeKeeper, app.RegisterCodespace(gov.DefaultCodespace))
+
+	// register ibc packet routes
+	ibcRouter := ibc.NewRouter().
+		AddRoute(ibc.TransferRoute, ibc.NewTransferHandler(app.coinKeeper))
 
 	// register message routes
 	app.Router().
 		AddRoute("bank", bank.NewHandler(app.coinKeeper)).
-		AddRoute("ibc", ibc.NewHandler(app.ibcMapper, app.coinKeeper)).
+		AddRoute("ibc", ibc.NewHandler(app.ibcMapper, app.coinKeeper, ibcRouter)).
 		AddRoute("stake", stake.NewHandler(app.stakeKeeper)).
 		AddRoute("slashing", slashing.NewHandler(app.slashingKeeper)).
-		AddRoute("gov", gov.NewHandler(app.govKeeper))
+		AddRoute("distribution", distribution.NewHandler(app.distributionKeeper)).
+		AddRoute("gov", gov.NewHandler(app.govKeeper)).
+		AddRoute("feegrant", feegrant.NewHandler(app.feeGrantKeeper)).
+		AddRoute("authz", authz.NewHandler(app.authzKeeper))
+
+	// register custom query routes
+	app.QueryRouter().
+		AddRoute("gov", gov.NewQuerier(app.govKeeper)).
+		AddRoute("stake", stake.NewQuerier(app.stakeKeeper)).
+		AddRoute("slashing", slashing.NewQuerier(app.slashingKeeper)).
+		AddRoute("distribution", distribution.NewQuerier(app.distributionKeeper))
 
 	// initialize BaseApp
 	app.SetInitChainer(app.initChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetEndBlocker(app.EndBlocker)
-	app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, app.feeCollectionKeeper))
-	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyIBC, app.keyStake, app.keySlashing, app.keyGov, app.keyFeeCollection)
+	app.SetAnteHandler(auth.NewAnteHandlerWithFeeGrants(app.accountMapper, app.feeCollectionKeeper, app.feeGrantKeeper))
+	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyIBC, app.keyStake, app.keySlashing, app.keyDistribution, app.keyGov, app.keyFeeCollection, app.keyParams, app.keyUpgrade, app.keyFeeGrant, app.keyAuthz)
 	err := app.LoadLatestVersion(app.keyMain)
 	if err != nil {
 		cmn.Exit(err.Error())
@@ -115,7 +157,10 @@ func MakeCodec() *wire.Codec {
 	bank.RegisterWire(cdc)
 	stake.RegisterWire(cdc)
 	slashing.RegisterWire(cdc)
+	distribution.RegisterWire(cdc)
 	gov.RegisterWire(cdc)
+	feegrant.RegisterWire(cdc)
+	authz.RegisterWire(cdc)
 	auth.RegisterWire(cdc)
 	sdk.RegisterWire(cdc)
 	wire.RegisterCrypto(cdc)
@@ -124,7 +169,11 @@ func MakeCodec() *wire.Codec {
 
 // application updates every end block
 func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req wrsp.RequestBeginBlock) wrsp.ResponseBeginBlock {
-	tags := slashing.BeginBlocker(ctx, req, app.slashingKeeper)
+	tags := upgrade.BeginBlocker(ctx, app.upgradeKeeper)
+
+	// rewards of the previous block are allocated before any slashing
+	distribution.BeginBlocker(ctx, req, app.distributionKeeper)
+	tags = tags.AppendTags(slashing.BeginBlocker(ctx, req, app.slashingKeeper))
 
 	return wrsp.ResponseBeginBlock{
 		Tags: tags.ToKVPairs(),
@@ -134,9 +183,11 @@ func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req wrsp.RequestBeginBlock) wr
 // application updates every end block
 // nolint: unparam
 func (app *GaiaApp) EndBlocker(ctx sdk.Context, req wrsp.RequestEndBlock) wrsp.ResponseEndBlock {
-	validatorUpdates := stake.EndBlocker(ctx, app.stakeKeeper)
+	// governance penalties must be applied before the validator set is updated
+	tags := gov.EndBlocker(ctx, app.govKeeper)
 
-	tags, _ := gov.EndBlocker(ctx, app.govKeeper)
+	validatorUpdates, stakeTags := stake.EndBlocker(ctx, app.stakeKeeper)
+	tags = tags.AppendTags(stakeTags)
 
 	return wrsp.ResponseEndBlock{
 		ValidatorUpdates: validatorUpdates,
@@ -159,21 +210,37 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req wrsp.RequestInitChain) wrsp
 	// load the accounts
 	for _, gacc := range genesisState.Accounts {
 		acc := gacc.ToAccount()
-		acc.AccountNumber = app.accountMapper.GetNextAccountNumber(ctx)
+		err = acc.SetAccountNumber(app.accountMapper.GetNextAccountNumber(ctx))
+		if err != nil {
+			panic(err)
+		}
 		app.accountMapper.SetAccount(ctx, acc)
 	}
 
 	// load the initial stake information
 	stake.InitGenesis(ctx, app.stakeKeeper, genesisState.StakeData)
 
-	gov.InitGenesis(ctx, app.govKeeper, gov.DefaultGenesisState())
+	slashing.InitGenesis(ctx, app.slashingKeeper, genesisState.SlashingData)
+
+	distribution.InitGenesis(ctx, app.distributionKeeper, genesisState.DistributionData)
+
+	gov.InitGenesis(ctx, app.govKeeper, genesisState.GovData)
+
+	feegrant.InitGenesis(ctx, app.feeGrantKeeper, genesisState.FeeGrantData)
+
+	authz.InitGenesis(ctx, app.authzKeeper, genesisState.AuthzData)
+
+	upgrade.InitGenesis(ctx, app.upgradeKeeper, genesisState.UpgradeData)
+
+	ibc.InitGenesis(ctx, app.ibcMapper, genesisState.IBCData)
 
 	return wrsp.ResponseInitChain{}
 }
 
 // export the state of ton for a genesis file
 func (app *GaiaApp) ExportAppStateAndValidators() (appState json.RawMessage, validators []tmtypes.GenesisValidator, err error) {
-	ctx := app.NewContext(true, wrsp.Header{})
+	// the modules export the heights relative to the last block
+	ctx := app.NewContext(true, wrsp.Header{Height: app.LastBlockHeight()})
 
 	// iterate to get the accounts
 	accounts := []GenesisAccount{}
@@ -185,8 +252,15 @@ func (app *GaiaApp) ExportAppStateAndValidators() (appState json.RawMessage, val
 	app.accountMapper.IterateAccounts(ctx, appendAccount)
 
 	genState := GenesisState{
-		Accounts:  accounts,
-		StakeData: stake.WriteGenesis(ctx, app.stakeKeeper),
+		Accounts:         accounts,
+		StakeData:        stake.WriteGenesis(ctx, app.stakeKeeper),
+		SlashingData:     slashing.WriteGenesis(ctx, app.slashingKeeper),
+		DistributionData: distribution.WriteGenesis(ctx, app.distributionKeeper),
+		GovData:          gov.WriteGenesis(ctx, app.govKeeper),
+		FeeGrantData:     feegrant.WriteGenesis(ctx, app.feeGrantKeeper),
+		AuthzData:        authz.WriteGenesis(ctx, app.authzKeeper),
+		UpgradeData:      upgrade.WriteGenesis(ctx, app.upgradeKeeper),
+		IBCData:          ibc.WriteGenesis(ctx, app.ibcMapper),
 	}
 	appState, err = wire.MarshalJSONIndent(app.cdc, genState)
 	if err != nil {
@@ -1,11 +1,25 @@
 package app
 
 import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
 	"github.com/tepleton/tepleton-sdk/wire"
+	sdk "github.com/tepleton/tepleton-sdk/types"
 	"github.com/tepleton/tepleton-sdk/x/auth"
+	"github.com/tepleton/tepleton-sdk/x/authz"
+	"github.com/tepleton/tepleton-sdk/x/distribution"
+	"github.com/tepleton/tepleton-sdk/x/feegrant"
+	"github.com/tepleton/tepleton-sdk/x/gov"
+	"github.com/tepleton/tepleton-sdk/x/slashing"
 	"github.com/tepleton/tepleton-sdk/x/stake"
+	"github.com/tepleton/tepleton-sdk/x/upgrade"
 
 	wrsp "github.com/tepleton/tepleton/wrsp/types"
+	"github.com/tepleton/tepleton/crypto"
+	dbm "github.com/tepleton/tepleton/libs/db"
+	"github.com/tepleton/tepleton/libs/log"
 )
 
 func setGenesis(gapp *GaiaApp, accs ...*auth.BaseAccount) error {
@@ -15,8 +29,14 @@ func setGenesis(gapp *GaiaApp, accs ...*auth.BaseAccount) error {
 	}
 
 	genesisState := GenesisState{
-		Accounts:  genaccs,
-		StakeData: stake.DefaultGenesisState(),
+		Accounts:         genaccs,
+		StakeData:        stake.DefaultGenesisState(),
+		SlashingData:     slashing.DefaultGenesisState(),
+		DistributionData: distribution.DefaultGenesisState(),
+		GovData:          gov.DefaultGenesisState(),
+		FeeGrantData:     feegrant.DefaultGenesisState(),
+		AuthzData:        authz.DefaultGenesisState(),
+		UpgradeData:      upgrade.DefaultGenesisState(),
 	}
 
 	stateBytes, err := wire.MarshalJSONIndent(gapp.cdc, genesisState)
@@ -31,3 +51,109 @@ func setGenesis(gapp *GaiaApp, accs ...*auth.BaseAccount) error {
 
 	return nil
 }
+
+func TestGaiaExportImportGov(t *testing.T) {
+	gapp := NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB())
+
+	addr := sdk.Address(crypto.GenPrivKeyEd25519().PubKey().Address())
+	acc := auth.NewBaseAccountWithAddress(addr)
+	acc.Coins = sdk.Coins{sdk.NewCoin("steak", 100)}
+	require.Nil(t, setGenesis(gapp, &acc))
+
+	// create an active proposal with a deposit and a vote, and two inactive
+	// ones, one of which is a software upgrade proposal
+	header := wrsp.Header{Height: gapp.LastBlockHeight() + 1}
+	gapp.BeginBlock(wrsp.RequestBeginBlock{Header: header})
+	ctx := gapp.NewContext(false, header)
+	active := gapp.govKeeper.NewTextProposal(ctx, "Active", "description", gov.ProposalTypeText)
+	err, votingStarted := gapp.govKeeper.AddDeposit(ctx, active.GetProposalID(), addr, sdk.Coins{sdk.NewCoin("steak", 10)})
+	require.Nil(t, err)
+	require.True(t, votingStarted)
+	require.Nil(t, gapp.govKeeper.AddVote(ctx, active.GetProposalID(), addr, gov.OptionYes))
+	inactive := gapp.govKeeper.NewTextProposal(ctx, "Inactive", "description", gov.ProposalTypeText)
+	err, _ = gapp.govKeeper.AddDeposit(ctx, inactive.GetProposalID(), addr, sdk.Coins{sdk.NewCoin("steak", 5)})
+	require.Nil(t, err)
+	plan := upgrade.Plan{Name: "v2", Height: header.Height + 100}
+	upgradeProposal := gapp.govKeeper.NewSoftwareUpgradeProposal(ctx, "Upgrade", "description", plan)
+	err, _ = gapp.govKeeper.AddDeposit(ctx, upgradeProposal.GetProposalID(), addr, sdk.Coins{sdk.NewCoin("steak", 5)})
+	require.Nil(t, err)
+	gapp.EndBlock(wrsp.RequestEndBlock{})
+	gapp.Commit()
+
+	appState, _, exportErr := gapp.ExportAppStateAndValidators()
+	require.Nil(t, exportErr)
+
+	var genesisState GenesisState
+	require.Nil(t, gapp.cdc.UnmarshalJSON(appState, &genesisState))
+	govData := genesisState.GovData
+	require.Equal(t, int64(4), govData.StartingProposalID)
+	require.Equal(t, 3, len(govData.Proposals))
+	require.Equal(t, 3, len(govData.Deposits))
+	require.Equal(t, 1, len(govData.Votes))
+	require.Equal(t, gov.ProposalQueue{active.GetProposalID()}, govData.ActiveProposalQueue)
+	require.Equal(t, gov.ProposalQueue{inactive.GetProposalID(), upgradeProposal.GetProposalID()}, govData.InactiveProposalQueue)
+
+	// the heights are exported relative to the last block
+	for _, proposal := range govData.Proposals {
+		require.Equal(t, int64(0), proposal.GetSubmitBlock())
+		if exported, ok := proposal.(*gov.SoftwareUpgradeProposal); ok {
+			require.Equal(t, int64(100), exported.Plan.Height)
+		}
+	}
+
+	// restart a new chain from the exported state, the deposit and voting
+	// periods end as many blocks after the restart as they had left
+	gapp2 := NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB())
+	gapp2.InitChain(wrsp.RequestInitChain{AppStateBytes: appState})
+	gapp2.Commit()
+
+	// the upgrade plan is rebased on the import height
+	ctx = gapp2.NewContext(true, wrsp.Header{})
+	imported := gapp2.govKeeper.GetProposal(ctx, upgradeProposal.GetProposalID())
+	require.Equal(t, int64(100), imported.(*gov.SoftwareUpgradeProposal).Plan.Height)
+
+	votingPeriod := gov.DefaultVotingProcedure().VotingPeriod
+	require.Equal(t, votingPeriod, gov.DefaultDepositProcedure().MaxDepositPeriod)
+	for _, height := range []int64{votingPeriod - 1, votingPeriod} {
+		header = wrsp.Header{Height: height}
+		gapp2.BeginBlock(wrsp.RequestBeginBlock{Header: header})
+		gapp2.EndBlock(wrsp.RequestEndBlock{Height: height})
+		gapp2.Commit()
+
+		ctx = gapp2.NewContext(true, header)
+		ended := height == votingPeriod
+		proposal := gapp2.govKeeper.GetProposal(ctx, active.GetProposalID())
+		require.NotNil(t, proposal)
+		require.Equal(t, ended, proposal.GetStatus() != gov.StatusVotingPeriod)
+		require.Equal(t, ended, gapp2.govKeeper.GetProposal(ctx, inactive.GetProposalID()) == nil)
+		require.Equal(t, ended, gapp2.govKeeper.GetProposal(ctx, upgradeProposal.GetProposalID()) == nil)
+	}
+}
+
+func TestGaiaExportImportUpgrade(t *testing.T) {
+	gapp := NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB())
+	require.Nil(t, setGenesis(gapp))
+
+	// schedule an upgrade 10 blocks after the next one
+	header := wrsp.Header{Height: gapp.LastBlockHeight() + 1}
+	gapp.BeginBlock(wrsp.RequestBeginBlock{Header: header})
+	ctx := gapp.NewContext(false, header)
+	plan := upgrade.Plan{Name: "v2", Height: header.Height + 10}
+	require.Nil(t, gapp.upgradeKeeper.ScheduleUpgrade(ctx, plan))
+	gapp.EndBlock(wrsp.RequestEndBlock{})
+	gapp.Commit()
+
+	appState, _, err := gapp.ExportAppStateAndValidators()
+	require.Nil(t, err)
+	var genesisState GenesisState
+	require.Nil(t, gapp.cdc.UnmarshalJSON(appState, &genesisState))
+	require.Equal(t, upgrade.Plan{Name: "v2", Height: 10}, genesisState.UpgradeData.Plan)
+
+	// the restarted chain halts at the same number of blocks after the export
+	gapp2 := NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB())
+	gapp2.InitChain(wrsp.RequestInitChain{AppStateBytes: appState})
+	gapp2.Commit()
+	got, found := gapp2.upgradeKeeper.GetUpgradePlan(gapp2.NewContext(true, wrsp.Header{}))
+	require.True(t, found)
+	require.Equal(t, int64(10), got.Height)
+}
@@ -13,7 +13,14 @@ import (
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	"github.com/tepleton/tepleton-sdk/wire"
 	"github.com/tepleton/tepleton-sdk/x/auth"
+	"github.com/tepleton/tepleton-sdk/x/distribution"
+	"github.com/tepleton/tepleton-sdk/x/authz"
+	"github.com/tepleton/tepleton-sdk/x/feegrant"
+	"github.com/tepleton/tepleton-sdk/x/gov"
+	"github.com/tepleton/tepleton-sdk/x/ibc"
+	"github.com/tepleton/tepleton-sdk/x/slashing"
 	"github.com/tepleton/tepleton-sdk/x/stake"
+	"github.com/tepleton/tepleton-sdk/x/upgrade"
 )
 
 var (
@@ -24,14 +31,29 @@ var (
 
 // State to Unmarshal
 type GenesisState struct {
-	Accounts  []GenesisAccount   `json:"accounts"`
-	StakeData stake.GenesisState `json:"stake"`
+	Accounts         []GenesisAccount          `json:"accounts"`
+	StakeData        stake.GenesisState        `json:"stake"`
+	SlashingData     slashing.GenesisState     `json:"slashing"`
+	DistributionData distribution.GenesisState `json:"distribution"`
+	GovData          gov.GenesisState          `json:"gov"`
+	FeeGrantData     feegrant.GenesisState     `json:"feegrant"`
+	AuthzData        authz.GenesisState        `json:"authz"`
+	UpgradeData      upgrade.GenesisState      `json:"upgrade"`
+	IBCData          ibc.GenesisState          `json:"ibc"`
 }
 
 // GenesisAccount doesn't need pubkey or sequence
 type GenesisAccount struct {
 	Address sdk.Address `json:"address"`
 	Coins   sdk.Coins   `json:"coins"`
+
+	// vesting schedule of investor and team allocations, a continuous vesting
+	// account if the start time is set and a delayed vesting account otherwise
+	OriginalVesting  sdk.Coins `json:"original_vesting,omitempty"`
+	DelegatedFree    sdk.Coins `json:"delegated_free,omitempty"`
+	DelegatedVesting sdk.Coins `json:"delegated_vesting,omitempty"`
+	StartTime        int64     `json:"start_time,omitempty"`
+	EndTime          int64     `json:"end_time,omitempty"`
 }
 
 func NewGenesisAccount(acc *auth.BaseAccount) GenesisAccount {
@@ -42,18 +64,47 @@ func NewGenesisAccount(acc *auth.BaseAccount) GenesisAccount {
 }
 
 func NewGenesisAccountI(acc auth.Account) GenesisAccount {
-	return GenesisAccount{
+	gacc := GenesisAccount{
 		Address: acc.GetAddress(),
 		Coins:   acc.GetCoins(),
 	}
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		gacc.OriginalVesting = vacc.GetOriginalVesting()
+		gacc.DelegatedFree = vacc.GetDelegatedFree()
+		gacc.DelegatedVesting = vacc.GetDelegatedVesting()
+		gacc.StartTime = vacc.GetStartTime()
+		gacc.EndTime = vacc.GetEndTime()
+	}
+	return gacc
 }
 
-// convert GenesisAccount to auth.BaseAccount
-func (ga *GenesisAccount) ToAccount() (acc *auth.BaseAccount) {
-	return &auth.BaseAccount{
+// convert GenesisAccount to an auth.BaseAccount, or to a vesting account
+// if it has an original vesting amount
+func (ga *GenesisAccount) ToAccount() (acc auth.Account) {
+	baseAcc := auth.BaseAccount{
 		Address: ga.Address,
 		Coins:   ga.Coins.Sort(),
 	}
+	if ga.OriginalVesting.IsZero() {
+		return &baseAcc
+	}
+
+	baseVestingAcc := auth.BaseVestingAccount{
+		BaseAccount:      baseAcc,
+		OriginalVesting:  ga.OriginalVesting.Sort(),
+		DelegatedFree:    ga.DelegatedFree.Sort(),
+		DelegatedVesting: ga.DelegatedVesting.Sort(),
+		EndTime:          ga.EndTime,
+	}
+	if ga.StartTime != 0 {
+		return &auth.ContinuousVestingAccount{
+			BaseVestingAccount: baseVestingAcc,
+			StartTime:          ga.StartTime,
+		}
+	}
+	return &auth.DelayedVestingAccount{
+		BaseVestingAccount: baseVestingAcc,
+	}
 }
 
 // get app init parameters for server init command
@@ -188,8 +239,15 @@ func GaiaAppGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (genesisState
 
 	// create the final app state
 	genesisState = GenesisState{
-		Accounts:  genaccs,
-		StakeData: stakeData,
+		Accounts:         genaccs,
+		StakeData:        stakeData,
+		SlashingData:     slashing.DefaultGenesisState(),
+		DistributionData: distribution.DefaultGenesisState(),
+		GovData:          gov.DefaultGenesisState(),
+		FeeGrantData:     feegrant.DefaultGenesisState(),
+		AuthzData:        authz.DefaultGenesisState(),
+		UpgradeData:      upgrade.DefaultGenesisState(),
+		IBCData:          ibc.DefaultGenesisState(),
 	}
 	return
 }
@@ -14,7 +14,22 @@ func TestToAccount(t *testing.T) {
 	addr := sdk.Address(priv.PubKey().Address())
 	authAcc := auth.NewBaseAccountWithAddress(addr)
 	genAcc := NewGenesisAccount(&authAcc)
-	require.Equal(t, authAcc, *genAcc.ToAccount())
+	require.Equal(t, &authAcc, genAcc.ToAccount())
+}
+
+func TestToVestingAccount(t *testing.T) {
+	priv := crypto.GenPrivKeyEd25519()
+	addr := sdk.Address(priv.PubKey().Address())
+	coins := sdk.Coins{sdk.NewCoin("steak", 100)}
+
+	continuousAcc := auth.NewContinuousVestingAccount(addr, coins, 1000, 2000)
+	continuousAcc.TrackDelegation(1500, sdk.Coins{sdk.NewCoin("steak", 60)})
+	genAcc := NewGenesisAccountI(&continuousAcc)
+	require.Equal(t, &continuousAcc, genAcc.ToAccount())
+
+	delayedAcc := auth.NewDelayedVestingAccount(addr, coins, 2000)
+	genAcc = NewGenesisAccountI(&delayedAcc)
+	require.Equal(t, &delayedAcc, genAcc.ToAccount())
 }
 
 func TestGaiaAppGenTx(t *testing.T) {
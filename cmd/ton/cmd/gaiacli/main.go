@@ -12,7 +12,10 @@ import (
 	"github.com/tepleton/tepleton-sdk/client/tx"
 	"github.com/tepleton/tepleton-sdk/version"
 	authcmd "github.com/tepleton/tepleton-sdk/x/auth/client/cli"
+	authzcmd "github.com/tepleton/tepleton-sdk/x/authz/client/cli"
 	bankcmd "github.com/tepleton/tepleton-sdk/x/bank/client/cli"
+	distributioncmd "github.com/tepleton/tepleton-sdk/x/distribution/client/cli"
+	feegrantcmd "github.com/tepleton/tepleton-sdk/x/feegrant/client/cli"
 	govcmd "github.com/tepleton/tepleton-sdk/x/gov/client/cli"
 	ibccmd "github.com/tepleton/tepleton-sdk/x/ibc/client/cli"
 	slashingcmd "github.com/tepleton/tepleton-sdk/x/slashing/client/cli"
@@ -88,6 +91,13 @@ func main() {
 			stakecmd.GetCmdQueryValidators("stake", cdc),
 			stakecmd.GetCmdQueryDelegation("stake", cdc),
 			stakecmd.GetCmdQueryDelegations("stake", cdc),
+			stakecmd.GetCmdQueryUnbondingDelegation("stake", cdc),
+			stakecmd.GetCmdQueryUnbondingDelegations("stake", cdc),
+			stakecmd.GetCmdQueryRedelegation("stake", cdc),
+			stakecmd.GetCmdQueryRedelegations("stake", cdc),
+			stakecmd.GetCmdQueryValidatorDelegations("stake", cdc),
+			stakecmd.GetCmdQueryValidatorUnbondingDelegations("stake", cdc),
+			stakecmd.GetCmdQueryValidatorRedelegations("stake", cdc),
 			slashingcmd.GetCmdQuerySigningInfo("slashing", cdc),
 		)...)
 	stakeCmd.AddCommand(
@@ -98,6 +108,7 @@ func main() {
 			stakecmd.GetCmdUnbond("stake", cdc),
 			stakecmd.GetCmdRedelegate("stake", cdc),
 			slashingcmd.GetCmdUnrevoke(cdc),
+			distributioncmd.GetCmdWithdrawRewards(cdc),
 		)...)
 	rootCmd.AddCommand(
 		stakeCmd,
@@ -123,6 +134,43 @@ func main() {
 		govCmd,
 	)
 
+	//Add feegrant commands
+	feeGrantCmd := &cobra.Command{
+		Use:   "feegrant",
+		Short: "Fee allowance subcommands",
+	}
+	feeGrantCmd.AddCommand(
+		client.GetCommands(
+			feegrantcmd.GetCmdQueryFeeAllowance("feegrant", cdc),
+		)...)
+	feeGrantCmd.AddCommand(
+		client.PostCommands(
+			feegrantcmd.GetCmdGrantFeeAllowance(cdc),
+			feegrantcmd.GetCmdRevokeFeeAllowance(cdc),
+		)...)
+	rootCmd.AddCommand(
+		feeGrantCmd,
+	)
+
+	//Add authz commands
+	authzCmd := &cobra.Command{
+		Use:   "authz",
+		Short: "Authorization subcommands",
+	}
+	authzCmd.AddCommand(
+		client.GetCommands(
+			authzcmd.GetCmdQueryAuthorization("authz", cdc),
+		)...)
+	authzCmd.AddCommand(
+		client.PostCommands(
+			authzcmd.GetCmdGrantAuthorization(cdc),
+			authzcmd.GetCmdRevokeAuthorization(cdc),
+			authzcmd.GetCmdExec(cdc),
+		)...)
+	rootCmd.AddCommand(
+		authzCmd,
+	)
+
 	//Add auth and bank commands
 	rootCmd.AddCommand(
 		client.GetCommands(
@@ -131,7 +179,12 @@ func main() {
 	rootCmd.AddCommand(
 		client.PostCommands(
 			bankcmd.SendTxCmd(cdc),
+			authcmd.GetSignCommand(cdc, authcmd.GetAccountDecoder(cdc)),
+			authcmd.GetBroadcastCommand(cdc),
 		)...)
+	rootCmd.AddCommand(
+		authcmd.GetMultiSignCommand(cdc),
+	)
 
 	// add proxy, version and key info
 	rootCmd.AddCommand(
@@ -11,6 +11,7 @@ import (
 	"github.com/tepleton/tepleton/libs/log"
 	tmtypes "github.com/tepleton/tepleton/types"
 
+	"github.com/tepleton/tepleton-sdk/baseapp"
 	"github.com/tepleton/tepleton-sdk/cmd/ton/app"
 	"github.com/tepleton/tepleton-sdk/server"
 )
@@ -28,6 +29,7 @@ func main() {
 	server.AddCommands(ctx, cdc, rootCmd, app.GaiaAppInit(),
 		server.ConstructAppCreator(newApp, "ton"),
 		server.ConstructAppExporter(exportAppStateAndTMValidators, "ton"))
+	rootCmd.AddCommand(server.SnapshotCmd(ctx, "ton"))
 
 	// prepare and add flags
 	executor := cli.PrepareBaseCmd(rootCmd, "GA", app.DefaultNodeHome)
@@ -39,7 +41,11 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB) wrsp.Application {
-	return app.NewGaiaApp(logger, db)
+	conf := server.GetBaseConfig()
+	return app.NewGaiaApp(logger, db,
+		baseapp.SetMinimumGasPrices(conf.MinGasPrices),
+		baseapp.SetPruning(conf.Pruning, conf.PruningKeepRecent, conf.PruningKeepEvery),
+	)
 }
 
 func exportAppStateAndTMValidators(logger log.Logger, db dbm.DB) (json.RawMessage, []tmtypes.GenesisValidator, error) {
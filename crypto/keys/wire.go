@@ -2,6 +2,7 @@ package keys
 
 import (
 	ccrypto "github.com/tepleton/tepleton-sdk/crypto"
+	"github.com/tepleton/tepleton-sdk/crypto/multisig"
 	amino "github.com/tepleton/go-amino"
 	tcrypto "github.com/tepleton/tepleton/crypto"
 )
@@ -10,6 +11,7 @@ var cdc = amino.NewCodec()
 
 func init() {
 	tcrypto.RegisterAmino(cdc)
+	multisig.RegisterAmino(cdc)
 	cdc.RegisterInterface((*Info)(nil), nil)
 	cdc.RegisterConcrete(ccrypto.PrivKeyLedgerSecp256k1{},
 		"tepleton/PrivKeyLedgerSecp256k1", nil)
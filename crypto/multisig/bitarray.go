@@ -0,0 +1,51 @@
+package multisig
+
+// CompactBitArray is a bit array stored in as few bytes as possible,
+// to record which keys of a threshold public key signed.
+type CompactBitArray struct {
+	Size  int    `json:"size"`
+	Elems []byte `json:"elems"`
+}
+
+// NewCompactBitArray returns a bit array of size bits, all unset
+func NewCompactBitArray(size int) *CompactBitArray {
+	if size <= 0 {
+		return nil
+	}
+	return &CompactBitArray{
+		Size:  size,
+		Elems: make([]byte, (size+7)/8),
+	}
+}
+
+// GetIndex returns whether the bit at index i is set
+func (bA *CompactBitArray) GetIndex(i int) bool {
+	if bA == nil || i < 0 || i >= bA.Size || len(bA.Elems) != (bA.Size+7)/8 {
+		return false
+	}
+	return bA.Elems[i>>3]&(1<<uint(7-(i%8))) > 0
+}
+
+// SetIndex sets the bit at index i, returning false if it is out of range
+func (bA *CompactBitArray) SetIndex(i int, v bool) bool {
+	if bA == nil || i < 0 || i >= bA.Size {
+		return false
+	}
+	if v {
+		bA.Elems[i>>3] |= 1 << uint(7-(i%8))
+	} else {
+		bA.Elems[i>>3] &^= 1 << uint(7-(i%8))
+	}
+	return true
+}
+
+// NumTrueBitsBefore returns the number of bits set before index i
+func (bA *CompactBitArray) NumTrueBitsBefore(i int) int {
+	count := 0
+	for j := 0; j < i; j++ {
+		if bA.GetIndex(j) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,71 @@
+package multisig
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/tepleton/tepleton/crypto"
+)
+
+var _ crypto.Signature = Multisignature{}
+
+// Multisignature is the signature of a threshold public key. It holds the
+// signatures of the keys which signed, ordered as the keys, and a bit array
+// of which keys signed.
+type Multisignature struct {
+	BitArray *CompactBitArray   `json:"bit_array"`
+	Sigs     []crypto.Signature `json:"sigs"`
+}
+
+// NewMultisig returns an empty multisignature for a threshold public key of n keys
+func NewMultisig(n int) *Multisignature {
+	return &Multisignature{BitArray: NewCompactBitArray(n)}
+}
+
+// AddSignature adds the signature of the key at the given index, replacing
+// the former signature of that key if any
+func (mSig *Multisignature) AddSignature(sig crypto.Signature, index int) error {
+	if mSig.BitArray == nil || index < 0 || index >= mSig.BitArray.Size {
+		return errors.New("signature index out of range of the threshold public key")
+	}
+	newSigIndex := mSig.BitArray.NumTrueBitsBefore(index)
+	if mSig.BitArray.GetIndex(index) {
+		mSig.Sigs[newSigIndex] = sig
+		return nil
+	}
+	mSig.BitArray.SetIndex(index, true)
+	mSig.Sigs = append(mSig.Sigs, nil)
+	copy(mSig.Sigs[newSigIndex+1:], mSig.Sigs[newSigIndex:])
+	mSig.Sigs[newSigIndex] = sig
+	return nil
+}
+
+// AddSignatureFromPubKey adds the signature of the given public key,
+// which must be one of the keys of the threshold public key
+func (mSig *Multisignature) AddSignatureFromPubKey(sig crypto.Signature, pubkey crypto.PubKey, keys []crypto.PubKey) error {
+	for i, key := range keys {
+		if key.Equals(pubkey) {
+			return mSig.AddSignature(sig, i)
+		}
+	}
+	return errors.New("public key is not part of the threshold public key")
+}
+
+// Bytes returns the amino encoding of the multisignature
+func (mSig Multisignature) Bytes() []byte {
+	return cdc.MustMarshalBinaryBare(mSig)
+}
+
+// IsZero returns true if the multisignature holds no signature
+func (mSig Multisignature) IsZero() bool {
+	return len(mSig.Sigs) == 0
+}
+
+// Equals returns true if the other signature is an identical multisignature
+func (mSig Multisignature) Equals(other crypto.Signature) bool {
+	otherSig, ok := other.(Multisignature)
+	if !ok {
+		return false
+	}
+	return bytes.Equal(mSig.Bytes(), otherSig.Bytes())
+}
@@ -0,0 +1,125 @@
+package multisig
+
+import (
+	"errors"
+
+	"github.com/tepleton/tepleton/crypto"
+	"github.com/tepleton/tepleton/crypto/tmhash"
+)
+
+var _ crypto.PubKey = PubKeyMultisigThreshold{}
+
+// PubKeyMultisigThreshold is a k-of-n threshold public key. Its signatures
+// are multisignatures from at least K of its public keys, and its address
+// derives from the threshold and the ordered set of public keys.
+type PubKeyMultisigThreshold struct {
+	K       uint            `json:"threshold"`
+	PubKeys []crypto.PubKey `json:"pubkeys"`
+}
+
+// NewPubKeyMultisigThreshold returns a public key requiring the signatures of
+// k of the given public keys. Panics if k is not between 1 and the number of keys.
+func NewPubKeyMultisigThreshold(k int, pubkeys []crypto.PubKey) crypto.PubKey {
+	if k <= 0 {
+		panic("threshold k of n multisignature: k <= 0")
+	}
+	pk := PubKeyMultisigThreshold{uint(k), pubkeys}
+	if err := pk.validate(); err != nil {
+		panic(err)
+	}
+	return pk
+}
+
+// a threshold public key requires between 1 and all of its public keys
+func (pk PubKeyMultisigThreshold) validate() error {
+	if pk.K == 0 {
+		return errors.New("threshold k of n multisignature: k <= 0")
+	}
+	if uint(len(pk.PubKeys)) < pk.K {
+		return errors.New("threshold k of n multisignature: len(pubkeys) < k")
+	}
+	return nil
+}
+
+// thresholdPubKeyAmino is the amino representation of the threshold public
+// key, it is decoded through it to reject the keys with an invalid threshold
+type thresholdPubKeyAmino struct {
+	K       uint            `json:"threshold"`
+	PubKeys []crypto.PubKey `json:"pubkeys"`
+}
+
+// MarshalAmino implements the amino representation of the key
+func (pk PubKeyMultisigThreshold) MarshalAmino() (thresholdPubKeyAmino, error) {
+	return thresholdPubKeyAmino(pk), nil
+}
+
+// UnmarshalAmino decodes the amino representation of the key, failing if
+// the threshold isn't between 1 and the number of public keys
+func (pk *PubKeyMultisigThreshold) UnmarshalAmino(repr thresholdPubKeyAmino) error {
+	decoded := PubKeyMultisigThreshold(repr)
+	if err := decoded.validate(); err != nil {
+		return err
+	}
+	*pk = decoded
+	return nil
+}
+
+// VerifyBytes expects sig to be a Multisignature whose bit array has one bit
+// per public key, set for each key which signed, with the signatures ordered as
+// the keys. It returns true if at least K signatures are present and all of
+// them are valid, and always false for a key with an invalid threshold.
+func (pk PubKeyMultisigThreshold) VerifyBytes(msg []byte, sig crypto.Signature) bool {
+	if pk.validate() != nil {
+		return false
+	}
+	multisig, ok := sig.(Multisignature)
+	if !ok {
+		return false
+	}
+	size := len(pk.PubKeys)
+	if multisig.BitArray == nil || multisig.BitArray.Size != size {
+		return false
+	}
+	if len(multisig.Sigs) < int(pk.K) || multisig.BitArray.NumTrueBitsBefore(size) != len(multisig.Sigs) {
+		return false
+	}
+	sigIndex := 0
+	for i := 0; i < size; i++ {
+		if !multisig.BitArray.GetIndex(i) {
+			continue
+		}
+		if !pk.PubKeys[i].VerifyBytes(msg, multisig.Sigs[sigIndex]) {
+			return false
+		}
+		sigIndex++
+	}
+	return true
+}
+
+// Bytes returns the amino encoding of the threshold public key
+func (pk PubKeyMultisigThreshold) Bytes() []byte {
+	return cdc.MustMarshalBinaryBare(pk)
+}
+
+// Address returns the truncated hash of the threshold public key
+func (pk PubKeyMultisigThreshold) Address() crypto.Address {
+	return crypto.Address(tmhash.Sum(pk.Bytes()))
+}
+
+// Equals returns true if the other key is a threshold public key with the
+// same threshold and the same public keys in the same order
+func (pk PubKeyMultisigThreshold) Equals(other crypto.PubKey) bool {
+	otherKey, ok := other.(PubKeyMultisigThreshold)
+	if !ok {
+		return false
+	}
+	if pk.K != otherKey.K || len(pk.PubKeys) != len(otherKey.PubKeys) {
+		return false
+	}
+	for i := 0; i < len(pk.PubKeys); i++ {
+		if !pk.PubKeys[i].Equals(otherKey.PubKeys[i]) {
+			return false
+		}
+	}
+	return true
+}
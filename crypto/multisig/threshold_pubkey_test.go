@@ -0,0 +1,154 @@
+package multisig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tepleton/tepleton/crypto"
+)
+
+func generatePubKeysAndSignatures(n int, msg []byte) (pubkeys []crypto.PubKey, signatures []crypto.Signature) {
+	pubkeys = make([]crypto.PubKey, n)
+	signatures = make([]crypto.Signature, n)
+	for i := 0; i < n; i++ {
+		var privkey crypto.PrivKey
+		if i%2 == 0 {
+			privkey = crypto.GenPrivKeyEd25519()
+		} else {
+			privkey = crypto.GenPrivKeySecp256k1()
+		}
+		pubkeys[i] = privkey.PubKey()
+		signatures[i], _ = privkey.Sign(msg)
+	}
+	return
+}
+
+func TestThresholdMultisigValid(t *testing.T) {
+	msg := []byte{1, 2, 3, 4}
+	pubkeys, sigs := generatePubKeysAndSignatures(5, msg)
+	multisigKey := NewPubKeyMultisigThreshold(2, pubkeys)
+	multisignature := NewMultisig(5)
+
+	// a single signature is below the threshold
+	require.NoError(t, multisignature.AddSignatureFromPubKey(sigs[4], pubkeys[4], pubkeys))
+	require.False(t, multisigKey.VerifyBytes(msg, *multisignature))
+
+	// signatures can be added in any order
+	require.NoError(t, multisignature.AddSignatureFromPubKey(sigs[1], pubkeys[1], pubkeys))
+	require.True(t, multisigKey.VerifyBytes(msg, *multisignature))
+	require.Equal(t, []crypto.Signature{sigs[1], sigs[4]}, multisignature.Sigs)
+
+	// adding the same signature twice doesn't count it twice
+	require.NoError(t, multisignature.AddSignatureFromPubKey(sigs[1], pubkeys[1], pubkeys))
+	require.Equal(t, 2, len(multisignature.Sigs))
+	require.True(t, multisigKey.VerifyBytes(msg, *multisignature))
+
+	require.NoError(t, multisignature.AddSignatureFromPubKey(sigs[0], pubkeys[0], pubkeys))
+	require.True(t, multisigKey.VerifyBytes(msg, *multisignature))
+	require.False(t, multisigKey.VerifyBytes([]byte{1, 2, 3}, *multisignature))
+}
+
+func TestThresholdMultisigInvalid(t *testing.T) {
+	msg := []byte{1, 2, 3, 4}
+	pubkeys, sigs := generatePubKeysAndSignatures(3, msg)
+	multisigKey := NewPubKeyMultisigThreshold(2, pubkeys)
+
+	// signature of a key which isn't part of the threshold public key
+	otherKeys, otherSigs := generatePubKeysAndSignatures(1, msg)
+	multisignature := NewMultisig(3)
+	require.Error(t, multisignature.AddSignatureFromPubKey(otherSigs[0], otherKeys[0], pubkeys))
+
+	// signature of a key set at the index of another key
+	require.NoError(t, multisignature.AddSignature(sigs[0], 0))
+	require.NoError(t, multisignature.AddSignature(sigs[2], 1))
+	require.False(t, multisigKey.VerifyBytes(msg, *multisignature))
+
+	// bit array not matching the signatures
+	multisignature = NewMultisig(3)
+	require.NoError(t, multisignature.AddSignature(sigs[0], 0))
+	require.NoError(t, multisignature.AddSignature(sigs[1], 1))
+	multisignature.BitArray.SetIndex(2, true)
+	require.False(t, multisigKey.VerifyBytes(msg, *multisignature))
+
+	// bit array of the wrong size
+	multisignature = NewMultisig(4)
+	require.NoError(t, multisignature.AddSignature(sigs[0], 0))
+	require.NoError(t, multisignature.AddSignature(sigs[1], 1))
+	require.False(t, multisigKey.VerifyBytes(msg, *multisignature))
+
+	// not a multisignature
+	require.False(t, multisigKey.VerifyBytes(msg, sigs[0]))
+}
+
+func TestThresholdMultisigAddress(t *testing.T) {
+	pubkeys, _ := generatePubKeysAndSignatures(3, []byte{1})
+	multisigKey := NewPubKeyMultisigThreshold(2, pubkeys)
+
+	// the address derives from the threshold and the ordered keys
+	require.Equal(t, multisigKey.Address(), NewPubKeyMultisigThreshold(2, pubkeys).Address())
+	require.NotEqual(t, multisigKey.Address(), NewPubKeyMultisigThreshold(3, pubkeys).Address())
+	reordered := []crypto.PubKey{pubkeys[1], pubkeys[0], pubkeys[2]}
+	require.NotEqual(t, multisigKey.Address(), NewPubKeyMultisigThreshold(2, reordered).Address())
+
+	require.True(t, multisigKey.Equals(NewPubKeyMultisigThreshold(2, pubkeys)))
+	require.False(t, multisigKey.Equals(NewPubKeyMultisigThreshold(2, reordered)))
+	require.False(t, multisigKey.Equals(pubkeys[0]))
+}
+
+func TestThresholdMultisigAmino(t *testing.T) {
+	msg := []byte{1, 2, 3, 4}
+	pubkeys, sigs := generatePubKeysAndSignatures(3, msg)
+	multisigKey := NewPubKeyMultisigThreshold(2, pubkeys)
+	multisignature := NewMultisig(3)
+	require.NoError(t, multisignature.AddSignature(sigs[0], 0))
+	require.NoError(t, multisignature.AddSignature(sigs[2], 2))
+
+	var decodedKey crypto.PubKey
+	require.NoError(t, cdc.UnmarshalBinaryBare(multisigKey.Bytes(), &decodedKey))
+	require.True(t, multisigKey.Equals(decodedKey))
+
+	var decodedSig crypto.Signature
+	require.NoError(t, cdc.UnmarshalBinaryBare(multisignature.Bytes(), &decodedSig))
+	require.True(t, multisignature.Equals(decodedSig))
+	require.True(t, decodedKey.VerifyBytes(msg, decodedSig))
+}
+
+func TestThresholdMultisigInvalidThreshold(t *testing.T) {
+	msg := []byte{1, 2, 3, 4}
+	pubkeys, sigs := generatePubKeysAndSignatures(2, msg)
+	require.Panics(t, func() { NewPubKeyMultisigThreshold(0, pubkeys) })
+	require.Panics(t, func() { NewPubKeyMultisigThreshold(3, pubkeys) })
+
+	// an empty multisignature doesn't satisfy a key without threshold
+	zeroKey := PubKeyMultisigThreshold{0, pubkeys}
+	require.False(t, zeroKey.VerifyBytes(msg, *NewMultisig(2)))
+
+	// nor can all the signatures satisfy a threshold above the number of keys
+	multisignature := NewMultisig(2)
+	require.NoError(t, multisignature.AddSignature(sigs[0], 0))
+	require.NoError(t, multisignature.AddSignature(sigs[1], 1))
+	require.True(t, NewPubKeyMultisigThreshold(2, pubkeys).VerifyBytes(msg, *multisignature))
+	require.False(t, PubKeyMultisigThreshold{3, pubkeys}.VerifyBytes(msg, *multisignature))
+
+	// such keys can't be decoded
+	var decodedKey crypto.PubKey
+	require.Error(t, cdc.UnmarshalBinaryBare(zeroKey.Bytes(), &decodedKey))
+	require.Error(t, cdc.UnmarshalBinaryBare(PubKeyMultisigThreshold{3, pubkeys}.Bytes(), &decodedKey))
+}
+
+func TestCompactBitArray(t *testing.T) {
+	require.Nil(t, NewCompactBitArray(0))
+
+	bA := NewCompactBitArray(10)
+	require.Equal(t, 2, len(bA.Elems))
+	require.True(t, bA.SetIndex(0, true))
+	require.True(t, bA.SetIndex(9, true))
+	require.False(t, bA.SetIndex(10, true))
+	require.True(t, bA.GetIndex(9))
+	require.False(t, bA.GetIndex(5))
+	require.Equal(t, 1, bA.NumTrueBitsBefore(9))
+	require.Equal(t, 2, bA.NumTrueBitsBefore(10))
+	require.True(t, bA.SetIndex(0, false))
+	require.Equal(t, 0, bA.NumTrueBitsBefore(9))
+}
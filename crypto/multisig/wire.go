@@ -0,0 +1,21 @@
+package multisig
+
+import (
+	amino "github.com/tepleton/go-amino"
+	"github.com/tepleton/tepleton/crypto"
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	crypto.RegisterAmino(cdc)
+	RegisterAmino(cdc)
+}
+
+// RegisterAmino registers the threshold public key and its signature in the given (amino) codec.
+func RegisterAmino(cdc *amino.Codec) {
+	cdc.RegisterConcrete(PubKeyMultisigThreshold{},
+		"tepleton/PubKeyMultisigThreshold", nil)
+	cdc.RegisterConcrete(Multisignature{},
+		"tepleton/Multisignature", nil)
+}
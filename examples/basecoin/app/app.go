@@ -68,10 +68,14 @@ func NewBasecoinApp(logger log.Logger, db dbm.DB) *BasecoinApp {
 	app.coinKeeper = bank.NewKeeper(app.accountMapper)
 	app.ibcMapper = ibc.NewMapper(app.cdc, app.keyIBC, app.RegisterCodespace(ibc.DefaultCodespace))
 
+	// register ibc packet routes
+	ibcRouter := ibc.NewRouter().
+		AddRoute(ibc.TransferRoute, ibc.NewTransferHandler(app.coinKeeper))
+
 	// register message routes
 	app.Router().
 		AddRoute("bank", bank.NewHandler(app.coinKeeper)).
-		AddRoute("ibc", ibc.NewHandler(app.ibcMapper, app.coinKeeper))
+		AddRoute("ibc", ibc.NewHandler(app.ibcMapper, app.coinKeeper, ibcRouter))
 
 	// perform initialization logic
 	app.SetInitChainer(app.initChainer)
@@ -38,6 +38,14 @@ func (ms multiStore) MountStoreWithDB(key sdk.StoreKey, typ sdk.StoreType, db db
 	ms.kv[key] = kvStore{store: make(map[string][]byte)}
 }
 
+func (ms multiStore) SetPruning(pruning sdk.PruningOptions) {
+	panic("not implemented")
+}
+
+func (ms multiStore) CacheMultiStoreWithVersion(version int64) (sdk.CacheMultiStore, error) {
+	panic("not implemented")
+}
+
 func (ms multiStore) LoadLatestVersion() error {
 	return nil
 }
@@ -12,3 +12,28 @@ type GenTx struct {
 	Overwrite bool
 	IP        string
 }
+
+// Node-local settings of the application, which don't need to be the same on
+// every node. They are read from the flags of the start command, or from
+// config.toml.
+type BaseConfig struct {
+	// Minimum gas prices a transaction must pay for to be accepted in the
+	// mempool, such as "0.025steak,0.1photino". Empty accepts any fee.
+	MinGasPrices string `mapstructure:"minimum_gas_prices"`
+
+	// Which old versions of the state are kept: "nothing" for archive nodes,
+	// "everything", "default", or "custom" using the numbers of versions below
+	Pruning           string `mapstructure:"pruning"`
+	PruningKeepRecent int64  `mapstructure:"pruning_keep_recent"`
+	PruningKeepEvery  int64  `mapstructure:"pruning_keep_every"`
+}
+
+// DefaultBaseConfig returns the default node-local settings
+func DefaultBaseConfig() BaseConfig {
+	return BaseConfig{
+		MinGasPrices:      "",
+		Pruning:           "default",
+		PruningKeepRecent: 0,
+		PruningKeepEvery:  0,
+	}
+}
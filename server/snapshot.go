@@ -0,0 +1,142 @@
+package server
+
+import (
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	dbm "github.com/tepleton/tepleton/libs/db"
+
+	"github.com/tepleton/tepleton-sdk/store"
+)
+
+const (
+	flagSnapshotDir = "snapshot-dir"
+	flagAppHash     = "app-hash"
+)
+
+// SnapshotCmd creates, restores and lists the snapshots of the state of the
+// application, stored in the database of the given name
+func SnapshotCmd(ctx *Context, dbName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Manage snapshots of the application state",
+	}
+	cmd.AddCommand(
+		snapshotCreateCmd(ctx, dbName),
+		snapshotRestoreCmd(ctx, dbName),
+		snapshotListCmd(ctx, dbName),
+	)
+	cmd.PersistentFlags().String(flagSnapshotDir, "", "Directory of the snapshots, defaults to <home>/data/snapshots")
+	return cmd
+}
+
+func snapshotCreateCmd(ctx *Context, dbName string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create [height]",
+		Short: "Create a snapshot of the application state at a committed height",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			manager, db, err := openSnapshotManager(dbName)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			metadata, err := manager.Create(height)
+			if err != nil {
+				return err
+			}
+			ctx.Logger.Info("Created snapshot", "height", metadata.Height,
+				"chunks", len(metadata.Chunks), "app_hash", metadata.AppHash)
+			return nil
+		},
+	}
+}
+
+func snapshotRestoreCmd(ctx *Context, dbName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore [height]",
+		Short: "Restore the application state from a snapshot, into an empty node",
+		Long: `Restore the application state from a snapshot, into an empty node.
+The restored state is checked against the trusted app hash of the height,
+which must be taken from a block header of the chain, not from the snapshot.
+The Tendermint state of the node must be brought to the same height
+separately.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			appHash, err := hex.DecodeString(viper.GetString(flagAppHash))
+			if err != nil {
+				return fmt.Errorf("invalid app hash: %v", err)
+			}
+			if len(appHash) == 0 {
+				return fmt.Errorf("the --%s flag is required", flagAppHash)
+			}
+			manager, db, err := openSnapshotManager(dbName)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			err = manager.Restore(height, appHash)
+			if err != nil {
+				return err
+			}
+			ctx.Logger.Info("Restored snapshot", "height", height)
+			return nil
+		},
+	}
+	cmd.Flags().String(flagAppHash, "", "Trusted app hash of the snapshot height, in hex")
+	return cmd
+}
+
+func snapshotListCmd(ctx *Context, dbName string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the snapshots of the application state",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, db, err := openSnapshotManager(dbName)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			snapshots, err := manager.List()
+			if err != nil {
+				return err
+			}
+			for _, snapshot := range snapshots {
+				fmt.Printf("height: %d\tformat: %d\tchunks: %d\tapp hash: %s\n",
+					snapshot.Height, snapshot.Format, len(snapshot.Chunks), snapshot.AppHash)
+			}
+			return nil
+		},
+	}
+}
+
+// open the application database and the snapshot manager of its multistore
+func openSnapshotManager(dbName string) (*store.SnapshotManager, dbm.DB, error) {
+	dataDir := filepath.Join(viper.GetString("home"), "data")
+	db, err := dbm.NewGoLevelDB(dbName, dataDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	dir := viper.GetString(flagSnapshotDir)
+	if dir == "" {
+		dir = filepath.Join(dataDir, "snapshots")
+	}
+	return store.NewSnapshotManager(store.NewCommitMultiStore(db), dir), db, nil
+}
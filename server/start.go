@@ -5,6 +5,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	serverconfig "github.com/tepleton/tepleton-sdk/server/config"
 	"github.com/tepleton/tepleton/wrsp/server"
 
 	tcmd "github.com/tepleton/tepleton/cmd/tepleton/commands"
@@ -17,6 +18,14 @@ import (
 const (
 	flagWithTendermint = "with-tepleton"
 	flagAddress        = "address"
+
+	// FlagMinGasPrices is the flag, and config.toml key, of the node's minimum gas prices
+	FlagMinGasPrices = "minimum_gas_prices"
+
+	// Flags, and config.toml keys, of the pruning strategy of the node
+	FlagPruning           = "pruning"
+	FlagPruningKeepRecent = "pruning_keep_recent"
+	FlagPruningKeepEvery  = "pruning_keep_every"
 )
 
 // StartCmd runs the service passed in, either
@@ -39,6 +48,14 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 	// basic flags for wrsp app
 	cmd.Flags().Bool(flagWithTendermint, true, "run wrsp app embedded in-process with tepleton")
 	cmd.Flags().String(flagAddress, "tcp://0.0.0.0:26658", "Listen address")
+	cmd.Flags().String(FlagMinGasPrices, serverconfig.DefaultBaseConfig().MinGasPrices,
+		"Minimum gas prices to accept transactions in the mempool, e.g. 0.025steak,0.1photino")
+	cmd.Flags().String(FlagPruning, serverconfig.DefaultBaseConfig().Pruning,
+		"Pruning strategy: nothing (archive node), everything (only keep the latest state), default or custom")
+	cmd.Flags().Int64(FlagPruningKeepRecent, serverconfig.DefaultBaseConfig().PruningKeepRecent,
+		"Number of recent states to keep with the custom pruning strategy")
+	cmd.Flags().Int64(FlagPruningKeepEvery, serverconfig.DefaultBaseConfig().PruningKeepEvery,
+		"Keep the states at a multiple of this height with the custom pruning strategy, none if 0")
 
 	// AddNodeFlags adds support for all tepleton-specific command line options
 	tcmd.AddNodeFlags(cmd)
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/tepleton/tepleton-sdk/client"
+	serverconfig "github.com/tepleton/tepleton-sdk/server/config"
 	"github.com/tepleton/tepleton-sdk/version"
 	"github.com/tepleton/tepleton-sdk/wire"
 	tcmd "github.com/tepleton/tepleton/cmd/tepleton/commands"
@@ -90,6 +91,18 @@ func interceptLoadConfig() (conf *cfg.Config, err error) {
 	return
 }
 
+// GetBaseConfig returns the node-local settings of the application, read from
+// the flags of the start command or from config.toml, on top of the defaults
+func GetBaseConfig() serverconfig.BaseConfig {
+	conf := serverconfig.DefaultBaseConfig()
+	err := viper.Unmarshal(&conf)
+	if err != nil {
+		// TODO: Handle with #870
+		panic(err)
+	}
+	return conf
+}
+
 // add server commands
 func AddCommands(
 	ctx *Context, cdc *wire.Codec,
@@ -14,19 +14,17 @@ import (
 )
 
 const (
-	defaultIAVLCacheSize  = 10000
-	defaultIAVLNumRecent  = 100
-	defaultIAVLStoreEvery = 10000
+	defaultIAVLCacheSize = 10000
 )
 
-// load the iavl store
-func LoadIAVLStore(db dbm.DB, id CommitID) (CommitStore, error) {
+// load the iavl store, pruning its old versions according to the options
+func LoadIAVLStore(db dbm.DB, id CommitID, pruning PruningOptions) (CommitStore, error) {
 	tree := iavl.NewVersionedTree(db, defaultIAVLCacheSize)
 	_, err := tree.LoadVersion(id.Version)
 	if err != nil {
 		return nil, err
 	}
-	store := newIAVLStore(tree, defaultIAVLNumRecent, defaultIAVLStoreEvery)
+	store := newIAVLStore(tree, pruning.KeepRecent, pruning.KeepEvery)
 	return store, nil
 }
 
@@ -219,6 +217,181 @@ func (st *iavlStore) Query(req wrsp.RequestQuery) (res wrsp.ResponseQuery) {
 
 //----------------------------------------
 
+var _ KVStore = (*iavlVersionStore)(nil)
+var _ CommitStore = (*iavlVersionStore)(nil)
+
+// number of key/value pairs read at once by the iterators of a past version
+const iavlVersionPageSize = 100
+
+// iavlVersionStore is a read-only view of an iavlStore as it was committed
+// at a past version. It reads from the versions kept by the tree in use
+// rather than loading the tree again.
+type iavlVersionStore struct {
+	tree *iavl.VersionedTree
+	id   CommitID
+}
+
+// view of the store at a committed version, failing if it was pruned
+func (st *iavlStore) versionStore(id CommitID) (*iavlVersionStore, error) {
+	if !st.tree.VersionExists(id.Version) {
+		return nil, fmt.Errorf("version %d does not exist", id.Version)
+	}
+	return &iavlVersionStore{tree: st.tree, id: id}, nil
+}
+
+// Implements Committer.
+func (vs *iavlVersionStore) Commit() CommitID {
+	panic("cannot commit a past version")
+}
+
+// Implements Committer.
+func (vs *iavlVersionStore) LastCommitID() CommitID {
+	return vs.id
+}
+
+// Implements Store.
+func (vs *iavlVersionStore) GetStoreType() StoreType {
+	return sdk.StoreTypeIAVL
+}
+
+// Implements Store.
+func (vs *iavlVersionStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(vs)
+}
+
+// Implements KVStore.
+func (vs *iavlVersionStore) Set(key, value []byte) {
+	panic("cannot write to a past version")
+}
+
+// Implements KVStore.
+func (vs *iavlVersionStore) Delete(key []byte) {
+	panic("cannot write to a past version")
+}
+
+// Implements KVStore.
+func (vs *iavlVersionStore) Get(key []byte) (value []byte) {
+	_, v := vs.tree.GetVersioned(key, vs.id.Version)
+	return v
+}
+
+// Implements KVStore.
+func (vs *iavlVersionStore) Has(key []byte) (exists bool) {
+	return vs.Get(key) != nil
+}
+
+// Implements KVStore
+func (vs *iavlVersionStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{vs, prefix}
+}
+
+// Implements KVStore.
+func (vs *iavlVersionStore) Iterator(start, end []byte) Iterator {
+	return newIAVLVersionIterator(vs.tree, vs.id.Version, start, end)
+}
+
+// Implements KVStore. The key/value pairs of the domain are all read before
+// iterating, as the tree can only be read by ascending pages.
+func (vs *iavlVersionStore) ReverseIterator(start, end []byte) Iterator {
+	iter := newIAVLVersionIterator(vs.tree, vs.id.Version, start, end)
+	var items []cmn.KVPair
+	for ; iter.Valid(); iter.Next() {
+		items = append(items, cmn.KVPair{Key: iter.Key(), Value: iter.Value()})
+	}
+	iter.Close()
+	for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+		items[i], items[j] = items[j], items[i]
+	}
+	return &iavlVersionIterator{start: start, end: end, items: items}
+}
+
+// Implements Iterator, reading a past version of a tree by ascending pages.
+type iavlVersionIterator struct {
+	tree    *iavl.VersionedTree
+	version int64
+
+	// Domain
+	start, end []byte
+
+	items []cmn.KVPair // rest of the current page
+	next  []byte       // start of the next page, nil after the last one
+}
+
+var _ Iterator = (*iavlVersionIterator)(nil)
+
+func newIAVLVersionIterator(tree *iavl.VersionedTree, version int64, start, end []byte) *iavlVersionIterator {
+	iter := &iavlVersionIterator{
+		tree:    tree,
+		version: version,
+		start:   cp(start),
+		end:     cp(end),
+	}
+	iter.readPage(iter.start)
+	return iter
+}
+
+// read the page of the domain beginning at a key
+func (iter *iavlVersionIterator) readPage(start []byte) {
+	keys, values, _, err := iter.tree.GetVersionedRangeWithProof(start, iter.end, iavlVersionPageSize, iter.version)
+	if err != nil {
+		panic(err)
+	}
+	iter.items = make([]cmn.KVPair, len(keys))
+	for i := range keys {
+		iter.items[i] = cmn.KVPair{Key: keys[i], Value: values[i]}
+	}
+	iter.next = nil
+	if len(keys) == iavlVersionPageSize {
+		// the smallest key after the last one of the page
+		iter.next = append(cp(keys[len(keys)-1]), 0x00)
+	}
+}
+
+// Implements Iterator.
+func (iter *iavlVersionIterator) Domain() (start, end []byte) {
+	return iter.start, iter.end
+}
+
+// Implements Iterator.
+func (iter *iavlVersionIterator) Valid() bool {
+	return len(iter.items) > 0
+}
+
+// Implements Iterator.
+func (iter *iavlVersionIterator) Next() {
+	iter.assertValid()
+	iter.items = iter.items[1:]
+	if len(iter.items) == 0 && iter.next != nil {
+		iter.readPage(iter.next)
+	}
+}
+
+// Implements Iterator.
+func (iter *iavlVersionIterator) Key() []byte {
+	iter.assertValid()
+	return iter.items[0].Key
+}
+
+// Implements Iterator.
+func (iter *iavlVersionIterator) Value() []byte {
+	iter.assertValid()
+	return iter.items[0].Value
+}
+
+// Implements Iterator.
+func (iter *iavlVersionIterator) Close() {
+	iter.items = nil
+	iter.next = nil
+}
+
+func (iter *iavlVersionIterator) assertValid() {
+	if !iter.Valid() {
+		panic("iavlVersionIterator is invalid")
+	}
+}
+
+//----------------------------------------
+
 // Implements Iterator.
 type iavlIterator struct {
 	// Underlying store
@@ -266,6 +266,54 @@ func nextVersion(iavl *iavlStore) {
 	iavl.Set(key, value)
 	iavl.Commit()
 }
+func TestIAVLVersionStore(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewVersionedTree(db, cacheSize)
+	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+
+	// more pairs than a page of the iterators
+	n := 2*iavlVersionPageSize + 10
+	for i := 0; i < n; i++ {
+		iavlStore.Set([]byte(fmt.Sprintf("key%03d", i)), []byte("v1"))
+	}
+	id1 := iavlStore.Commit()
+	iavlStore.Set([]byte("key000"), []byte("v2"))
+	iavlStore.Delete([]byte("key001"))
+	iavlStore.Set([]byte("later"), []byte("v2"))
+	iavlStore.Commit()
+
+	vs, err := iavlStore.versionStore(id1)
+	require.Nil(t, err)
+	require.Equal(t, id1, vs.LastCommitID())
+	require.Equal(t, []byte("v1"), vs.Get([]byte("key000")))
+	require.True(t, vs.Has([]byte("key001")))
+	require.False(t, vs.Has([]byte("later")))
+	require.Panics(t, func() { vs.Set([]byte("key"), []byte("value")) })
+
+	i := 0
+	iter := vs.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		require.Equal(t, []byte(fmt.Sprintf("key%03d", i)), iter.Key())
+		require.Equal(t, []byte("v1"), iter.Value())
+		i++
+	}
+	iter.Close()
+	require.Equal(t, n, i)
+
+	iter = vs.ReverseIterator([]byte("key100"), []byte("key200"))
+	for i = 199; iter.Valid(); iter.Next() {
+		require.Equal(t, []byte(fmt.Sprintf("key%03d", i)), iter.Key())
+		i--
+	}
+	iter.Close()
+	require.Equal(t, 99, i)
+
+	// the current version is left untouched
+	require.Equal(t, []byte("v2"), iavlStore.Get([]byte("key000")))
+	_, err = iavlStore.versionStore(CommitID{Version: 10})
+	require.NotNil(t, err)
+}
+
 func TestIAVLDefaultPruning(t *testing.T) {
 	//Expected stored / deleted version numbers for:
 	//numRecent = 5, storeEvery = 3
@@ -0,0 +1,100 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/tepleton/iavl"
+)
+
+// MultiStoreProof is returned by a proven rootMultiStore query. It chains
+// the substore iavl proof up to the app hash by carrying the commit info of
+// every mounted store, from which the simple merkle root can be recomputed.
+type MultiStoreProof struct {
+	StoreInfos []storeInfo
+	StoreName  string
+	RangeProof *iavl.RangeProof
+}
+
+// build a MultiStoreProof from the iavl proof bytes of a substore query
+func buildMultiStoreProof(iavlProof []byte, storeName string, storeInfos []storeInfo) ([]byte, error) {
+	var rangeProof iavl.RangeProof
+	err := cdc.UnmarshalBinary(iavlProof, &rangeProof)
+	if err != nil {
+		return nil, err
+	}
+
+	// the verifier requires the store infos sorted by name
+	sorted := make([]storeInfo, len(storeInfos))
+	copy(sorted, storeInfos)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	proof := MultiStoreProof{
+		StoreInfos: sorted,
+		StoreName:  storeName,
+		RangeProof: &rangeProof,
+	}
+	return cdc.MarshalBinary(proof)
+}
+
+// VerifyMultiStoreCommitInfo checks that the store infos hash to the app
+// hash and returns the commit hash of the named substore. The store infos
+// must be strictly sorted by name: the hash only keeps one store info per
+// name, so a duplicated name could otherwise carry a forged substore hash.
+func VerifyMultiStoreCommitInfo(storeName string, storeInfos []storeInfo, appHash []byte) ([]byte, error) {
+	infos := make(map[string]storeInfo, len(storeInfos))
+	for i, si := range storeInfos {
+		if i > 0 && si.Name <= storeInfos[i-1].Name {
+			return nil, fmt.Errorf("store infos are not strictly sorted by name at %s", si.Name)
+		}
+		infos[si.Name] = si
+	}
+	si, ok := infos[storeName]
+	if !ok {
+		return nil, fmt.Errorf("store %s not found in commit info", storeName)
+	}
+	substoreCommitHash := si.Core.CommitID.Hash
+
+	ci := commitInfo{
+		Version:    -1, // not used in the hash
+		StoreInfos: storeInfos,
+	}
+	if !bytes.Equal(appHash, ci.Hash()) {
+		return nil, fmt.Errorf("commit info does not match app hash %X", appHash)
+	}
+	return substoreCommitHash, nil
+}
+
+// VerifyRangeProof checks that the key is committed with the given value
+// under the substore commit hash.
+func VerifyRangeProof(key, value []byte, substoreCommitHash []byte, rangeProof *iavl.RangeProof) error {
+	if rangeProof == nil {
+		return fmt.Errorf("missing iavl proof")
+	}
+	err := rangeProof.Verify(substoreCommitHash)
+	if err != nil {
+		return err
+	}
+	return rangeProof.VerifyItem(key, value)
+}
+
+// VerifyProof decodes the proof of a proven rootMultiStore query and checks
+// that the key is committed with the given value in the named substore of
+// the state identified by appHash.
+func VerifyProof(proofBytes []byte, storeName string, key, value, appHash []byte) error {
+	var proof MultiStoreProof
+	err := cdc.UnmarshalBinary(proofBytes, &proof)
+	if err != nil {
+		return err
+	}
+	if proof.StoreName != storeName {
+		return fmt.Errorf("proof is for store %s, expected %s", proof.StoreName, storeName)
+	}
+
+	substoreCommitHash, err := VerifyMultiStoreCommitInfo(storeName, proof.StoreInfos, appHash)
+	if err != nil {
+		return err
+	}
+	return VerifyRangeProof(key, value, substoreCommitHash, proof.RangeProof)
+}
@@ -25,6 +25,7 @@ const (
 type rootMultiStore struct {
 	db           dbm.DB
 	lastCommitID CommitID
+	pruning      PruningOptions
 	storesParams map[StoreKey]storeParams
 	stores       map[StoreKey]CommitStore
 	keysByName   map[string]StoreKey
@@ -37,6 +38,7 @@ var _ Queryable = (*rootMultiStore)(nil)
 func NewCommitMultiStore(db dbm.DB) *rootMultiStore {
 	return &rootMultiStore{
 		db:           db,
+		pruning:      sdk.PruneDefault,
 		storesParams: make(map[StoreKey]storeParams),
 		stores:       make(map[StoreKey]CommitStore),
 		keysByName:   make(map[string]StoreKey),
@@ -64,6 +66,11 @@ func (rs *rootMultiStore) MountStoreWithDB(key StoreKey, typ StoreType, db dbm.D
 	rs.keysByName[key.Name()] = key
 }
 
+// Implements CommitMultiStore.
+func (rs *rootMultiStore) SetPruning(pruning PruningOptions) {
+	rs.pruning = pruning
+}
+
 // Implements CommitMultiStore.
 func (rs *rootMultiStore) GetCommitStore(key StoreKey) CommitStore {
 	return rs.stores[key]
@@ -117,6 +124,18 @@ func (rs *rootMultiStore) LoadVersion(ver int64) error {
 		newStores[key] = store
 	}
 
+	// Load the transient stores, which are not part of the commits
+	for key, storeParams := range rs.storesParams {
+		if storeParams.typ != sdk.StoreTypeTransient {
+			continue
+		}
+		store, err := rs.loadCommitStoreFromParams(CommitID{}, storeParams)
+		if err != nil {
+			return fmt.Errorf("failed to load rootMultiStore: %v", err)
+		}
+		newStores[key] = store
+	}
+
 	// If any CommitStoreLoaders were not used, return error.
 	for key := range rs.storesParams {
 		if _, ok := newStores[key]; !ok {
@@ -173,6 +192,50 @@ func (rs *rootMultiStore) CacheMultiStore() CacheMultiStore {
 	return newCacheMultiStoreFromRMS(rs)
 }
 
+// Implements CommitMultiStore.
+func (rs *rootMultiStore) CacheMultiStoreWithVersion(version int64) (CacheMultiStore, error) {
+	cInfo, err := getCommitInfo(rs.db, version)
+	if err != nil {
+		return nil, err
+	}
+	commitIDs := make(map[string]CommitID, len(cInfo.StoreInfos))
+	for _, storeInfo := range cInfo.StoreInfos {
+		commitIDs[storeInfo.Name] = storeInfo.Core.CommitID
+	}
+
+	stores := make(map[StoreKey]CommitStore, len(rs.stores))
+	for key, store := range rs.stores {
+		switch store.GetStoreType() {
+		case sdk.StoreTypeIAVL:
+			// read the version from the tree in use, leaving it untouched
+			st, ok := store.(*iavlStore)
+			if !ok {
+				return nil, fmt.Errorf("store %s cannot be read at past versions", key.Name())
+			}
+			id, ok := commitIDs[key.Name()]
+			if !ok {
+				return nil, fmt.Errorf("store %s was not committed at version %d", key.Name(), version)
+			}
+			versioned, err := st.versionStore(id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load store %s at version %d: %v", key.Name(), version, err)
+			}
+			stores[key] = versioned
+		case sdk.StoreTypeTransient:
+			// the transient data of past blocks is gone
+			stores[key] = newTransientStore()
+		default:
+			stores[key] = store
+		}
+	}
+	versioned := &rootMultiStore{
+		db:         rs.db,
+		stores:     stores,
+		keysByName: rs.keysByName,
+	}
+	return newCacheMultiStoreFromRMS(versioned), nil
+}
+
 // Implements MultiStore.
 func (rs *rootMultiStore) GetStore(key StoreKey) Store {
 	return rs.stores[key]
@@ -206,7 +269,8 @@ func (rs *rootMultiStore) getStoreByName(name string) Store {
 // Query calls substore.Query with the same `req` where `req.Path` is
 // modified to remove the substore prefix.
 // Ie. `req.Path` here is `/<substore>/<path>`, and trimmed to `/<path>` for the substore.
-// TODO: add proof for `multistore -> substore`.
+// If a proof is requested, the substore proof is wrapped in a MultiStoreProof
+// so it can be verified against the app hash.
 func (rs *rootMultiStore) Query(req wrsp.RequestQuery) wrsp.ResponseQuery {
 	// Query just routes this to a substore.
 	path := req.Path
@@ -229,6 +293,20 @@ func (rs *rootMultiStore) Query(req wrsp.RequestQuery) wrsp.ResponseQuery {
 	// trim the path and make the query
 	req.Path = subpath
 	res := queryable.Query(req)
+	if !req.Prove || len(res.Proof) == 0 {
+		return res
+	}
+
+	// add proof for `multistore -> substore`
+	cInfo, errInfo := getCommitInfo(rs.db, res.Height)
+	if errInfo != nil {
+		return sdk.ErrInternal(errInfo.Error()).QueryResult()
+	}
+	proof, errProof := buildMultiStoreProof(res.Proof, storeName, cInfo.StoreInfos)
+	if errProof != nil {
+		return sdk.ErrInternal(errProof.Error()).QueryResult()
+	}
+	res.Proof = proof
 	return res
 }
 
@@ -251,19 +329,17 @@ func parsePath(path string) (storeName string, subpath string, err sdk.Error) {
 //----------------------------------------
 
 func (rs *rootMultiStore) loadCommitStoreFromParams(id CommitID, params storeParams) (store CommitStore, err error) {
-	var db dbm.DB
-	if params.db != nil {
-		db = dbm.NewPrefixDB(params.db, []byte("s/_/"))
-	} else {
-		db = dbm.NewPrefixDB(rs.db, []byte("s/k:"+params.key.Name()+"/"))
-	}
+	db := rs.getStoreDB(params.key.Name())
 	switch params.typ {
 	case sdk.StoreTypeMulti:
 		panic("recursive MultiStores not yet supported")
 		// TODO: id?
 		// return NewCommitMultiStore(db, id)
 	case sdk.StoreTypeIAVL:
-		store, err = LoadIAVLStore(db, id)
+		store, err = LoadIAVLStore(db, id, rs.pruning)
+		return
+	case sdk.StoreTypeTransient:
+		store = newTransientStore()
 		return
 	case sdk.StoreTypeDB:
 		panic("dbm.DB is not a CommitStore")
@@ -272,6 +348,14 @@ func (rs *rootMultiStore) loadCommitStoreFromParams(id CommitID, params storePar
 	}
 }
 
+// database of the store of a name, which needn't be mounted
+func (rs *rootMultiStore) getStoreDB(name string) dbm.DB {
+	if key, ok := rs.keysByName[name]; ok && rs.storesParams[key].db != nil {
+		return dbm.NewPrefixDB(rs.storesParams[key].db, []byte("s/_/"))
+	}
+	return dbm.NewPrefixDB(rs.db, []byte("s/k:"+name+"/"))
+}
+
 func (rs *rootMultiStore) nameToKey(name string) StoreKey {
 	for key := range rs.storesParams {
 		if key.Name() == name {
@@ -381,6 +465,11 @@ func commitStores(version int64, storeMap map[StoreKey]CommitStore) commitInfo {
 		// Commit
 		commitID := store.Commit()
 
+		// Transient stores are emptied, and left out of the commit hash
+		if store.GetStoreType() == sdk.StoreTypeTransient {
+			continue
+		}
+
 		// Record CommitID
 		si := storeInfo{}
 		si.Name = key.Name()
@@ -76,6 +76,65 @@ func TestMultistoreCommitLoad(t *testing.T) {
 	checkStore(t, store, commitID, commitID)
 }
 
+func TestMultistorePruning(t *testing.T) {
+	testCases := []struct {
+		pruning  sdk.PruningOptions
+		kept     []int64
+		released []int64
+	}{
+		{sdk.PruneNothing, []int64{1, 2, 3, 4, 5}, nil},
+		{sdk.PruneEverything, []int64{5}, []int64{1, 2, 3, 4}},
+		{sdk.PruningOptions{KeepRecent: 1, KeepEvery: 2}, []int64{2, 4, 5}, []int64{1, 3}},
+	}
+
+	for _, tc := range testCases {
+		db := dbm.NewMemDB()
+		store := newMultiStoreWithMounts(db)
+		store.SetPruning(tc.pruning)
+		err := store.LoadLatestVersion()
+		require.Nil(t, err)
+		for i := 0; i < 5; i++ {
+			store.Commit()
+		}
+
+		s1 := store.getStoreByName("store1").(*iavlStore)
+		for _, ver := range tc.kept {
+			require.True(t, s1.VersionExists(ver), "version %d with %v", ver, tc.pruning)
+		}
+		for _, ver := range tc.released {
+			require.False(t, s1.VersionExists(ver), "version %d with %v", ver, tc.pruning)
+		}
+	}
+}
+
+func TestMultistoreTransient(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	keyTransient := sdk.NewKVStoreKey("transient")
+	store.MountStoreWithDB(keyTransient, sdk.StoreTypeTransient, nil)
+	require.Nil(t, store.LoadLatestVersion())
+
+	// the transient store is emptied at commit and left out of the hash
+	store.GetKVStore(keyTransient).Set([]byte("key"), []byte("value"))
+	require.Equal(t, []byte("value"), store.GetKVStore(keyTransient).Get([]byte("key")))
+	commitID := store.Commit()
+	require.Nil(t, store.GetKVStore(keyTransient).Get([]byte("key")))
+	persisted := map[StoreKey]CommitStore{}
+	for key, s := range store.stores {
+		if key != keyTransient {
+			persisted[key] = s
+		}
+	}
+	require.Equal(t, hashStores(persisted), commitID.Hash)
+
+	// the transient store is mounted again when loading a version
+	store = newMultiStoreWithMounts(db)
+	store.MountStoreWithDB(keyTransient, sdk.StoreTypeTransient, nil)
+	require.Nil(t, store.LoadLatestVersion())
+	require.Equal(t, commitID, store.LastCommitID())
+	require.NotNil(t, store.GetKVStore(keyTransient))
+}
+
 func TestParsePath(t *testing.T) {
 	_, _, err := parsePath("foo")
 	require.Error(t, err)
@@ -157,6 +216,34 @@ func TestMultiStoreQuery(t *testing.T) {
 	qres = multi.Query(query)
 	require.Equal(t, sdk.ToWRSPCode(sdk.CodespaceRoot, sdk.CodeOK), sdk.WRSPCodeType(qres.Code))
 	require.Equal(t, v2, qres.Value)
+
+	// Test store2 proof against the app hash.
+	err = VerifyProof(qres.Proof, "store2", k2, v2, cid.Hash)
+	require.Nil(t, err)
+	err = VerifyProof(qres.Proof, "store2", k2, v, cid.Hash)
+	require.NotNil(t, err)
+	err = VerifyProof(qres.Proof, "store1", k2, v2, cid.Hash)
+	require.NotNil(t, err)
+	err = VerifyProof(qres.Proof, "store2", k2, v2, []byte("bad apphash"))
+	require.NotNil(t, err)
+
+	// A forged store info prepended for the same store is rejected
+	var proof MultiStoreProof
+	err = cdc.UnmarshalBinary(qres.Proof, &proof)
+	require.Nil(t, err)
+	_, err = VerifyMultiStoreCommitInfo("store2", proof.StoreInfos, cid.Hash)
+	require.Nil(t, err)
+	forged := storeInfo{Name: "store2"}
+	forged.Core.CommitID = CommitID{Version: cid.Version, Hash: []byte("forged root")}
+	var forgedInfos []storeInfo
+	for _, si := range proof.StoreInfos {
+		if si.Name == "store2" {
+			forgedInfos = append(forgedInfos, forged)
+		}
+		forgedInfos = append(forgedInfos, si)
+	}
+	_, err = VerifyMultiStoreCommitInfo("store2", forgedInfos, cid.Hash)
+	require.NotNil(t, err)
 }
 
 //-----------------------------------------------------------------------
@@ -0,0 +1,477 @@
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/tepleton/go-amino"
+	"github.com/tepleton/tepleton/crypto/tmhash"
+	cmn "github.com/tepleton/tepleton/libs/common"
+	dbm "github.com/tepleton/tepleton/libs/db"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+const (
+	// version of the format of the snapshots
+	snapshotFormat = 2
+
+	// approximate size of the nodes in a chunk
+	defaultSnapshotChunkSize = 10 * 1024 * 1024
+
+	snapshotMetadataFile = "metadata.json"
+)
+
+// SnapshotMetadata describes a snapshot of a multistore at a height
+type SnapshotMetadata struct {
+	Height  int64           `json:"height"`
+	Format  uint32          `json:"format"`
+	AppHash cmn.HexBytes    `json:"app_hash"` // commit hash of the multistore at the height
+	Stores  []SnapshotStore `json:"stores"`   // stores, sorted by name
+	Chunks  []cmn.HexBytes  `json:"chunks"`   // sha256 hashes of the chunks, in order
+}
+
+// SnapshotStore is a store of a snapshot, with the root hash of its tree
+type SnapshotStore struct {
+	Name string       `json:"name"`
+	Hash cmn.HexBytes `json:"hash"`
+}
+
+// a node of the tree of a store, as encoded in its database
+type snapshotItem struct {
+	Store string
+	Node  []byte
+}
+
+// the nodes in a chunk file
+type snapshotChunk struct {
+	Items []snapshotItem
+}
+
+// SnapshotManager creates snapshots of the committed versions of a
+// multistore, and restores them into an empty multistore, so that a node
+// can start from a recent state. A snapshot is a directory named after its
+// height, holding the chunks of the nodes of the stores and the metadata.
+//
+// The hash of an IAVL tree depends on the versions at which its nodes were
+// saved, which its key/value pairs don't tell, so the stores are exported
+// as the nodes of their trees at the height, which are rebuilt as they were.
+type SnapshotManager struct {
+	multistore *rootMultiStore
+	dir        string
+	chunkSize  int
+}
+
+// nolint
+func NewSnapshotManager(multistore *rootMultiStore, dir string) *SnapshotManager {
+	return &SnapshotManager{
+		multistore: multistore,
+		dir:        dir,
+		chunkSize:  defaultSnapshotChunkSize,
+	}
+}
+
+// Create writes the snapshot of the multistore at a committed height
+func (m *SnapshotManager) Create(height int64) (SnapshotMetadata, error) {
+	rs := m.multistore
+	cInfo, err := getCommitInfo(rs.db, height)
+	if err != nil {
+		return SnapshotMetadata{}, fmt.Errorf("no commit at height %d: %v", height, err)
+	}
+	dir := m.snapshotDir(height)
+	if _, err := os.Stat(dir); err == nil {
+		return SnapshotMetadata{}, fmt.Errorf("snapshot at height %d already exists", height)
+	}
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return SnapshotMetadata{}, err
+	}
+
+	metadata := SnapshotMetadata{
+		Height:  height,
+		Format:  snapshotFormat,
+		AppHash: cInfo.Hash(),
+	}
+	sort.Slice(cInfo.StoreInfos, func(i, j int) bool {
+		return cInfo.StoreInfos[i].Name < cInfo.StoreInfos[j].Name
+	})
+
+	var items []snapshotItem
+	size := 0
+	flush := func() error {
+		hash, err := m.writeChunk(dir, len(metadata.Chunks), items)
+		if err != nil {
+			return err
+		}
+		metadata.Chunks = append(metadata.Chunks, hash)
+		items, size = nil, 0
+		return nil
+	}
+
+	for _, storeInfo := range cInfo.StoreInfos {
+		db := rs.getStoreDB(storeInfo.Name)
+
+		// make sure the version wasn't pruned, so the snapshot can be restored
+		rootKey := iavlRootKey(height)
+		if !db.Has(rootKey) {
+			return SnapshotMetadata{}, fmt.Errorf("store %s has no tree at height %d", storeInfo.Name, height)
+		}
+		rootHash := db.Get(rootKey)
+		if !bytes.Equal(rootHash, storeInfo.Core.CommitID.Hash) {
+			return SnapshotMetadata{}, fmt.Errorf("hash of store %s doesn't match its commit at height %d", storeInfo.Name, height)
+		}
+		metadata.Stores = append(metadata.Stores, SnapshotStore{Name: storeInfo.Name, Hash: rootHash})
+
+		// only the nodes of the tree at the height, neither the older
+		// versions nor the newer ones
+		err := walkIAVLTree(db, rootHash, func(bz []byte) error {
+			items = append(items, snapshotItem{Store: storeInfo.Name, Node: bz})
+			size += len(storeInfo.Name) + len(bz)
+			if size >= m.chunkSize {
+				return flush()
+			}
+			return nil
+		})
+		if err != nil {
+			return SnapshotMetadata{}, fmt.Errorf("failed to export store %s at height %d: %v", storeInfo.Name, height, err)
+		}
+	}
+	if len(items) > 0 {
+		if err := flush(); err != nil {
+			return SnapshotMetadata{}, err
+		}
+	}
+
+	// the metadata is written last, marking the snapshot as complete
+	bz, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return SnapshotMetadata{}, err
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, snapshotMetadataFile), bz, 0644)
+	if err != nil {
+		return SnapshotMetadata{}, err
+	}
+	return metadata, nil
+}
+
+// Restore writes the snapshot at a height into the multistore, which must
+// be empty, and verifies the commit hash of the restored stores against the
+// trusted app hash of the height, taken from a block header of the chain
+// rather than from the snapshot. The multistore must then be loaded at the
+// height. If the snapshot is rejected, the database must be discarded.
+func (m *SnapshotManager) Restore(height int64, appHash []byte) error {
+	rs := m.multistore
+	if len(appHash) == 0 {
+		return fmt.Errorf("the trusted app hash of the snapshot height is required")
+	}
+	if getLatestVersion(rs.db) != 0 {
+		return fmt.Errorf("snapshots can only be restored into an empty multistore")
+	}
+	metadata, err := m.readMetadata(height)
+	if err != nil {
+		return err
+	}
+	if metadata.Format != snapshotFormat {
+		return fmt.Errorf("unsupported snapshot format %d", metadata.Format)
+	}
+
+	// the root hashes of the stores must make up the trusted app hash, so
+	// that the restored trees can be checked against them
+	cInfo := commitInfo{Version: height}
+	stores := make(map[string]bool, len(metadata.Stores))
+	for i, store := range metadata.Stores {
+		if i > 0 && store.Name <= metadata.Stores[i-1].Name {
+			return fmt.Errorf("stores of the snapshot are not sorted or are duplicated")
+		}
+		stores[store.Name] = true
+		si := storeInfo{}
+		si.Name = store.Name
+		si.Core.CommitID = CommitID{Version: height, Hash: store.Hash}
+		cInfo.StoreInfos = append(cInfo.StoreInfos, si)
+	}
+	if !bytes.Equal(cInfo.Hash(), appHash) {
+		return fmt.Errorf("commit hash %X of the snapshot stores doesn't match the trusted app hash %X", cInfo.Hash(), appHash)
+	}
+
+	// the nodes are stored under their hash, which is computed here rather
+	// than trusted, so that a forged node is never reachable from a root
+	dir := m.snapshotDir(height)
+	for i, hash := range metadata.Chunks {
+		items, err := readChunk(dir, i, hash)
+		if err != nil {
+			return err
+		}
+		batches := make(map[string]dbm.Batch)
+		for _, item := range items {
+			if !stores[item.Store] {
+				return fmt.Errorf("chunk %d holds a node of store %s, which isn't in the snapshot", i, item.Store)
+			}
+			node, err := decodeIAVLNode(item.Node)
+			if err != nil {
+				return fmt.Errorf("invalid node in chunk %d: %v", i, err)
+			}
+			batch, ok := batches[item.Store]
+			if !ok {
+				batch = rs.getStoreDB(item.Store).NewBatch()
+				batches[item.Store] = batch
+			}
+			batch.Set(iavlNodeKey(node.hash()), item.Node)
+		}
+		for _, batch := range batches {
+			batch.Write()
+		}
+	}
+
+	// check the trees are complete, then make them the version of the height
+	for _, store := range metadata.Stores {
+		db := rs.getStoreDB(store.Name)
+		if len(store.Hash) > 0 {
+			_, _, err := verifyIAVLTree(db, store.Hash)
+			if err != nil {
+				return fmt.Errorf("invalid tree of store %s: %v", store.Name, err)
+			}
+		}
+		db.Set(iavlRootKey(height), store.Hash)
+		restored, err := LoadIAVLStore(db, CommitID{Version: height}, sdk.PruneNothing)
+		if err != nil {
+			return fmt.Errorf("failed to load restored store %s: %v", store.Name, err)
+		}
+		if !bytes.Equal(restored.LastCommitID().Hash, store.Hash) {
+			return fmt.Errorf("restored store %s doesn't match its hash", store.Name)
+		}
+	}
+
+	batch := rs.db.NewBatch()
+	setCommitInfo(batch, height, cInfo)
+	setLatestVersion(batch, height)
+	batch.Write()
+	return nil
+}
+
+// List returns the metadata of the complete snapshots, by ascending height
+func (m *SnapshotManager) List() ([]SnapshotMetadata, error) {
+	entries, err := ioutil.ReadDir(m.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snapshots []SnapshotMetadata
+	for _, entry := range entries {
+		height, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if !entry.IsDir() || err != nil {
+			continue
+		}
+		metadata, err := m.readMetadata(height)
+		if err != nil {
+			// incomplete snapshot
+			continue
+		}
+		snapshots = append(snapshots, metadata)
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Height < snapshots[j].Height })
+	return snapshots, nil
+}
+
+func (m *SnapshotManager) snapshotDir(height int64) string {
+	return filepath.Join(m.dir, strconv.FormatInt(height, 10))
+}
+
+func (m *SnapshotManager) readMetadata(height int64) (metadata SnapshotMetadata, err error) {
+	bz, err := ioutil.ReadFile(filepath.Join(m.snapshotDir(height), snapshotMetadataFile))
+	if err != nil {
+		return metadata, fmt.Errorf("no snapshot at height %d: %v", height, err)
+	}
+	err = json.Unmarshal(bz, &metadata)
+	return metadata, err
+}
+
+// write the items in the chunk of the given index, returning its hash
+func (m *SnapshotManager) writeChunk(dir string, index int, items []snapshotItem) (cmn.HexBytes, error) {
+	bz, err := cdc.MarshalBinary(snapshotChunk{Items: items})
+	if err != nil {
+		return nil, err
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, strconv.Itoa(index)), bz, 0644)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(bz)
+	return hash[:], nil
+}
+
+// read the items of the chunk of the given index, checking its hash
+func readChunk(dir string, index int, hash cmn.HexBytes) (items []snapshotItem, err error) {
+	bz, err := ioutil.ReadFile(filepath.Join(dir, strconv.Itoa(index)))
+	if err != nil {
+		return nil, err
+	}
+	if actual := sha256.Sum256(bz); !bytes.Equal(actual[:], hash) {
+		return nil, fmt.Errorf("hash of chunk %d doesn't match the snapshot metadata", index)
+	}
+	var chunk snapshotChunk
+	err = cdc.UnmarshalBinary(bz, &chunk)
+	return chunk.Items, err
+}
+
+//----------------------------------------
+// IAVL trees in their database
+
+// keys of the records of an IAVL tree in its database
+const (
+	iavlNodeKeyFmt = "n/%X"    // n/<hash>
+	iavlRootKeyFmt = "r/%010d" // r/<version>
+)
+
+func iavlNodeKey(hash []byte) []byte {
+	return []byte(fmt.Sprintf(iavlNodeKeyFmt, hash))
+}
+
+func iavlRootKey(version int64) []byte {
+	return []byte(fmt.Sprintf(iavlRootKeyFmt, version))
+}
+
+// a node of an IAVL tree, decoded from its database record
+type iavlNode struct {
+	height    int8
+	size      int64
+	version   int64
+	key       []byte
+	value     []byte // leaves only
+	leftHash  []byte // inner nodes only
+	rightHash []byte // inner nodes only
+}
+
+func (node iavlNode) isLeaf() bool {
+	return node.height == 0
+}
+
+// decode a node record, the way IAVL encodes it
+func decodeIAVLNode(bz []byte) (node iavlNode, err error) {
+	var n int
+	node.height, n, err = amino.DecodeInt8(bz)
+	if err != nil {
+		return node, err
+	}
+	bz = bz[n:]
+	node.size, n, err = amino.DecodeVarint(bz)
+	if err != nil {
+		return node, err
+	}
+	bz = bz[n:]
+	node.version, n, err = amino.DecodeVarint(bz)
+	if err != nil {
+		return node, err
+	}
+	bz = bz[n:]
+	node.key, n, err = amino.DecodeByteSlice(bz)
+	if err != nil {
+		return node, err
+	}
+	bz = bz[n:]
+	if node.isLeaf() {
+		node.value, n, err = amino.DecodeByteSlice(bz)
+		if err != nil {
+			return node, err
+		}
+		bz = bz[n:]
+	} else {
+		node.leftHash, n, err = amino.DecodeByteSlice(bz)
+		if err != nil {
+			return node, err
+		}
+		bz = bz[n:]
+		node.rightHash, n, err = amino.DecodeByteSlice(bz)
+		if err != nil {
+			return node, err
+		}
+		bz = bz[n:]
+		if len(node.leftHash) == 0 || len(node.rightHash) == 0 {
+			return node, fmt.Errorf("inner node without children")
+		}
+	}
+	if len(bz) != 0 {
+		return node, fmt.Errorf("%d trailing bytes after node", len(bz))
+	}
+	return node, nil
+}
+
+// the hash of a node, the way IAVL computes it. The key of an inner node
+// isn't part of its hash.
+func (node iavlNode) hash() []byte {
+	// writes to a buffer don't fail
+	buf := new(bytes.Buffer)
+	_ = amino.EncodeInt8(buf, node.height)
+	_ = amino.EncodeVarint(buf, node.size)
+	_ = amino.EncodeVarint(buf, node.version)
+	if node.isLeaf() {
+		_ = amino.EncodeByteSlice(buf, node.key)
+		_ = amino.EncodeByteSlice(buf, tmhash.Sum(node.value))
+	} else {
+		_ = amino.EncodeByteSlice(buf, node.leftHash)
+		_ = amino.EncodeByteSlice(buf, node.rightHash)
+	}
+	return tmhash.Sum(buf.Bytes())
+}
+
+// call fn on the records of the nodes of the tree of a root hash, in
+// pre-order
+func walkIAVLTree(db dbm.DB, hash []byte, fn func(bz []byte) error) error {
+	if len(hash) == 0 {
+		return nil
+	}
+	bz := db.Get(iavlNodeKey(hash))
+	if bz == nil {
+		return fmt.Errorf("missing node %X", hash)
+	}
+	node, err := decodeIAVLNode(bz)
+	if err != nil {
+		return err
+	}
+	err = fn(bz)
+	if err != nil || node.isLeaf() {
+		return err
+	}
+	err = walkIAVLTree(db, node.leftHash, fn)
+	if err != nil {
+		return err
+	}
+	return walkIAVLTree(db, node.rightHash, fn)
+}
+
+// check that all the nodes of the tree of a root hash are in the database,
+// and that the key of each inner node, which its hash doesn't cover, is the
+// first key of its right subtree. Returns the first and last keys of the tree.
+func verifyIAVLTree(db dbm.DB, hash []byte) (first, last []byte, err error) {
+	bz := db.Get(iavlNodeKey(hash))
+	if bz == nil {
+		return nil, nil, fmt.Errorf("missing node %X", hash)
+	}
+	node, err := decodeIAVLNode(bz)
+	if err != nil {
+		return nil, nil, err
+	}
+	if node.isLeaf() {
+		return node.key, node.key, nil
+	}
+	first, leftLast, err := verifyIAVLTree(db, node.leftHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	rightFirst, last, err := verifyIAVLTree(db, node.rightHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !bytes.Equal(node.key, rightFirst) || bytes.Compare(leftLast, rightFirst) >= 0 {
+		return nil, nil, fmt.Errorf("invalid key of inner node %X", hash)
+	}
+	return first, last, nil
+}
@@ -0,0 +1,145 @@
+package store
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tepleton/tepleton/libs/db"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+func newSnapshotMultiStore(db dbm.DB) *rootMultiStore {
+	store := NewCommitMultiStore(db)
+	store.MountStoreWithDB(sdk.NewKVStoreKey("store1"), sdk.StoreTypeIAVL, nil)
+	store.MountStoreWithDB(sdk.NewKVStoreKey("store2"), sdk.StoreTypeIAVL, nil)
+	return store
+}
+
+func TestSnapshotCreateRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store := newSnapshotMultiStore(dbm.NewMemDB())
+	require.Nil(t, store.LoadLatestVersion())
+	for i := byte(0); i < 3; i++ {
+		store.getStoreByName("store1").(KVStore).Set([]byte{i}, []byte("value1"))
+		store.getStoreByName("store2").(KVStore).Set([]byte{i}, []byte("value2"))
+		store.Commit()
+	}
+	// overwritten after the snapshot height
+	store.getStoreByName("store1").(KVStore).Set([]byte{1}, []byte("later"))
+	store.Commit()
+
+	manager := NewSnapshotManager(store, dir)
+	manager.chunkSize = 20
+	metadata, err := manager.Create(3)
+	require.Nil(t, err)
+	require.Equal(t, int64(3), metadata.Height)
+	require.Equal(t, 2, len(metadata.Stores))
+	require.Equal(t, "store1", metadata.Stores[0].Name)
+	require.Equal(t, "store2", metadata.Stores[1].Name)
+	require.True(t, len(metadata.Chunks) > 1)
+	cInfo, err := getCommitInfo(store.db, 3)
+	require.Nil(t, err)
+	require.Equal(t, cInfo.Hash(), []byte(metadata.AppHash))
+
+	// uncommitted height, existing snapshot
+	_, err = manager.Create(10)
+	require.NotNil(t, err)
+	_, err = manager.Create(3)
+	require.NotNil(t, err)
+
+	snapshots, err := manager.List()
+	require.Nil(t, err)
+	require.Equal(t, []SnapshotMetadata{metadata}, snapshots)
+
+	// restore in a new multistore and load the height
+	restored := newSnapshotMultiStore(dbm.NewMemDB())
+	err = NewSnapshotManager(restored, dir).Restore(3, nil)
+	require.NotNil(t, err)
+	err = NewSnapshotManager(restored, dir).Restore(3, []byte("forged"))
+	require.NotNil(t, err)
+	err = NewSnapshotManager(restored, dir).Restore(3, cInfo.Hash())
+	require.Nil(t, err)
+	require.Nil(t, restored.LoadLatestVersion())
+	require.Equal(t, cInfo.CommitID(), restored.LastCommitID())
+	require.Equal(t, []byte("value1"), restored.getStoreByName("store1").(KVStore).Get([]byte{1}))
+	require.Equal(t, []byte("value2"), restored.getStoreByName("store2").(KVStore).Get([]byte{2}))
+
+	// only the tree at the height is restored, without the other versions
+	for _, name := range []string{"store1", "store2"} {
+		db := restored.getStoreDB(name)
+		require.True(t, db.Has(iavlRootKey(3)))
+		require.False(t, db.Has(iavlRootKey(2)))
+		require.False(t, db.Has(iavlRootKey(4)))
+	}
+	nodes := 0
+	iter := restored.getStoreDB("store1").Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		if iter.Key()[0] == 'n' {
+			nodes++
+		}
+	}
+	iter.Close()
+	require.Equal(t, 5, nodes) // 3 leaves and 2 inner nodes
+
+	// only into an empty multistore
+	err = NewSnapshotManager(restored, dir).Restore(3, cInfo.Hash())
+	require.NotNil(t, err)
+}
+
+func TestSnapshotRestoreCorruptedChunk(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store := newSnapshotMultiStore(dbm.NewMemDB())
+	require.Nil(t, store.LoadLatestVersion())
+	store.getStoreByName("store1").(KVStore).Set([]byte("key"), []byte("value"))
+	store.Commit()
+	metadata, err := NewSnapshotManager(store, dir).Create(1)
+	require.Nil(t, err)
+
+	chunk := filepath.Join(dir, "1", "0")
+	bz, err := ioutil.ReadFile(chunk)
+	require.Nil(t, err)
+	bz[len(bz)-1]++
+	require.Nil(t, ioutil.WriteFile(chunk, bz, 0644))
+
+	err = NewSnapshotManager(newSnapshotMultiStore(dbm.NewMemDB()), dir).Restore(1, metadata.AppHash)
+	require.NotNil(t, err)
+}
+
+func TestSnapshotRestoreUnknownStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store := newSnapshotMultiStore(dbm.NewMemDB())
+	require.Nil(t, store.LoadLatestVersion())
+	store.getStoreByName("store1").(KVStore).Set([]byte("key"), []byte("value"))
+	store.Commit()
+	manager := NewSnapshotManager(store, dir)
+	metadata, err := manager.Create(1)
+	require.Nil(t, err)
+
+	// a chunk writing into a store outside of the snapshot, with valid hashes
+	items, err := readChunk(manager.snapshotDir(1), 0, metadata.Chunks[0])
+	require.Nil(t, err)
+	items = append(items, snapshotItem{Store: "store3", Node: items[0].Node})
+	hash, err := manager.writeChunk(manager.snapshotDir(1), 0, items)
+	require.Nil(t, err)
+	metadata.Chunks[0] = hash
+	bz, err := json.Marshal(metadata)
+	require.Nil(t, err)
+	require.Nil(t, ioutil.WriteFile(filepath.Join(manager.snapshotDir(1), snapshotMetadataFile), bz, 0644))
+
+	err = NewSnapshotManager(newSnapshotMultiStore(dbm.NewMemDB()), dir).Restore(1, metadata.AppHash)
+	require.NotNil(t, err)
+}
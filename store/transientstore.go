@@ -0,0 +1,37 @@
+package store
+
+import (
+	dbm "github.com/tepleton/tepleton/libs/db"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+var _ KVStore = (*transientStore)(nil)
+var _ CommitStore = (*transientStore)(nil)
+
+// transientStore is an in-memory store for data which only lasts until the
+// end of the block. It is emptied when committed, and is not part of the
+// commit hash of the multistore.
+type transientStore struct {
+	dbStoreAdapter
+}
+
+func newTransientStore() *transientStore {
+	return &transientStore{dbStoreAdapter{dbm.NewMemDB()}}
+}
+
+// Implements Committer, emptying the store.
+func (ts *transientStore) Commit() (id CommitID) {
+	ts.dbStoreAdapter = dbStoreAdapter{dbm.NewMemDB()}
+	return
+}
+
+// Implements Committer.
+func (ts *transientStore) LastCommitID() (id CommitID) {
+	return
+}
+
+// Implements Store.
+func (ts *transientStore) GetStoreType() StoreType {
+	return sdk.StoreTypeTransient
+}
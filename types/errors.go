@@ -54,6 +54,8 @@ const (
 	CodeInvalidCoins      CodeType = 11
 	CodeOutOfGas          CodeType = 12
 	CodeMemoTooLarge      CodeType = 13
+	CodeInsufficientFee   CodeType = 14
+	CodeBlockGasOverflow  CodeType = 15
 
 	// CodespaceRoot is a codespace for error codes in this file only.
 	// Notice that 0 is an "unset" codespace, which can be overridden with
@@ -94,6 +96,10 @@ func CodeToDefaultMsg(code CodeType) string {
 		return "out of gas"
 	case CodeMemoTooLarge:
 		return "memo too large"
+	case CodeInsufficientFee:
+		return "insufficient fee"
+	case CodeBlockGasOverflow:
+		return "block gas overflow"
 	default:
 		return fmt.Sprintf("unknown code %d", code)
 	}
@@ -143,6 +149,12 @@ func ErrOutOfGas(msg string) Error {
 func ErrMemoTooLarge(msg string) Error {
 	return newErrorWithRootCodespace(CodeMemoTooLarge, msg)
 }
+func ErrInsufficientFee(msg string) Error {
+	return newErrorWithRootCodespace(CodeInsufficientFee, msg)
+}
+func ErrBlockGasOverflow(msg string) Error {
+	return newErrorWithRootCodespace(CodeBlockGasOverflow, msg)
+}
 
 //----------------------------------------
 // Error & sdkError
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// GasPrice is the price of a unit of gas in a coin denomination
+type GasPrice struct {
+	Denom  string `json:"denom"`
+	Amount Rat    `json:"amount"`
+}
+
+func (price GasPrice) String() string {
+	return fmt.Sprintf("%v%v", price.Amount.FloatString(), price.Denom)
+}
+
+// GasPrices is a set of gas prices, one per denomination
+type GasPrices []GasPrice
+
+func (prices GasPrices) String() string {
+	strs := make([]string, len(prices))
+	for i, price := range prices {
+		strs[i] = price.String()
+	}
+	return strings.Join(strs, ",")
+}
+
+// IsZero returns true if no price is set
+func (prices GasPrices) IsZero() bool {
+	for _, price := range prices {
+		if !price.Amount.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// RequiredFees returns the fee, in every denomination of the prices, for the
+// given amount of gas. Amounts are rounded up.
+func (prices GasPrices) RequiredFees(gas int64) Coins {
+	var fees Coins
+	for _, price := range prices {
+		if price.Amount.IsZero() {
+			continue
+		}
+		num := price.Amount.Num().MulRaw(gas)
+		denom := price.Amount.Denom()
+		amount := num.Add(denom).SubRaw(1).Div(denom)
+		fees = append(fees, Coin{Denom: price.Denom, Amount: amount})
+	}
+	return fees.Sort()
+}
+
+// IsCoveredBy returns true if the fee pays for the gas at the price of at
+// least one of the denominations
+func (prices GasPrices) IsCoveredBy(fee Coins, gas int64) bool {
+	requiredFees := prices.RequiredFees(gas)
+	if requiredFees.IsZero() {
+		return true
+	}
+	for _, required := range requiredFees {
+		if !fee.AmountOf(required.Denom).LT(required.Amount) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	reGasPriceAmt = `[[:digit:]]+(?:\.[[:digit:]]+)?`
+	reGasPrice    = regexp.MustCompile(fmt.Sprintf(`^(%s)%s(%s)$`, reGasPriceAmt, reSpc, reDnm))
+)
+
+// ParseGasPrices parses a list of decimal gas prices separated by commas,
+// such as "0.025steak,0.1photino". If nothing is provided, it returns nil.
+func ParseGasPrices(pricesStr string) (prices GasPrices, err error) {
+	pricesStr = strings.TrimSpace(pricesStr)
+	if len(pricesStr) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[string]bool)
+	for _, priceStr := range strings.Split(pricesStr, ",") {
+		matches := reGasPrice.FindStringSubmatch(strings.TrimSpace(priceStr))
+		if matches == nil {
+			return nil, fmt.Errorf("invalid gas price expression: %s", priceStr)
+		}
+		denomStr, amountStr := matches[2], matches[1]
+		if seen[denomStr] {
+			return nil, fmt.Errorf("duplicate gas price denomination: %s", denomStr)
+		}
+		seen[denomStr] = true
+
+		amount, errRat := NewRatFromDecimal(amountStr, 18)
+		if errRat != nil {
+			return nil, fmt.Errorf("invalid gas price amount: %s", amountStr)
+		}
+		prices = append(prices, GasPrice{Denom: denomStr, Amount: amount})
+	}
+	return prices, nil
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGasPrices(t *testing.T) {
+	cases := []struct {
+		input    string
+		valid    bool
+		expected GasPrices
+	}{
+		{"", true, nil},
+		{"1atom", true, GasPrices{{"atom", NewRat(1)}}},
+		{"0.025steak", true, GasPrices{{"steak", NewRat(1, 40)}}},
+		{"0.025steak, 2photino", true, GasPrices{{"steak", NewRat(1, 40)}, {"photino", NewRat(2)}}},
+		{"0.025", false, nil},
+		{"steak", false, nil},
+		{"-1steak", false, nil},
+		{"1.steak", false, nil},
+		{"1steak,2steak", false, nil},
+	}
+
+	for i, tc := range cases {
+		prices, err := ParseGasPrices(tc.input)
+		if !tc.valid {
+			require.NotNil(t, err, "%d: %s", i, tc.input)
+			continue
+		}
+		require.Nil(t, err, "%d: %s", i, tc.input)
+		require.Equal(t, len(tc.expected), len(prices), "%d: %s", i, tc.input)
+		for j, price := range prices {
+			require.Equal(t, tc.expected[j].Denom, price.Denom)
+			require.True(t, tc.expected[j].Amount.Equal(price.Amount), "%d: %s", i, tc.input)
+		}
+	}
+}
+
+func TestGasPricesRequiredFees(t *testing.T) {
+	prices := GasPrices{{"steak", NewRat(1, 40)}, {"photino", NewRat(2)}, {"atom", ZeroRat()}}
+
+	// amounts are rounded up, zero prices are ignored
+	fees := prices.RequiredFees(100)
+	require.True(t, fees.IsEqual(Coins{NewCoin("photino", 200), NewCoin("steak", 3)}), fees.String())
+	require.True(t, prices.RequiredFees(0).IsZero())
+
+	// the fee has to cover one of the denominations
+	require.True(t, prices.IsCoveredBy(Coins{NewCoin("steak", 3)}, 100))
+	require.True(t, prices.IsCoveredBy(Coins{NewCoin("photino", 200), NewCoin("steak", 1)}, 100))
+	require.False(t, prices.IsCoveredBy(Coins{NewCoin("photino", 199), NewCoin("steak", 2)}, 100))
+	require.False(t, prices.IsCoveredBy(Coins{NewCoin("atom", 1000)}, 100))
+	require.False(t, prices.IsCoveredBy(nil, 100))
+
+	// no minimum gas prices
+	require.True(t, GasPrices(nil).IsCoveredBy(nil, 100))
+	require.True(t, GasPrices{{"atom", ZeroRat()}}.IsCoveredBy(nil, 100))
+}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"fmt"
+
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+
+	"github.com/tepleton/tepleton-sdk/wire"
+)
+
+// Querier answers the custom queries of a module, reached through the query
+// paths "/custom/<module>/<path...>", with the elements of the path after the module
+type Querier func(ctx Context, path []string, req wrsp.RequestQuery) (res []byte, err Error)
+
+// UnmarshalQueryParams decodes the JSON params of a custom query
+func UnmarshalQueryParams(cdc *wire.Codec, data []byte, params interface{}) Error {
+	if err := cdc.UnmarshalJSON(data, params); err != nil {
+		return ErrUnknownRequest(fmt.Sprintf("invalid query params: %v", err))
+	}
+	return nil
+}
+
+// MarshalQueryResult encodes the result of a custom query as indented JSON
+func MarshalQueryResult(cdc *wire.Codec, result interface{}) ([]byte, Error) {
+	bz, err := wire.MarshalJSONIndent(cdc, result)
+	if err != nil {
+		return nil, ErrInternal(fmt.Sprintf("could not marshal result to JSON: %v", err))
+	}
+	return bz, nil
+}
@@ -22,6 +22,9 @@ type Result struct {
 	FeeAmount int64
 	FeeDenom  string
 
+	// FeeRequired is the fee required by the node for the gas used, reported by simulations.
+	FeeRequired Coins
+
 	// Tags are used for transaction indexing and pubsub.
 	Tags Tags
 }
@@ -40,6 +40,7 @@ type Validator interface {
 	GetPower() Rat            // validation power
 	GetDelegatorShares() Rat  // Total out standing delegator shares
 	GetBondHeight() int64     // height in which the validator became active
+	GetCommission() Rat       // commission rate charged on the rewards of the delegators
 }
 
 // validator which fulfills wrsp validator interface for use in Tendermint
@@ -87,3 +88,11 @@ type DelegationSet interface {
 	IterateDelegations(ctx Context, delegator Address,
 		fn func(index int64, delegation Delegation) (stop bool))
 }
+
+// event hooks for the staking module, so other modules can keep track of
+// delegations (e.g. to settle the rewards accumulated by a delegation)
+type StakingHooks interface {
+	// called before the shares of a delegation are added or removed,
+	// including when the delegation is created or removed
+	BeforeDelegationSharesModified(ctx Context, delegator, validator Address)
+}
@@ -67,6 +67,13 @@ type CommitMultiStore interface {
 	// If db == nil, the new store will use the CommitMultiStore db.
 	MountStoreWithDB(key StoreKey, typ StoreType, db dbm.DB)
 
+	// Set the pruning of the stores loaded afterwards, when loading a version.
+	SetPruning(pruning PruningOptions)
+
+	// Cache wrap the stores as they were committed at a version, to read
+	// past states. Fails if the version was pruned.
+	CacheMultiStoreWithVersion(version int64) (CacheMultiStore, error)
+
 	// Panics on a nil key.
 	GetCommitStore(key StoreKey) CommitStore
 
@@ -213,8 +220,57 @@ const (
 	StoreTypeDB
 	StoreTypeIAVL
 	StoreTypePrefix
+	StoreTypeTransient
+)
+
+//----------------------------------------
+// Pruning
+
+// PruningOptions - which old versions of the IAVL stores are kept after a commit
+type PruningOptions struct {
+	// number of recent versions kept
+	KeepRecent int64
+	// versions at a multiple of this height are kept forever, none if zero
+	KeepEvery int64
+}
+
+// nolint - pruning strategies
+const (
+	PruningStrategyNothing    = "nothing"
+	PruningStrategyEverything = "everything"
+	PruningStrategyDefault    = "default"
+	PruningStrategyCustom     = "custom"
+)
+
+var (
+	// PruneNothing keeps every version, for archive nodes
+	PruneNothing = PruningOptions{KeepRecent: 0, KeepEvery: 1}
+	// PruneEverything only keeps the latest version
+	PruneEverything = PruningOptions{KeepRecent: 0, KeepEvery: 0}
+	// PruneDefault keeps the recent versions, and sync waypoints every 10000 versions
+	PruneDefault = PruningOptions{KeepRecent: 100, KeepEvery: 10000}
 )
 
+// NewPruningOptions returns the options of a named pruning strategy. The
+// numbers of versions to keep are only used by the custom strategy.
+func NewPruningOptions(strategy string, keepRecent, keepEvery int64) (PruningOptions, error) {
+	switch strategy {
+	case PruningStrategyNothing:
+		return PruneNothing, nil
+	case PruningStrategyEverything:
+		return PruneEverything, nil
+	case PruningStrategyDefault, "":
+		return PruneDefault, nil
+	case PruningStrategyCustom:
+		if keepRecent < 0 || keepEvery < 0 {
+			return PruningOptions{}, fmt.Errorf("numbers of versions to keep must not be negative")
+		}
+		return PruningOptions{KeepRecent: keepRecent, KeepEvery: keepEvery}, nil
+	default:
+		return PruningOptions{}, fmt.Errorf("unknown pruning strategy %s", strategy)
+	}
+}
+
 //----------------------------------------
 // Keys for accessing substores
 
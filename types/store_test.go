@@ -25,3 +25,27 @@ func TestPrefixEndBytes(t *testing.T) {
 		require.Equal(t, test.expected, end)
 	}
 }
+
+func TestNewPruningOptions(t *testing.T) {
+	var testCases = []struct {
+		strategy   string
+		keepRecent int64
+		keepEvery  int64
+		expected   PruningOptions
+		expectErr  bool
+	}{
+		{"nothing", 5, 5, PruneNothing, false},
+		{"everything", 5, 5, PruneEverything, false},
+		{"default", 5, 5, PruneDefault, false},
+		{"", 0, 0, PruneDefault, false},
+		{"custom", 5, 50, PruningOptions{KeepRecent: 5, KeepEvery: 50}, false},
+		{"custom", -1, 50, PruningOptions{}, true},
+		{"some", 0, 0, PruningOptions{}, true},
+	}
+
+	for _, test := range testCases {
+		pruning, err := NewPruningOptions(test.strategy, test.keepRecent, test.keepEvery)
+		require.Equal(t, test.expectErr, err != nil, test.strategy)
+		require.Equal(t, test.expected, pruning, test.strategy)
+	}
+}
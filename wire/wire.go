@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 
+	"github.com/tepleton/tepleton-sdk/crypto/multisig"
 	amino "github.com/tepleton/go-amino"
 	"github.com/tepleton/tepleton/crypto"
 )
@@ -16,9 +17,10 @@ func NewCodec() *Codec {
 	return cdc
 }
 
-// Register the go-crypto to the codec
+// Register the go-crypto and the threshold multisig keys to the codec
 func RegisterCrypto(cdc *Codec) {
 	crypto.RegisterAmino(cdc)
+	multisig.RegisterAmino(cdc)
 }
 
 // attempt to make some pretty json
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"fmt"
 
+	"github.com/tepleton/tepleton-sdk/crypto/multisig"
 	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton/crypto"
 )
 
 const (
@@ -14,10 +16,24 @@ const (
 	maxMemoCharacters         = 100
 )
 
+// FeeGrantKeeper lets an account pay the fees of the txs of another account,
+// from a fee allowance it granted to it
+type FeeGrantKeeper interface {
+	// deduct the fee from the allowance, or return an error if it can't pay it
+	UseGrantedFees(ctx sdk.Context, granter, grantee sdk.Address, fee sdk.Coins) sdk.Error
+}
+
 // NewAnteHandler returns an AnteHandler that checks
 // and increments sequence numbers, checks signatures & account numbers,
 // and deducts fees from the first signer.
 func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
+	return NewAnteHandlerWithFeeGrants(am, fck, nil)
+}
+
+// NewAnteHandlerWithFeeGrants returns an AnteHandler like NewAnteHandler,
+// which deducts the fees from the fee granter instead of the first signer
+// when the fee has a granter, and the granter gave the first signer an allowance.
+func NewAnteHandlerWithFeeGrants(am AccountMapper, fck FeeCollectionKeeper, fgk FeeGrantKeeper) sdk.AnteHandler {
 
 	return func(
 		ctx sdk.Context, tx sdk.Tx,
@@ -45,6 +61,26 @@ func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 				true
 		}
 
+		// reject txs not paying the node's minimum gas prices from the mempool,
+		// DeliverTx must not depend on this node-local setting
+		if ctx.IsCheckTx() {
+			minGasPrices := ctx.MinimumGasPrices()
+			if !minGasPrices.IsCoveredBy(stdTx.Fee.Amount, stdTx.Fee.Gas) {
+				return ctx,
+					sdk.ErrInsufficientFee(fmt.Sprintf("fee %s for %d gas, one of %s required",
+						stdTx.Fee.Amount, stdTx.Fee.Gas, minGasPrices.RequiredFees(stdTx.Fee.Gas))).Result(),
+					true
+			}
+		}
+
+		// reject txs which could never fit in a block from the mempool
+		if ctx.IsCheckTx() && stdTx.Fee.Gas > ctx.BlockGasMeter().Limit() {
+			return ctx,
+				sdk.ErrBlockGasOverflow(fmt.Sprintf("tx gas %d exceeds the block gas limit %d",
+					stdTx.Fee.Gas, ctx.BlockGasMeter().Limit())).Result(),
+				true
+		}
+
 		// set the gas meter
 		ctx = ctx.WithGasMeter(sdk.NewGasMeter(stdTx.Fee.Gas))
 
@@ -87,12 +123,15 @@ func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 				return ctx, res, true
 			}
 
-			// first sig pays the fees
+			// first sig pays the fees, unless they are granted
 			if i == 0 {
-				// TODO: min fee
 				if !fee.Amount.IsZero() {
 					ctx.GasMeter().ConsumeGas(deductFeesCost, "deductFees")
-					signerAcc, res = deductFees(signerAcc, fee)
+					if len(fee.Granter) != 0 {
+						res = deductGrantedFees(ctx, am, fgk, signerAddr, fee)
+					} else {
+						signerAcc, res = deductFees(ctx, signerAcc, fee)
+					}
 					if !res.IsOK() {
 						return ctx, res, true
 					}
@@ -164,7 +203,7 @@ func processSig(
 	}
 
 	// Check sig.
-	ctx.GasMeter().ConsumeGas(verifyCost, "ante verify")
+	consumeSignatureGas(ctx.GasMeter(), sig.Signature)
 	if !pubKey.VerifyBytes(signBytes, sig.Signature) {
 		return nil, sdk.ErrUnauthorized("signature verification failed").Result()
 	}
@@ -172,13 +211,60 @@ func processSig(
 	return
 }
 
+// Charge the verification of every signature a multisignature holds.
+func consumeSignatureGas(meter sdk.GasMeter, sig crypto.Signature) {
+	if msig, ok := sig.(multisig.Multisignature); ok {
+		for _, subsig := range msig.Sigs {
+			consumeSignatureGas(meter, subsig)
+		}
+		return
+	}
+	meter.ConsumeGas(verifyCost, "ante verify")
+}
+
+// Deduct the fee from the account of the granter, and from the allowance it
+// granted to the fee payer.
+func deductGrantedFees(ctx sdk.Context, am AccountMapper, fgk FeeGrantKeeper, payer sdk.Address, fee StdFee) sdk.Result {
+	if fgk == nil {
+		return sdk.ErrUnauthorized("fee grants are not supported").Result()
+	}
+	// the account of the payer is saved after, it can't pay its own fees here
+	if bytes.Equal(fee.Granter, payer) {
+		return sdk.ErrUnauthorized("fee granter can't be the fee payer").Result()
+	}
+
+	granterAcc := am.GetAccount(ctx, fee.Granter)
+	if granterAcc == nil {
+		return sdk.ErrUnknownAddress(fee.Granter.String()).Result()
+	}
+	granterAcc, res := deductFees(ctx, granterAcc, fee)
+	if !res.IsOK() {
+		return res
+	}
+	err := fgk.UseGrantedFees(ctx, fee.Granter, payer, fee.Amount)
+	if err != nil {
+		return err.Result()
+	}
+	am.SetAccount(ctx, granterAcc)
+	return sdk.Result{}
+}
+
 // Deduct the fee from the account.
 // We could use the CoinKeeper (in addition to the AccountMapper,
 // because the CoinKeeper doesn't give us accounts), but it seems easier to do this.
-func deductFees(acc Account, fee StdFee) (Account, sdk.Result) {
+// The locked coins of a vesting account cannot pay fees.
+func deductFees(ctx sdk.Context, acc Account, fee StdFee) (Account, sdk.Result) {
 	coins := acc.GetCoins()
 	feeAmount := fee.Amount
 
+	if vacc, ok := acc.(VestingAccount); ok {
+		spendable := vacc.GetSpendableCoins(ctx.BlockHeader().Time)
+		if !spendable.Minus(feeAmount).IsNotNegative() {
+			errMsg := fmt.Sprintf("%s spendable < %s", spendable, feeAmount)
+			return nil, sdk.ErrInsufficientFunds(errMsg).Result()
+		}
+	}
+
 	newCoins := coins.Minus(feeAmount)
 	if !newCoins.IsNotNegative() {
 		errMsg := fmt.Sprintf("%s < %s", coins, feeAmount)
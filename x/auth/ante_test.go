@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/tepleton/tepleton/crypto"
 	"github.com/tepleton/tepleton/libs/log"
 
+	"github.com/tepleton/tepleton-sdk/crypto/multisig"
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	wire "github.com/tepleton/tepleton-sdk/wire"
 )
@@ -338,6 +340,235 @@ func TestAnteHandlerFees(t *testing.T) {
 	require.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(sdk.Coins{sdk.NewCoin("atom", 150)}))
 }
 
+// Test that the minimum gas prices are enforced in CheckTx only.
+func TestAnteHandlerMinimumGasPrices(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	minGasPrices, err := sdk.ParseGasPrices("0.03atom")
+	require.Nil(t, err)
+	checkCtx := sdk.NewContext(ms, wrsp.Header{ChainID: "mychainid"}, true, log.NewNopLogger()).
+		WithMinimumGasPrices(minGasPrices)
+	deliverCtx := sdk.NewContext(ms, wrsp.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+
+	// set the accounts
+	acc1 := mapper.NewAccountWithAddress(checkCtx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(checkCtx, acc1)
+
+	// msg and signatures
+	var tx sdk.Tx
+	msg := newTestMsg(addr1)
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
+	msgs := []sdk.Msg{msg}
+
+	// 5000 gas require a fee of 150atom
+	tx = newTestTx(checkCtx, msgs, privs, accnums, seqs, NewStdFee(5000, sdk.NewCoin("atom", 149)))
+	checkInvalidTx(t, anteHandler, checkCtx, tx, sdk.CodeInsufficientFee)
+	tx = newTestTx(checkCtx, msgs, privs, accnums, seqs, NewStdFee(5000))
+	checkInvalidTx(t, anteHandler, checkCtx, tx, sdk.CodeInsufficientFee)
+
+	// DeliverTx doesn't depend on the minimum gas prices
+	checkValidTx(t, anteHandler, deliverCtx, tx)
+
+	seqs = []int64{1}
+	tx = newTestTx(checkCtx, msgs, privs, accnums, seqs, NewStdFee(5000, sdk.NewCoin("atom", 150)))
+	checkValidTx(t, anteHandler, checkCtx, tx)
+}
+
+// Test that CheckTx rejects txs whose gas exceeds the block gas limit.
+func TestAnteHandlerBlockGasLimit(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	checkCtx := sdk.NewContext(ms, wrsp.Header{ChainID: "mychainid"}, true, log.NewNopLogger()).
+		WithBlockGasMeter(sdk.NewGasMeter(10000))
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+
+	// set the accounts
+	acc1 := mapper.NewAccountWithAddress(checkCtx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(checkCtx, acc1)
+
+	// msg and signatures
+	var tx sdk.Tx
+	msg := newTestMsg(addr1)
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
+	msgs := []sdk.Msg{msg}
+
+	tx = newTestTx(checkCtx, msgs, privs, accnums, seqs, NewStdFee(10001))
+	checkInvalidTx(t, anteHandler, checkCtx, tx, sdk.CodeBlockGasOverflow)
+	tx = newTestTx(checkCtx, msgs, privs, accnums, seqs, NewStdFee(10000))
+	checkValidTx(t, anteHandler, checkCtx, tx)
+}
+
+// fee grant keeper granting a single spend limit
+type testFeeGrantKeeper struct {
+	granter, grantee sdk.Address
+	spendLimit       *sdk.Coins
+}
+
+func (fgk testFeeGrantKeeper) UseGrantedFees(ctx sdk.Context, granter, grantee sdk.Address, fee sdk.Coins) sdk.Error {
+	if !bytes.Equal(granter, fgk.granter) || !bytes.Equal(grantee, fgk.grantee) {
+		return sdk.ErrUnauthorized("no allowance")
+	}
+	left := fgk.spendLimit.Minus(fee)
+	if !left.IsNotNegative() {
+		return sdk.ErrUnauthorized("spend limit exceeded")
+	}
+	*fgk.spendLimit = left
+	return nil
+}
+
+// Test that a fee granter pays the fees from its allowance.
+func TestAnteHandlerFeeGrants(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	ctx := sdk.NewContext(ms, wrsp.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+	_, addr2 := privAndAddr()
+
+	// the grantee has no coins, the granter grants it 200atom of fees
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	mapper.SetAccount(ctx, acc1)
+	acc2 := mapper.NewAccountWithAddress(ctx, addr2)
+	acc2.SetCoins(sdk.Coins{sdk.NewCoin("atom", 1000)})
+	mapper.SetAccount(ctx, acc2)
+	spendLimit := sdk.Coins{sdk.NewCoin("atom", 200)}
+	fgk := testFeeGrantKeeper{granter: addr2, grantee: addr1, spendLimit: &spendLimit}
+	anteHandler := NewAnteHandlerWithFeeGrants(mapper, feeCollector, fgk)
+
+	// msg and signatures
+	var tx sdk.Tx
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
+	fee := newStdFee()
+	fee.Granter = addr2
+
+	// fee grants are not supported by the default ante handler
+	tx = newTestTx(ctx, msgs, privs, accnums, seqs, fee)
+	checkInvalidTx(t, NewAnteHandler(mapper, feeCollector), ctx, tx, sdk.CodeUnauthorized)
+
+	checkValidTx(t, anteHandler, ctx, tx)
+	require.True(t, mapper.GetAccount(ctx, addr2).GetCoins().IsEqual(sdk.Coins{sdk.NewCoin("atom", 850)}))
+	require.True(t, spendLimit.IsEqual(sdk.Coins{sdk.NewCoin("atom", 50)}))
+	require.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(sdk.Coins{sdk.NewCoin("atom", 150)}))
+
+	// the allowance doesn't cover the fee
+	seqs = []int64{1}
+	tx = newTestTx(ctx, msgs, privs, accnums, seqs, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeUnauthorized)
+	require.True(t, mapper.GetAccount(ctx, addr2).GetCoins().IsEqual(sdk.Coins{sdk.NewCoin("atom", 850)}))
+
+	// the fee payer can't grant itself
+	fee.Granter = addr1
+	tx = newTestTx(ctx, msgs, privs, accnums, seqs, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeUnauthorized)
+}
+
+// Test that the locked coins of a vesting account cannot pay fees
+func TestAnteHandlerVestingFees(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	ctx := sdk.NewContext(ms, wrsp.Header{ChainID: "mychainid", Time: 1500}, false, log.NewNopLogger())
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+
+	// set the vesting account, half of its coins are locked
+	acc1 := NewContinuousVestingAccount(addr1, sdk.Coins{sdk.NewCoin("atom", 200)}, 1000, 2000)
+	mapper.SetAccount(ctx, &acc1)
+
+	// msg and signatures
+	var tx sdk.Tx
+	msg := newTestMsg(addr1)
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
+	msgs := []sdk.Msg{msg}
+
+	// signer does not have enough unlocked coins to pay the fee
+	tx = newTestTx(ctx, msgs, privs, accnums, seqs, NewStdFee(5000, sdk.NewCoin("atom", 101)))
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeInsufficientFunds)
+	require.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(emptyCoins))
+
+	tx = newTestTx(ctx, msgs, privs, accnums, seqs, NewStdFee(5000, sdk.NewCoin("atom", 100)))
+	checkValidTx(t, anteHandler, ctx, tx)
+	require.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(sdk.Coins{sdk.NewCoin("atom", 100)}))
+}
+
+// Test accounts of threshold public keys.
+func TestAnteHandlerMultisig(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	ctx := sdk.NewContext(ms, wrsp.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+
+	// a 2-of-3 threshold public key
+	priv1, _ := privAndAddr()
+	priv2, _ := privAndAddr()
+	priv3, _ := privAndAddr()
+	pubkeys := []crypto.PubKey{priv1.PubKey(), priv2.PubKey(), priv3.PubKey()}
+	multisigKey := multisig.NewPubKeyMultisigThreshold(2, pubkeys)
+	addr := multisigKey.Address()
+
+	acc := mapper.NewAccountWithAddress(ctx, addr)
+	acc.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc)
+
+	msgs := []sdk.Msg{newTestMsg(addr)}
+	fee := newStdFee()
+	newMultisigTx := func(seq int64, privs ...crypto.PrivKey) sdk.Tx {
+		signBytes := StdSignBytes(ctx.ChainID(), 0, seq, fee, msgs, "")
+		multisignature := multisig.NewMultisig(len(pubkeys))
+		for _, priv := range privs {
+			sig, err := priv.Sign(signBytes)
+			require.NoError(t, err)
+			require.NoError(t, multisignature.AddSignatureFromPubKey(sig, priv.PubKey(), pubkeys))
+		}
+		sigs := []StdSignature{{PubKey: multisigKey, Signature: *multisignature, AccountNumber: 0, Sequence: seq}}
+		return NewStdTx(msgs, fee, sigs, "")
+	}
+
+	// below the threshold
+	checkInvalidTx(t, anteHandler, ctx, newMultisigTx(0, priv1), sdk.CodeUnauthorized)
+
+	// enough signatures, in any order
+	checkValidTx(t, anteHandler, ctx, newMultisigTx(0, priv3, priv1))
+	require.True(t, multisigKey.Equals(mapper.GetAccount(ctx, addr).GetPubKey()))
+
+	// every signature is charged
+	newCtx, _, abort := anteHandler(ctx, newMultisigTx(1, priv1, priv2, priv3))
+	require.False(t, abort)
+	require.True(t, newCtx.GasMeter().GasConsumed() >= 3*verifyCost)
+}
+
 // Test logic around memo gas consumption.
 func TestAnteHandlerMemoGas(t *testing.T) {
 	// setup
@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tepleton/tepleton-sdk/client/context"
+	"github.com/tepleton/tepleton-sdk/client/keys"
+	"github.com/tepleton/tepleton-sdk/wire"
+	"github.com/tepleton/tepleton-sdk/x/auth"
+)
+
+const (
+	flagMultisig = "multisig"
+)
+
+// GetSignCommand returns the command to sign a transaction generated offline
+func GetSignCommand(cdc *wire.Codec, decoder auth.AccountDecoder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign <file>",
+		Short: "Sign a transaction generated offline",
+		Long: `Sign the transaction of the given file with the key of --name and print it.
+With --multisig, the key is one of the keys of the given threshold public key,
+and only its signature is printed, to be combined with the others by multisign.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stdTx, err := readStdTx(cdc, args[0])
+			if err != nil {
+				return err
+			}
+			ctx := context.NewCoreContextFromViper().WithDecoder(decoder)
+			name := ctx.FromAddressName
+
+			// the account number and sequence are the ones of the signing account
+			multisigName := viper.GetString(flagMultisig)
+			if multisigName != "" {
+				ctx = ctx.WithFromAddressName(multisigName)
+			}
+			ctx, err = context.EnsureAccountNumber(ctx)
+			if err != nil {
+				return err
+			}
+			ctx, err = context.EnsureSequence(ctx)
+			if err != nil {
+				return err
+			}
+
+			passphrase, err := ctx.GetPassphraseFromStdin(name)
+			if err != nil {
+				return err
+			}
+			sig, err := ctx.SignStdTx(name, passphrase, stdTx)
+			if err != nil {
+				return err
+			}
+
+			var output []byte
+			if multisigName != "" {
+				output, err = wire.MarshalJSONIndent(cdc, sig)
+			} else {
+				stdTx.Signatures = append(stdTx.Signatures, sig)
+				output, err = wire.MarshalJSONIndent(cdc, stdTx)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+	cmd.Flags().String(flagMultisig, "", "Name of the threshold public key the signature is made for")
+	return cmd
+}
+
+// GetMultiSignCommand returns the command to combine the signatures of the
+// keys of a threshold public key into a signature of the transaction
+func GetMultiSignCommand(cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "multisign <file> <multisig-name> <signature-files>...",
+		Short: "Sign a transaction generated offline with a threshold public key",
+		Long: `Combine the signatures made by sign --multisig with the keys of the
+threshold public key of the given name, add it to the transaction of the given
+file and print it.`,
+		Args: cobra.MinimumNArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stdTx, err := readStdTx(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			keybase, err := keys.GetKeyBase()
+			if err != nil {
+				return err
+			}
+			info, err := keybase.Get(args[1])
+			if err != nil {
+				return err
+			}
+
+			sigs := make([]auth.StdSignature, len(args)-2)
+			for i, file := range args[2:] {
+				bz, err := ioutil.ReadFile(file)
+				if err != nil {
+					return err
+				}
+				err = cdc.UnmarshalJSON(bz, &sigs[i])
+				if err != nil {
+					return errors.Wrapf(err, "invalid signature in %s", file)
+				}
+			}
+
+			sig, err := context.CombineSignatures(info.GetPubKey(), sigs)
+			if err != nil {
+				return err
+			}
+			stdTx.Signatures = append(stdTx.Signatures, sig)
+
+			output, err := wire.MarshalJSONIndent(cdc, stdTx)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+// GetBroadcastCommand returns the command to broadcast a transaction signed offline
+func GetBroadcastCommand(cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "broadcast <file>",
+		Short: "Broadcast a transaction signed offline",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stdTx, err := readStdTx(cdc, args[0])
+			if err != nil {
+				return err
+			}
+			txBytes, err := cdc.MarshalBinary(stdTx)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.BroadcastTx(txBytes)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+}
+
+func readStdTx(cdc *wire.Codec, file string) (stdTx auth.StdTx, err error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return
+	}
+	err = cdc.UnmarshalJSON(bz, &stdTx)
+	if err != nil {
+		err = errors.Wrapf(err, "invalid transaction in %s", file)
+	}
+	return
+}
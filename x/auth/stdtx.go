@@ -74,9 +74,12 @@ func FeePayer(tx sdk.Tx) sdk.Address {
 // StdFee includes the amount of coins paid in fees and the maximum
 // gas to be used by the transaction. The ratio yields an effective "gasprice",
 // which must be above some miminum to be accepted into the mempool.
+// The fee is paid by the first signer, or by the granter if set, from the
+// fee allowance it granted to the first signer.
 type StdFee struct {
-	Amount sdk.Coins `json:"amount"`
-	Gas    int64     `json:"gas"`
+	Amount  sdk.Coins   `json:"amount"`
+	Gas     int64       `json:"gas"`
+	Granter sdk.Address `json:"granter,omitempty"`
 }
 
 func NewStdFee(gas int64, amount ...sdk.Coin) StdFee {
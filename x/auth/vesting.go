@@ -0,0 +1,228 @@
+package auth
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// VestingAccount is an account whose original coins are only unlocked over
+// time. Locked coins cannot be spent, but they can be delegated.
+type VestingAccount interface {
+	Account
+
+	// coins of the original vesting amount which are still locked at a block time
+	GetVestingCoins(blockTime int64) sdk.Coins
+	// coins held by the account which can be spent at a block time
+	GetSpendableCoins(blockTime int64) sdk.Coins
+
+	// record the delegation of coins, which are taken from the locked coins first
+	TrackDelegation(blockTime int64, amt sdk.Coins)
+	// record the undelegation of coins, which are returned to the unlocked coins first
+	TrackUndelegation(amt sdk.Coins)
+
+	GetOriginalVesting() sdk.Coins
+	GetDelegatedFree() sdk.Coins
+	GetDelegatedVesting() sdk.Coins
+	GetStartTime() int64
+	GetEndTime() int64
+}
+
+//-----------------------------------------------------------
+// BaseVestingAccount
+
+// BaseVestingAccount - the common fields and logic of the vesting accounts.
+// The vesting schedule itself is implemented by the accounts embedding it.
+type BaseVestingAccount struct {
+	BaseAccount
+
+	OriginalVesting  sdk.Coins `json:"original_vesting"`  // coins locked when the account was created
+	DelegatedFree    sdk.Coins `json:"delegated_free"`    // unlocked coins currently delegated
+	DelegatedVesting sdk.Coins `json:"delegated_vesting"` // locked coins currently delegated
+	EndTime          int64     `json:"end_time"`          // unix time at which all the coins are unlocked
+}
+
+// coins held by the account minus the locked coins which are not delegated
+func (bva BaseVestingAccount) spendableCoins(vestingCoins sdk.Coins) sdk.Coins {
+	var spendable sdk.Coins
+	for _, coin := range bva.Coins {
+		locked := vestingCoins.AmountOf(coin.Denom).Sub(bva.DelegatedVesting.AmountOf(coin.Denom))
+		if locked.Sign() < 0 {
+			locked = sdk.ZeroInt()
+		}
+		amount := coin.Amount.Sub(locked)
+		if amount.Sign() > 0 {
+			spendable = append(spendable, sdk.Coin{Denom: coin.Denom, Amount: amount})
+		}
+	}
+	return spendable
+}
+
+// delegate the locked coins not delegated yet first, then the unlocked coins
+func (bva *BaseVestingAccount) trackDelegation(vestingCoins, amt sdk.Coins) {
+	for _, coin := range amt {
+		lockedFree := vestingCoins.AmountOf(coin.Denom).Sub(bva.DelegatedVesting.AmountOf(coin.Denom))
+		if lockedFree.Sign() < 0 {
+			lockedFree = sdk.ZeroInt()
+		}
+		vesting := minInt(lockedFree, coin.Amount)
+		free := coin.Amount.Sub(vesting)
+
+		if vesting.Sign() > 0 {
+			bva.DelegatedVesting = bva.DelegatedVesting.Plus(sdk.Coins{{Denom: coin.Denom, Amount: vesting}})
+		}
+		if free.Sign() > 0 {
+			bva.DelegatedFree = bva.DelegatedFree.Plus(sdk.Coins{{Denom: coin.Denom, Amount: free}})
+		}
+	}
+}
+
+// Implements VestingAccount.
+// The delegated unlocked coins are returned first, so that coins which
+// unlocked while delegated are not locked again. Slashed delegations return
+// less than was delegated, the difference stays recorded as delegated.
+func (bva *BaseVestingAccount) TrackUndelegation(amt sdk.Coins) {
+	for _, coin := range amt {
+		free := minInt(bva.DelegatedFree.AmountOf(coin.Denom), coin.Amount)
+		vesting := minInt(bva.DelegatedVesting.AmountOf(coin.Denom), coin.Amount.Sub(free))
+
+		if free.Sign() > 0 {
+			bva.DelegatedFree = bva.DelegatedFree.Minus(sdk.Coins{{Denom: coin.Denom, Amount: free}})
+		}
+		if vesting.Sign() > 0 {
+			bva.DelegatedVesting = bva.DelegatedVesting.Minus(sdk.Coins{{Denom: coin.Denom, Amount: vesting}})
+		}
+	}
+}
+
+// Implements VestingAccount.
+func (bva BaseVestingAccount) GetOriginalVesting() sdk.Coins {
+	return bva.OriginalVesting
+}
+
+// Implements VestingAccount.
+func (bva BaseVestingAccount) GetDelegatedFree() sdk.Coins {
+	return bva.DelegatedFree
+}
+
+// Implements VestingAccount.
+func (bva BaseVestingAccount) GetDelegatedVesting() sdk.Coins {
+	return bva.DelegatedVesting
+}
+
+// Implements VestingAccount.
+func (bva BaseVestingAccount) GetEndTime() int64 {
+	return bva.EndTime
+}
+
+func minInt(i1, i2 sdk.Int) sdk.Int {
+	if i1.LT(i2) {
+		return i1
+	}
+	return i2
+}
+
+//-----------------------------------------------------------
+// ContinuousVestingAccount
+
+var _ VestingAccount = (*ContinuousVestingAccount)(nil)
+
+// ContinuousVestingAccount - a vesting account whose coins are unlocked
+// linearly between its start and end time
+type ContinuousVestingAccount struct {
+	BaseVestingAccount
+
+	StartTime int64 `json:"start_time"` // unix time at which the coins start to be unlocked
+}
+
+// NewContinuousVestingAccount returns an account whose coins are all locked
+// at the start time and unlocked linearly until the end time
+func NewContinuousVestingAccount(addr sdk.Address, coins sdk.Coins, startTime, endTime int64) ContinuousVestingAccount {
+	return ContinuousVestingAccount{
+		BaseVestingAccount: BaseVestingAccount{
+			BaseAccount:     BaseAccount{Address: addr, Coins: coins},
+			OriginalVesting: coins,
+			EndTime:         endTime,
+		},
+		StartTime: startTime,
+	}
+}
+
+// Implements VestingAccount.
+func (cva ContinuousVestingAccount) GetVestingCoins(blockTime int64) sdk.Coins {
+	if blockTime <= cva.StartTime {
+		return cva.OriginalVesting
+	}
+	if blockTime >= cva.EndTime {
+		return nil
+	}
+
+	// the locked part of every coin is the time left over the vesting period
+	var vesting sdk.Coins
+	left, period := sdk.NewInt(cva.EndTime-blockTime), sdk.NewInt(cva.EndTime-cva.StartTime)
+	for _, coin := range cva.OriginalVesting {
+		amount := coin.Amount.Mul(left).Div(period)
+		if amount.Sign() > 0 {
+			vesting = append(vesting, sdk.Coin{Denom: coin.Denom, Amount: amount})
+		}
+	}
+	return vesting
+}
+
+// Implements VestingAccount.
+func (cva ContinuousVestingAccount) GetSpendableCoins(blockTime int64) sdk.Coins {
+	return cva.spendableCoins(cva.GetVestingCoins(blockTime))
+}
+
+// Implements VestingAccount.
+func (cva *ContinuousVestingAccount) TrackDelegation(blockTime int64, amt sdk.Coins) {
+	cva.trackDelegation(cva.GetVestingCoins(blockTime), amt)
+}
+
+// Implements VestingAccount.
+func (cva ContinuousVestingAccount) GetStartTime() int64 {
+	return cva.StartTime
+}
+
+//-----------------------------------------------------------
+// DelayedVestingAccount
+
+var _ VestingAccount = (*DelayedVestingAccount)(nil)
+
+// DelayedVestingAccount - a vesting account whose coins are all unlocked at its end time
+type DelayedVestingAccount struct {
+	BaseVestingAccount
+}
+
+// NewDelayedVestingAccount returns an account whose coins are all locked
+// until the end time
+func NewDelayedVestingAccount(addr sdk.Address, coins sdk.Coins, endTime int64) DelayedVestingAccount {
+	return DelayedVestingAccount{
+		BaseVestingAccount: BaseVestingAccount{
+			BaseAccount:     BaseAccount{Address: addr, Coins: coins},
+			OriginalVesting: coins,
+			EndTime:         endTime,
+		},
+	}
+}
+
+// Implements VestingAccount.
+func (dva DelayedVestingAccount) GetVestingCoins(blockTime int64) sdk.Coins {
+	if blockTime >= dva.EndTime {
+		return nil
+	}
+	return dva.OriginalVesting
+}
+
+// Implements VestingAccount.
+func (dva DelayedVestingAccount) GetSpendableCoins(blockTime int64) sdk.Coins {
+	return dva.spendableCoins(dva.GetVestingCoins(blockTime))
+}
+
+// Implements VestingAccount.
+func (dva *DelayedVestingAccount) TrackDelegation(blockTime int64, amt sdk.Coins) {
+	dva.trackDelegation(dva.GetVestingCoins(blockTime), amt)
+}
+
+// Implements VestingAccount.
+func (dva DelayedVestingAccount) GetStartTime() int64 {
+	return 0
+}
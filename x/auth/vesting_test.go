@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+func TestContinuousVestingAccount(t *testing.T) {
+	_, _, addr := keyPubAddr()
+	coins := sdk.Coins{sdk.NewCoin("steak", 100)}
+	acc := NewContinuousVestingAccount(addr, coins, 1000, 2000)
+
+	// all the coins are locked until the start time
+	require.Equal(t, coins, acc.GetVestingCoins(500))
+	require.Nil(t, acc.GetSpendableCoins(1000))
+
+	// unlocked linearly until the end time
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 75)}, acc.GetVestingCoins(1250))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 25)}, acc.GetSpendableCoins(1250))
+	require.Nil(t, acc.GetVestingCoins(2000))
+	require.Equal(t, coins, acc.GetSpendableCoins(2000))
+
+	// received coins are spendable
+	acc.SetCoins(coins.Plus(sdk.Coins{sdk.NewCoin("steak", 10)}))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 35)}, acc.GetSpendableCoins(1250))
+}
+
+func TestDelayedVestingAccount(t *testing.T) {
+	_, _, addr := keyPubAddr()
+	coins := sdk.Coins{sdk.NewCoin("steak", 100)}
+	acc := NewDelayedVestingAccount(addr, coins, 2000)
+
+	// all the coins are locked until the end time
+	require.Equal(t, coins, acc.GetVestingCoins(1999))
+	require.Nil(t, acc.GetSpendableCoins(1999))
+	require.Nil(t, acc.GetVestingCoins(2000))
+	require.Equal(t, coins, acc.GetSpendableCoins(2000))
+}
+
+func TestTrackDelegation(t *testing.T) {
+	_, _, addr := keyPubAddr()
+	coins := sdk.Coins{sdk.NewCoin("steak", 100)}
+	acc := NewContinuousVestingAccount(addr, coins, 1000, 2000)
+
+	// the locked coins are delegated first, so the unlocked coins stay spendable
+	acc.TrackDelegation(1500, sdk.Coins{sdk.NewCoin("steak", 60)})
+	acc.SetCoins(sdk.Coins{sdk.NewCoin("steak", 40)})
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 50)}, acc.GetDelegatedVesting())
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 10)}, acc.GetDelegatedFree())
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 40)}, acc.GetSpendableCoins(1500))
+
+	// the unlocked coins are returned first
+	acc.TrackUndelegation(sdk.Coins{sdk.NewCoin("steak", 30)})
+	acc.SetCoins(sdk.Coins{sdk.NewCoin("steak", 70)})
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 30)}, acc.GetDelegatedVesting())
+	require.Nil(t, acc.GetDelegatedFree())
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 50)}, acc.GetSpendableCoins(1500))
+
+	// once unlocked, every coin held can be spent
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 70)}, acc.GetSpendableCoins(2000))
+}
@@ -0,0 +1,42 @@
+package authz
+
+import (
+	"reflect"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// MsgName returns the name identifying a type of msg in authorizations,
+// made of its route and its type name, e.g. "gov/MsgVote"
+func MsgName(msg sdk.Msg) string {
+	return msg.Type() + "/" + reflect.Indirect(reflect.ValueOf(msg)).Type().Name()
+}
+
+// Authorization - a granter's authorization for a grantee to execute a type
+// of msg on its behalf
+type Authorization struct {
+	MsgName       string `json:"msg_name"`       // name of the msgs the grantee can execute
+	RemainingUses int64  `json:"remaining_uses"` // number of msgs the grantee can still execute, no limit if zero
+	Expiration    int64  `json:"expiration"`     // unix time from which the authorization can't be used, never if zero
+}
+
+// NewAuthorization creates an authorization
+func NewAuthorization(msgName string, maxUses, expiration int64) Authorization {
+	return Authorization{
+		MsgName:       msgName,
+		RemainingUses: maxUses,
+		Expiration:    expiration,
+	}
+}
+
+// IsExpired returns true if the authorization can't be used anymore at the block time
+func (authorization Authorization) IsExpired(blockTime int64) bool {
+	return authorization.Expiration != 0 && blockTime >= authorization.Expiration
+}
+
+// Grant - the authorization a granter gave to a grantee
+type Grant struct {
+	Granter       sdk.Address   `json:"granter"`
+	Grantee       sdk.Address   `json:"grantee"`
+	Authorization Authorization `json:"authorization"`
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tepleton/tepleton-sdk/client/context"
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+	"github.com/tepleton/tepleton-sdk/x/authz"
+)
+
+// get the command to query the authorization of a granter to a grantee for a type of msg
+func GetCmdQueryAuthorization(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "authorization [granter] [grantee] [msg-name]",
+		Short: "Query the authorization a granter gave to a grantee to execute a type of msg",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			granter, err := sdk.GetAccAddressBech32(args[0])
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.GetAccAddressBech32(args[1])
+			if err != nil {
+				return err
+			}
+			key := authz.GetAuthorizationKey(granter, grantee, args[2])
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.QueryStore(key, storeName)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return errors.Errorf("no authorization from %s to %s for %s", args[0], args[1], args[2])
+			}
+			var authorization authz.Authorization
+			cdc.MustUnmarshalBinary(res, &authorization)
+
+			output, err := wire.MarshalJSONIndent(cdc, authorization)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+	return cmd
+}
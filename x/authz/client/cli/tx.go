@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tepleton/tepleton-sdk/client/context"
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+	"github.com/tepleton/tepleton-sdk/x/auth"
+	authcmd "github.com/tepleton/tepleton-sdk/x/auth/client/cli"
+	"github.com/tepleton/tepleton-sdk/x/authz"
+)
+
+const (
+	flagMaxUses    = "max-uses"
+	flagExpiration = "expiration"
+)
+
+// create grant authorization command
+func GetCmdGrantAuthorization(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant [grantee] [msg-name]",
+		Args:  cobra.ExactArgs(2),
+		Short: "authorize an account to execute a type of msg on your behalf, e.g. gov/MsgVote",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			granter, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.GetAccAddressBech32(args[0])
+			if err != nil {
+				return err
+			}
+			authorization := authz.NewAuthorization(args[1], viper.GetInt64(flagMaxUses), viper.GetInt64(flagExpiration))
+
+			msg := authz.NewMsgGrantAuthorization(granter, grantee, authorization)
+
+			// build and sign the transaction, then broadcast to Tendermint
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagMaxUses, 0, "Number of msgs the grantee can execute, no limit if zero")
+	cmd.Flags().Int64(flagExpiration, 0, "Unix time from which the authorization expires, never if zero")
+	return cmd
+}
+
+// create revoke authorization command
+func GetCmdRevokeAuthorization(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke [grantee] [msg-name]",
+		Args:  cobra.ExactArgs(2),
+		Short: "revoke the authorization of an account to execute a type of msg",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			granter, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.GetAccAddressBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := authz.NewMsgRevokeAuthorization(granter, grantee, args[1])
+
+			// build and sign the transaction, then broadcast to Tendermint
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+	return cmd
+}
+
+// create exec command, executing the msgs of a transaction generated with
+// --generate-only on behalf of their signers
+func GetCmdExec(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec [tx-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "execute the msgs of a generated transaction on behalf of the accounts which authorized you",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var stdTx auth.StdTx
+			if err = cdc.UnmarshalJSON(bz, &stdTx); err != nil {
+				return errors.Wrapf(err, "invalid transaction in %s", args[0])
+			}
+
+			grantee, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			msg := authz.NewMsgExec(grantee, stdTx.GetMsgs())
+
+			// build and sign the transaction, then broadcast to Tendermint
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,56 @@
+//nolint
+package authz
+
+import (
+	"fmt"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default authz codespace
+	DefaultCodespace sdk.CodespaceType = 13
+
+	CodeInvalidAddress       CodeType = 101
+	CodeInvalidAuthorization CodeType = 102
+	CodeNoAuthorization      CodeType = 103
+	CodeAuthorizationExpired CodeType = 104
+	CodeInvalidMsgs          CodeType = 105
+)
+
+func ErrNilGranterAddr(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAddress, "granter address is nil")
+}
+func ErrNilGranteeAddr(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAddress, "grantee address is nil")
+}
+func ErrAddrTooLong(codespace sdk.CodespaceType, addr sdk.Address) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAddress, fmt.Sprintf("address %s is longer than %d bytes", addr, maxAddrLen))
+}
+func ErrSelfGrant(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAddress, "cannot grant an authorization to oneself")
+}
+func ErrEmptyMsgName(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAuthorization, "msg name of the authorization is empty")
+}
+func ErrInvalidUses(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAuthorization, "maximum number of uses must not be negative")
+}
+func ErrInvalidExpiration(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAuthorization, "expiration must not be negative")
+}
+func ErrNoAuthorization(codespace sdk.CodespaceType, granter sdk.Address, msgName string) sdk.Error {
+	return sdk.NewError(codespace, CodeNoAuthorization, fmt.Sprintf("no authorization from %s to execute %s", granter, msgName))
+}
+func ErrAuthorizationExpired(codespace sdk.CodespaceType, granter sdk.Address, msgName string) sdk.Error {
+	return sdk.NewError(codespace, CodeAuthorizationExpired, fmt.Sprintf("authorization from %s to execute %s expired", granter, msgName))
+}
+func ErrNoMsgs(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidMsgs, "no msgs to execute")
+}
+func ErrUnknownMsgRoute(codespace sdk.CodespaceType, route string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidMsgs, fmt.Sprintf("no handler for the msgs of %s", route))
+}
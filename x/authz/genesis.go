@@ -0,0 +1,36 @@
+package authz
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// GenesisState - all authz state that must be provided at genesis
+type GenesisState struct {
+	Grants []Grant `json:"grants"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Grants: []Grant{},
+	}
+}
+
+// InitGenesis - store the genesis grants
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	for _, grant := range data.Grants {
+		k.GrantAuthorization(ctx, grant.Granter, grant.Grantee, grant.Authorization)
+	}
+}
+
+// WriteGenesis - output all the grants
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	grants := []Grant{}
+	k.IterateGrants(ctx, func(grant Grant) (stop bool) {
+		grants = append(grants, grant)
+		return false
+	})
+	return GenesisState{
+		Grants: grants,
+	}
+}
@@ -0,0 +1,69 @@
+package authz
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// NewHandler handles the authz messages
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		// NOTE msg already has validate basic run
+		switch msg := msg.(type) {
+		case MsgGrantAuthorization:
+			return handleMsgGrantAuthorization(ctx, msg, k)
+		case MsgRevokeAuthorization:
+			return handleMsgRevokeAuthorization(ctx, msg, k)
+		case MsgExec:
+			return handleMsgExec(ctx, msg, k)
+		default:
+			return sdk.ErrTxDecode("invalid message parse in authz module").Result()
+		}
+	}
+}
+
+func handleMsgGrantAuthorization(ctx sdk.Context, msg MsgGrantAuthorization, k Keeper) sdk.Result {
+	if msg.Authorization.IsExpired(ctx.BlockHeader().Time) {
+		return ErrAuthorizationExpired(k.codespace, msg.Granter, msg.Authorization.MsgName).Result()
+	}
+
+	k.GrantAuthorization(ctx, msg.Granter, msg.Grantee, msg.Authorization)
+
+	tags := sdk.NewTags(
+		"action", []byte("grantAuthorization"),
+		"granter", []byte(msg.Granter.String()),
+		"grantee", []byte(msg.Grantee.String()),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+func handleMsgRevokeAuthorization(ctx sdk.Context, msg MsgRevokeAuthorization, k Keeper) sdk.Result {
+	if _, found := k.GetAuthorization(ctx, msg.Granter, msg.Grantee, msg.MsgName); !found {
+		return ErrNoAuthorization(k.codespace, msg.Granter, msg.MsgName).Result()
+	}
+
+	k.RevokeAuthorization(ctx, msg.Granter, msg.Grantee, msg.MsgName)
+
+	tags := sdk.NewTags(
+		"action", []byte("revokeAuthorization"),
+		"granter", []byte(msg.Granter.String()),
+		"grantee", []byte(msg.Grantee.String()),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+func handleMsgExec(ctx sdk.Context, msg MsgExec, k Keeper) sdk.Result {
+	res := k.DispatchMsgs(ctx, msg.Grantee, msg.Msgs)
+	if !res.IsOK() {
+		return res
+	}
+
+	res.Tags = sdk.NewTags(
+		"action", []byte("exec"),
+		"grantee", []byte(msg.Grantee.String()),
+	).AppendTags(res.Tags)
+	return res
+}
@@ -0,0 +1,155 @@
+package authz
+
+import (
+	"bytes"
+
+	"github.com/tepleton/tepleton-sdk/baseapp"
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+)
+
+// Keeper of the authz store
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *wire.Codec
+	router   baseapp.Router // routes the msgs executed on behalf of granters
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper creates an authz keeper, executing msgs through the given router
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, router baseapp.Router, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:  key,
+		cdc:       cdc,
+		router:    router,
+		codespace: codespace,
+	}
+}
+
+// the addresses in the keys are prefixed with their length in a single byte
+const maxAddrLen = 255
+
+// Key for the authorization of a granter to a grantee, for a msg name
+func GetAuthorizationKey(granter, grantee sdk.Address, msgName string) []byte {
+	return append(GetAuthorizationsKey(granter, grantee), []byte(msgName)...)
+}
+
+// Key prefix for all the authorizations of a granter to a grantee, each
+// address is prefixed with its length to split them back
+func GetAuthorizationsKey(granter, grantee sdk.Address) []byte {
+	key := append([]byte{0x00}, byte(len(granter)))
+	key = append(key, granter.Bytes()...)
+	key = append(key, byte(len(grantee)))
+	return append(key, grantee.Bytes()...)
+}
+
+// split an authorization key into the granter and grantee addresses and the msg name
+func splitAuthorizationKey(key []byte) (granter, grantee sdk.Address, msgName string) {
+	granterLen := int(key[1])
+	granter = sdk.Address(key[2 : 2+granterLen])
+	key = key[2+granterLen:]
+	granteeLen := int(key[0])
+	grantee = sdk.Address(key[1 : 1+granteeLen])
+	return granter, grantee, string(key[1+granteeLen:])
+}
+
+// GetAuthorization returns the authorization of a granter to a grantee for a msg name
+func (k Keeper) GetAuthorization(ctx sdk.Context, granter, grantee sdk.Address, msgName string) (authorization Authorization, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetAuthorizationKey(granter, grantee, msgName))
+	if bz == nil {
+		return authorization, false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &authorization)
+	return authorization, true
+}
+
+// GrantAuthorization sets the authorization of a granter to a grantee, replacing the
+// former one for the same msg name if any
+func (k Keeper) GrantAuthorization(ctx sdk.Context, granter, grantee sdk.Address, authorization Authorization) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(authorization)
+	store.Set(GetAuthorizationKey(granter, grantee, authorization.MsgName), bz)
+}
+
+// RevokeAuthorization removes the authorization of a granter to a grantee for a msg name
+func (k Keeper) RevokeAuthorization(ctx sdk.Context, granter, grantee sdk.Address, msgName string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetAuthorizationKey(granter, grantee, msgName))
+}
+
+// IterateGrants iterates over all the grants
+func (k Keeper) IterateGrants(ctx sdk.Context, fn func(grant Grant) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, []byte{0x00})
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var authorization Authorization
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &authorization)
+		granter, grantee, _ := splitAuthorizationKey(iterator.Key())
+		grant := Grant{
+			Granter:       granter,
+			Grantee:       grantee,
+			Authorization: authorization,
+		}
+		if fn(grant) {
+			return
+		}
+	}
+}
+
+// use the authorization of a granter for the grantee to execute a msg
+func (k Keeper) useAuthorization(ctx sdk.Context, granter, grantee sdk.Address, msg sdk.Msg) sdk.Error {
+	msgName := MsgName(msg)
+	authorization, found := k.GetAuthorization(ctx, granter, grantee, msgName)
+	if !found {
+		return ErrNoAuthorization(k.codespace, granter, msgName)
+	}
+	if authorization.IsExpired(ctx.BlockHeader().Time) {
+		return ErrAuthorizationExpired(k.codespace, granter, msgName)
+	}
+
+	// an authorization without limit of uses doesn't change
+	if authorization.RemainingUses == 0 {
+		return nil
+	}
+	authorization.RemainingUses--
+	if authorization.RemainingUses == 0 {
+		k.RevokeAuthorization(ctx, granter, grantee, msgName)
+		return nil
+	}
+	k.GrantAuthorization(ctx, granter, grantee, authorization)
+	return nil
+}
+
+// DispatchMsgs executes msgs on behalf of their signers, which must have authorized
+// the grantee to execute them. The msgs are dispatched to the handlers of
+// their routes as if they were signed by their signers.
+func (k Keeper) DispatchMsgs(ctx sdk.Context, grantee sdk.Address, msgs []sdk.Msg) sdk.Result {
+	result := sdk.Result{}
+	for _, msg := range msgs {
+		for _, signer := range msg.GetSigners() {
+			if bytes.Equal(signer, grantee) {
+				continue
+			}
+			err := k.useAuthorization(ctx, signer, grantee, msg)
+			if err != nil {
+				return err.Result()
+			}
+		}
+
+		handler := k.router.Route(msg.Type())
+		if handler == nil {
+			return ErrUnknownMsgRoute(k.codespace, msg.Type()).Result()
+		}
+		res := handler(ctx, msg)
+		if !res.IsOK() {
+			return res
+		}
+		result.Data = append(result.Data, res.Data...)
+		result.Tags = result.Tags.AppendTags(res.Tags)
+	}
+	return result
+}
@@ -0,0 +1,113 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tepleton/tepleton/crypto"
+
+	"github.com/tepleton/tepleton-sdk/baseapp"
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+	"github.com/tepleton/tepleton-sdk/x/mock"
+)
+
+var (
+	granter = sdk.Address(crypto.GenPrivKeyEd25519().PubKey().Address())
+	grantee = sdk.Address(crypto.GenPrivKeyEd25519().PubKey().Address())
+)
+
+// creates a keeper whose router counts the msgs executed by a test handler
+func createTestInput(t *testing.T) (sdk.Context, Keeper, *int) {
+	keyAuthz := sdk.NewKVStoreKey("authz")
+	ctx := mock.NewTestContext(t, 1000, keyAuthz)
+	cdc := wire.NewCodec()
+	RegisterWire(cdc)
+
+	executed := 0
+	router := baseapp.NewRouter()
+	router.AddRoute("TestMsg", func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		executed++
+		return sdk.Result{}
+	})
+	return ctx, NewKeeper(cdc, keyAuthz, router, DefaultCodespace), &executed
+}
+
+func TestDispatchMsgs(t *testing.T) {
+	ctx, k, executed := createTestInput(t)
+	msg := sdk.NewTestMsg(granter)
+	msgName := MsgName(msg)
+	require.Equal(t, "TestMsg/TestMsg", msgName)
+
+	// no authorization
+	res := k.DispatchMsgs(ctx, grantee, []sdk.Msg{msg})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeNoAuthorization), res.Code)
+	require.Equal(t, 0, *executed)
+
+	// msgs signed by the grantee itself don't need an authorization
+	res = k.DispatchMsgs(ctx, grantee, []sdk.Msg{sdk.NewTestMsg(grantee)})
+	require.True(t, res.IsOK())
+	require.Equal(t, 1, *executed)
+
+	// the uses decrease, the authorization is removed once used up
+	k.GrantAuthorization(ctx, granter, grantee, NewAuthorization(msgName, 2, 0))
+	res = k.DispatchMsgs(ctx, grantee, []sdk.Msg{msg})
+	require.True(t, res.IsOK())
+	require.Equal(t, 2, *executed)
+	authorization, found := k.GetAuthorization(ctx, granter, grantee, msgName)
+	require.True(t, found)
+	require.Equal(t, int64(1), authorization.RemainingUses)
+	res = k.DispatchMsgs(ctx, grantee, []sdk.Msg{msg})
+	require.True(t, res.IsOK())
+	_, found = k.GetAuthorization(ctx, granter, grantee, msgName)
+	require.False(t, found)
+
+	// an authorization without limit of uses stays
+	k.GrantAuthorization(ctx, granter, grantee, NewAuthorization(msgName, 0, 0))
+	res = k.DispatchMsgs(ctx, grantee, []sdk.Msg{msg, msg})
+	require.True(t, res.IsOK())
+	require.Equal(t, 5, *executed)
+	_, found = k.GetAuthorization(ctx, granter, grantee, msgName)
+	require.True(t, found)
+
+	// the authorization is only for the grantee
+	res = k.DispatchMsgs(ctx, granter, []sdk.Msg{sdk.NewTestMsg(grantee)})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeNoAuthorization), res.Code)
+
+	// expired authorization
+	k.GrantAuthorization(ctx, granter, grantee, NewAuthorization(msgName, 0, 1000))
+	res = k.DispatchMsgs(ctx, grantee, []sdk.Msg{msg})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeAuthorizationExpired), res.Code)
+	require.Equal(t, 5, *executed)
+
+	// revoked authorization
+	k.RevokeAuthorization(ctx, granter, grantee, msgName)
+	res = k.DispatchMsgs(ctx, grantee, []sdk.Msg{msg})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeNoAuthorization), res.Code)
+}
+
+func TestExportGrants(t *testing.T) {
+	ctx, k, _ := createTestInput(t)
+	k.GrantAuthorization(ctx, granter, grantee, NewAuthorization("gov/MsgVote", 3, 2000))
+	k.GrantAuthorization(ctx, granter, grantee, NewAuthorization("stake/MsgDelegate", 0, 0))
+
+	genesis := WriteGenesis(ctx, k)
+	require.Len(t, genesis.Grants, 2)
+	for _, grant := range genesis.Grants {
+		require.Equal(t, granter, grant.Granter)
+		require.Equal(t, grantee, grant.Grantee)
+	}
+	require.Equal(t, NewAuthorization("gov/MsgVote", 3, 2000), genesis.Grants[0].Authorization)
+
+	// addresses of different lengths are split back from the keys
+	k.RevokeAuthorization(ctx, granter, grantee, "gov/MsgVote")
+	k.RevokeAuthorization(ctx, granter, grantee, "stake/MsgDelegate")
+	shortGrantee := sdk.Address("grantee")
+	k.GrantAuthorization(ctx, granter, shortGrantee, NewAuthorization("gov/MsgVote", 0, 0))
+	genesis = WriteGenesis(ctx, k)
+	require.Len(t, genesis.Grants, 1)
+	require.Equal(t, granter, genesis.Grants[0].Granter)
+	require.Equal(t, shortGrantee, genesis.Grants[0].Grantee)
+	require.Equal(t, "gov/MsgVote", genesis.Grants[0].Authorization.MsgName)
+}
@@ -0,0 +1,195 @@
+package authz
+
+import (
+	"bytes"
+	"encoding/json"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// name to identify transaction types
+const MsgType = "authz"
+
+// verify interface at compile time
+var _, _, _ sdk.Msg = MsgGrantAuthorization{}, MsgRevokeAuthorization{}, MsgExec{}
+
+//______________________________________________________________________
+
+// MsgGrantAuthorization - struct for authorizing an account to execute a type of msg
+type MsgGrantAuthorization struct {
+	Granter       sdk.Address   `json:"granter"`
+	Grantee       sdk.Address   `json:"grantee"`
+	Authorization Authorization `json:"authorization"`
+}
+
+func NewMsgGrantAuthorization(granter, grantee sdk.Address, authorization Authorization) MsgGrantAuthorization {
+	return MsgGrantAuthorization{
+		Granter:       granter,
+		Grantee:       grantee,
+		Authorization: authorization,
+	}
+}
+
+//nolint
+func (msg MsgGrantAuthorization) Type() string              { return MsgType }
+func (msg MsgGrantAuthorization) GetSigners() []sdk.Address { return []sdk.Address{msg.Granter} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgGrantAuthorization) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Granter       string        `json:"granter"`
+		Grantee       string        `json:"grantee"`
+		Authorization Authorization `json:"authorization"`
+	}{
+		Granter:       sdk.MustBech32ifyAcc(msg.Granter),
+		Grantee:       sdk.MustBech32ifyAcc(msg.Grantee),
+		Authorization: msg.Authorization,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgGrantAuthorization) ValidateBasic() sdk.Error {
+	if len(msg.Granter) == 0 {
+		return ErrNilGranterAddr(DefaultCodespace)
+	}
+	if len(msg.Grantee) == 0 {
+		return ErrNilGranteeAddr(DefaultCodespace)
+	}
+	if len(msg.Granter) > maxAddrLen {
+		return ErrAddrTooLong(DefaultCodespace, msg.Granter)
+	}
+	if len(msg.Grantee) > maxAddrLen {
+		return ErrAddrTooLong(DefaultCodespace, msg.Grantee)
+	}
+	if bytes.Equal(msg.Granter, msg.Grantee) {
+		return ErrSelfGrant(DefaultCodespace)
+	}
+	if len(msg.Authorization.MsgName) == 0 {
+		return ErrEmptyMsgName(DefaultCodespace)
+	}
+	if msg.Authorization.RemainingUses < 0 {
+		return ErrInvalidUses(DefaultCodespace)
+	}
+	if msg.Authorization.Expiration < 0 {
+		return ErrInvalidExpiration(DefaultCodespace)
+	}
+	return nil
+}
+
+//______________________________________________________________________
+
+// MsgRevokeAuthorization - struct for revoking the authorization of an account to execute a type of msg
+type MsgRevokeAuthorization struct {
+	Granter sdk.Address `json:"granter"`
+	Grantee sdk.Address `json:"grantee"`
+	MsgName string      `json:"msg_name"`
+}
+
+func NewMsgRevokeAuthorization(granter, grantee sdk.Address, msgName string) MsgRevokeAuthorization {
+	return MsgRevokeAuthorization{
+		Granter: granter,
+		Grantee: grantee,
+		MsgName: msgName,
+	}
+}
+
+//nolint
+func (msg MsgRevokeAuthorization) Type() string              { return MsgType }
+func (msg MsgRevokeAuthorization) GetSigners() []sdk.Address { return []sdk.Address{msg.Granter} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgRevokeAuthorization) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Granter string `json:"granter"`
+		Grantee string `json:"grantee"`
+		MsgName string `json:"msg_name"`
+	}{
+		Granter: sdk.MustBech32ifyAcc(msg.Granter),
+		Grantee: sdk.MustBech32ifyAcc(msg.Grantee),
+		MsgName: msg.MsgName,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgRevokeAuthorization) ValidateBasic() sdk.Error {
+	if len(msg.Granter) == 0 {
+		return ErrNilGranterAddr(DefaultCodespace)
+	}
+	if len(msg.Grantee) == 0 {
+		return ErrNilGranteeAddr(DefaultCodespace)
+	}
+	if len(msg.Granter) > maxAddrLen {
+		return ErrAddrTooLong(DefaultCodespace, msg.Granter)
+	}
+	if len(msg.Grantee) > maxAddrLen {
+		return ErrAddrTooLong(DefaultCodespace, msg.Grantee)
+	}
+	if len(msg.MsgName) == 0 {
+		return ErrEmptyMsgName(DefaultCodespace)
+	}
+	return nil
+}
+
+//______________________________________________________________________
+
+// MsgExec - struct for executing msgs on behalf of the accounts which
+// authorized the grantee to execute them
+type MsgExec struct {
+	Grantee sdk.Address `json:"grantee"`
+	Msgs    []sdk.Msg   `json:"msgs"`
+}
+
+func NewMsgExec(grantee sdk.Address, msgs []sdk.Msg) MsgExec {
+	return MsgExec{
+		Grantee: grantee,
+		Msgs:    msgs,
+	}
+}
+
+//nolint
+func (msg MsgExec) Type() string              { return MsgType }
+func (msg MsgExec) GetSigners() []sdk.Address { return []sdk.Address{msg.Grantee} }
+
+// get the bytes for the message signer to sign on, made of the sign bytes
+// of the executed msgs
+func (msg MsgExec) GetSignBytes() []byte {
+	msgs := make([]json.RawMessage, len(msg.Msgs))
+	for i, m := range msg.Msgs {
+		msgs[i] = json.RawMessage(m.GetSignBytes())
+	}
+	b, err := msgCdc.MarshalJSON(struct {
+		Grantee string            `json:"grantee"`
+		Msgs    []json.RawMessage `json:"msgs"`
+	}{
+		Grantee: sdk.MustBech32ifyAcc(msg.Grantee),
+		Msgs:    msgs,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check, also checking the executed msgs
+func (msg MsgExec) ValidateBasic() sdk.Error {
+	if len(msg.Grantee) == 0 {
+		return ErrNilGranteeAddr(DefaultCodespace)
+	}
+	if len(msg.Msgs) == 0 {
+		return ErrNoMsgs(DefaultCodespace)
+	}
+	for _, m := range msg.Msgs {
+		if err := m.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
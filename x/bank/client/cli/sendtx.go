@@ -17,6 +17,8 @@ const (
 	flagTo     = "to"
 	flagAmount = "amount"
 	flagAsync  = "async"
+
+	flagGenerateOnly = "generate-only"
 )
 
 // SendTxCommand will create a send tx and sign it with the given key
@@ -49,6 +51,20 @@ func SendTxCmd(cdc *wire.Codec) *cobra.Command {
 			// build and sign the transaction, then broadcast to Tendermint
 			msg := client.BuildMsg(from, to, coins)
 
+			// print the unsigned transaction, to be signed offline
+			if viper.GetBool(flagGenerateOnly) {
+				stdTx, err := ctx.BuildUnsignedStdTx([]sdk.Msg{msg})
+				if err != nil {
+					return err
+				}
+				output, err := wire.MarshalJSONIndent(cdc, stdTx)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(output))
+				return nil
+			}
+
 			if viper.GetBool(flagAsync) {
 				res, err := ctx.EnsureSignBuildBroadcastAsync(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
 				if err != nil {
@@ -70,6 +86,7 @@ func SendTxCmd(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().String(flagTo, "", "Address to send coins")
 	cmd.Flags().String(flagAmount, "", "Amount of coins to send")
 	cmd.Flags().Bool(flagAsync, false, "Pass the async flag to send a tx without waiting for the tx to be included in a block")
+	cmd.Flags().Bool(flagGenerateOnly, false, "Print the unsigned transaction instead of signing and broadcasting it")
 
 	return cmd
 }
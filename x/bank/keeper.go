@@ -55,6 +55,17 @@ func (keeper Keeper) SendCoins(ctx sdk.Context, fromAddr sdk.Address, toAddr sdk
 	return sendCoins(ctx, keeper.am, fromAddr, toAddr, amt)
 }
 
+// DelegateCoins subtracts amt delegated to x/stake from the coins at the addr.
+// Unlike SubtractCoins, the locked coins of a vesting account can be delegated.
+func (keeper Keeper) DelegateCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	return delegateCoins(ctx, keeper.am, addr, amt)
+}
+
+// UndelegateCoins adds amt returned by x/stake to the coins at the addr.
+func (keeper Keeper) UndelegateCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	return undelegateCoins(ctx, keeper.am, addr, amt)
+}
+
 // InputOutputCoins handles a list of inputs and outputs
 func (keeper Keeper) InputOutputCoins(ctx sdk.Context, inputs []Input, outputs []Output) (sdk.Tags, sdk.Error) {
 	return inputOutputCoins(ctx, keeper.am, inputs, outputs)
@@ -147,8 +158,15 @@ func hasCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.Address, amt sdk.
 }
 
 // SubtractCoins subtracts amt from the coins at the addr.
+// The locked coins of a vesting account cannot be subtracted.
 func subtractCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.Address, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
 	ctx.GasMeter().ConsumeGas(costSubtractCoins, "subtractCoins")
+	if vacc, ok := am.GetAccount(ctx, addr).(auth.VestingAccount); ok {
+		spendable := vacc.GetSpendableCoins(ctx.BlockHeader().Time)
+		if !spendable.Minus(amt).IsNotNegative() {
+			return amt, nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s spendable < %s", spendable, amt))
+		}
+	}
 	oldCoins := getCoins(ctx, am, addr)
 	newCoins := oldCoins.Minus(amt)
 	if !newCoins.IsNotNegative() {
@@ -172,6 +190,55 @@ func addCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.Address, amt sdk.
 	return newCoins, tags, err
 }
 
+// DelegateCoins subtracts delegated coins from the coins at the addr,
+// recording the locked coins they are taken from for a vesting account.
+func delegateCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.Address, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	ctx.GasMeter().ConsumeGas(costSubtractCoins, "delegateCoins")
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		return nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", sdk.Coins{}, amt))
+	}
+	oldCoins := acc.GetCoins()
+	newCoins := oldCoins.Minus(amt)
+	if !newCoins.IsNotNegative() {
+		return nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", oldCoins, amt))
+	}
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		vacc.TrackDelegation(ctx.BlockHeader().Time, amt)
+	}
+	err := acc.SetCoins(newCoins)
+	if err != nil {
+		// Handle w/ #870
+		panic(err)
+	}
+	am.SetAccount(ctx, acc)
+	return sdk.NewTags("sender", []byte(addr.String())), nil
+}
+
+// UndelegateCoins adds undelegated coins to the coins at the addr,
+// recording their return for a vesting account.
+func undelegateCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.Address, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	ctx.GasMeter().ConsumeGas(costAddCoins, "undelegateCoins")
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		acc = am.NewAccountWithAddress(ctx, addr)
+	}
+	newCoins := acc.GetCoins().Plus(amt)
+	if !newCoins.IsNotNegative() {
+		return nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", acc.GetCoins(), amt))
+	}
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		vacc.TrackUndelegation(amt)
+	}
+	err := acc.SetCoins(newCoins)
+	if err != nil {
+		// Handle w/ #870
+		panic(err)
+	}
+	am.SetAccount(ctx, acc)
+	return sdk.NewTags("recipient", []byte(addr.String())), nil
+}
+
 // SendCoins moves coins from one account to another
 // NOTE: Make sure to revert state changes from tx on error
 func sendCoins(ctx sdk.Context, am auth.AccountMapper, fromAddr sdk.Address, toAddr sdk.Address, amt sdk.Coins) (sdk.Tags, sdk.Error) {
@@ -111,6 +111,45 @@ func TestKeeper(t *testing.T) {
 
 }
 
+func TestVestingAccountKeeper(t *testing.T) {
+	ms, authKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, wrsp.Header{Time: 1500}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
+	coinKeeper := NewKeeper(accountMapper)
+
+	addr := sdk.Address([]byte("addr1"))
+	addr2 := sdk.Address([]byte("addr2"))
+	acc := auth.NewContinuousVestingAccount(addr, sdk.Coins{sdk.NewCoin("steak", 100)}, 1000, 2000)
+	accountMapper.SetAccount(ctx, &acc)
+
+	// locked coins cannot be sent
+	_, err := coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewCoin("steak", 51)})
+	require.NotNil(t, err)
+	_, err = coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewCoin("steak", 10)})
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("steak", 90)}))
+
+	// but they can be delegated, after which the unlocked coins stay spendable
+	_, err = coinKeeper.DelegateCoins(ctx, addr, sdk.Coins{sdk.NewCoin("steak", 50)})
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("steak", 40)}))
+	_, _, err = coinKeeper.SubtractCoins(ctx, addr, sdk.Coins{sdk.NewCoin("steak", 40)})
+	require.Nil(t, err)
+
+	// undelegated coins are locked again until they unlock
+	_, err = coinKeeper.UndelegateCoins(ctx, addr, sdk.Coins{sdk.NewCoin("steak", 50)})
+	require.Nil(t, err)
+	_, _, err = coinKeeper.SubtractCoins(ctx, addr, sdk.Coins{sdk.NewCoin("steak", 1)})
+	require.NotNil(t, err)
+	ctx = ctx.WithBlockHeader(wrsp.Header{Time: 2000})
+	_, _, err = coinKeeper.SubtractCoins(ctx, addr, sdk.Coins{sdk.NewCoin("steak", 50)})
+	require.Nil(t, err)
+}
+
 func TestSendKeeper(t *testing.T) {
 	ms, authKey := setupMultiStore()
 
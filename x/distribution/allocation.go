@@ -0,0 +1,120 @@
+package distribution
+
+import (
+	"bytes"
+
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// rewards are rounded down to multiples of 1/rewardsPrecision, 10 decimals
+const rewardsPrecision = 10000000000
+
+// Allocate the fees collected during the previous block and the pending
+// inflation provisions to the bonded validators. The proposer of the previous
+// block receives a bonus depending on the power of the precommits it
+// included, the rest is split by power. Each validator takes its commission
+// and the remainder accrues to its delegators per delegator share.
+func (k Keeper) AllocateRewards(ctx sdk.Context, signingValidators []wrsp.SigningValidator) {
+
+	rewards := NewRatCoins(k.feeKeeper.GetCollectedFees(ctx))
+	k.feeKeeper.ClearCollectedFees(ctx)
+
+	pool := k.stakeKeeper.GetPool(ctx)
+	if pool.PendingProvisions > 0 {
+		bondDenom := k.stakeKeeper.GetParams(ctx).BondDenom
+		provisions := sdk.Coins{sdk.NewCoin(bondDenom, pool.PendingProvisions)}
+		rewards = rewards.Plus(NewRatCoins(provisions))
+		pool.PendingProvisions = 0
+		k.stakeKeeper.SetPool(ctx, pool)
+	}
+
+	rewards = rewards.Plus(k.GetUnallocatedRewards(ctx))
+	if rewards.IsZero() {
+		return
+	}
+
+	// collect the bonded validators
+	validators := []sdk.Validator{}
+	totalPower := sdk.ZeroRat()
+	k.stakeKeeper.IterateValidatorsBonded(ctx, func(_ int64, validator sdk.Validator) (stop bool) {
+		validators = append(validators, validator)
+		totalPower = totalPower.Add(validator.GetPower())
+		return false
+	})
+
+	// keep the rewards for later if there is nobody to reward
+	if totalPower.IsZero() {
+		k.setUnallocatedRewards(ctx, rewards)
+		return
+	}
+	k.setUnallocatedRewards(ctx, RatCoins{})
+
+	// reward the proposer of the previous block
+	remaining := rewards
+	proposer := k.GetPreviousProposer(ctx)
+	for _, validator := range validators {
+		if proposer == nil || !bytes.Equal(validator.GetPubKey().Address(), proposer) {
+			continue
+		}
+		proposerReward := rewards.MulRat(k.proposerRewardFraction(ctx, signingValidators))
+		k.allocateValidatorRewards(ctx, validator, proposerReward)
+		remaining = remaining.Minus(proposerReward)
+		break
+	}
+
+	// split the rest by power
+	for _, validator := range validators {
+		reward := remaining.MulRat(validator.GetPower().Quo(totalPower))
+		k.allocateValidatorRewards(ctx, validator, reward)
+	}
+}
+
+// fraction of the rewards given to the proposer, increasing with the power
+// of the precommits included in the block
+func (k Keeper) proposerRewardFraction(ctx sdk.Context, signingValidators []wrsp.SigningValidator) sdk.Rat {
+	var signedPower, totalPower int64
+	for _, signingValidator := range signingValidators {
+		totalPower += signingValidator.Validator.Power
+		if signingValidator.SignedLastBlock {
+			signedPower += signingValidator.Validator.Power
+		}
+	}
+
+	fraction := k.ProposerRewardBase(ctx)
+	if totalPower > 0 {
+		bonus := k.ProposerRewardBonus(ctx).Mul(sdk.NewRat(signedPower, totalPower))
+		fraction = fraction.Add(bonus)
+	}
+	return fraction
+}
+
+// take the commission of the validator and accrue the rest to its delegators.
+// The amounts are rounded down to a fixed precision so that their
+// denominators don't grow from block to block, and the rounding dust is
+// allocated with the rewards of the next block.
+func (k Keeper) allocateValidatorRewards(ctx sdk.Context, validator sdk.Validator, reward RatCoins) {
+	info, _ := k.GetValidatorDistInfo(ctx, validator.GetOwner())
+
+	delegatorShares := validator.GetDelegatorShares()
+	commission := reward
+	if !delegatorShares.IsZero() {
+		commission = reward.MulRat(validator.GetCommission())
+	}
+	commission = commission.RoundDown(rewardsPrecision)
+	info.Commission = info.Commission.Plus(commission)
+	allocated := commission
+
+	if !delegatorShares.IsZero() {
+		perShare := reward.Minus(commission).QuoRat(delegatorShares).RoundDown(rewardsPrecision)
+		info.RewardsPerShare = info.RewardsPerShare.Plus(perShare)
+		allocated = allocated.Plus(perShare.MulRat(delegatorShares))
+	}
+	k.setValidatorDistInfo(ctx, info)
+
+	dust := reward.Minus(allocated)
+	if !dust.IsZero() {
+		k.setUnallocatedRewards(ctx, k.GetUnallocatedRewards(ctx).Plus(dust))
+	}
+}
@@ -0,0 +1,141 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/x/stake"
+)
+
+// Test that the rewards are split between the proposer bonus, the
+// commission and the delegators, without losing any fraction
+func TestAllocateRewards(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	sh := stake.NewHandler(sk)
+	for i := 0; i < 2; i++ {
+		got := sh(ctx, newTestMsgCreateValidator(addrs[i], pks[i], sdk.NewInt(100)))
+		require.True(t, got.IsOK())
+	}
+	stake.EndBlocker(ctx, sk)
+	setCommission(ctx, sk, addrs[0], sdk.NewRat(1, 10))
+
+	// the first validator proposed the previous block, which all validators signed
+	keeper.setPreviousProposer(ctx, pks[0].Address())
+	addProvisions(ctx, sk, 1000)
+	keeper.AllocateRewards(ctx, signingValidators(100, 100))
+	require.Equal(t, int64(0), sk.GetPool(ctx).PendingProvisions)
+
+	// proposer reward of 1% + 4%, the remaining 950 is split by power
+	info0, found := keeper.GetValidatorDistInfo(ctx, addrs[0])
+	require.True(t, found)
+	require.True(t, sdk.NewRat(105, 2).Equal(info0.Commission.AmountOf("steak")))
+	require.True(t, sdk.NewRat(189, 40).Equal(info0.RewardsPerShare.AmountOf("steak")))
+	info1, found := keeper.GetValidatorDistInfo(ctx, addrs[1])
+	require.True(t, found)
+	require.True(t, info1.Commission.IsZero())
+	require.True(t, sdk.NewRat(19, 4).Equal(info1.RewardsPerShare.AmountOf("steak")))
+
+	// nothing was lost
+	total := info0.Commission.Plus(info1.Commission).
+		Plus(info0.RewardsPerShare.MulRat(sdk.NewRat(100))).
+		Plus(info1.RewardsPerShare.MulRat(sdk.NewRat(100)))
+	require.True(t, sdk.NewRat(1000).Equal(total.AmountOf("steak")))
+	require.True(t, keeper.GetUnallocatedRewards(ctx).IsZero())
+}
+
+// Test that the proposer bonus depends on the power of the included precommits
+func TestAllocateRewardsProposerBonus(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	sh := stake.NewHandler(sk)
+	for i := 0; i < 2; i++ {
+		got := sh(ctx, newTestMsgCreateValidator(addrs[i], pks[i], sdk.NewInt(100)))
+		require.True(t, got.IsOK())
+	}
+	stake.EndBlocker(ctx, sk)
+
+	// only half of the power precommitted, 1% + 4% * 1/2
+	keeper.setPreviousProposer(ctx, pks[1].Address())
+	signing := signingValidators(100, 100)
+	signing[0].SignedLastBlock = false
+	addProvisions(ctx, sk, 1000)
+	keeper.AllocateRewards(ctx, signing)
+
+	info0, _ := keeper.GetValidatorDistInfo(ctx, addrs[0])
+	info1, _ := keeper.GetValidatorDistInfo(ctx, addrs[1])
+	require.True(t, sdk.NewRat(485, 100).Equal(info0.RewardsPerShare.AmountOf("steak")))
+	require.True(t, sdk.NewRat(515, 100).Equal(info1.RewardsPerShare.AmountOf("steak")))
+
+	// without a known proposer the rewards are only split by power
+	keeper.setPreviousProposer(ctx, nil)
+	addProvisions(ctx, sk, 1000)
+	keeper.AllocateRewards(ctx, signingValidators(100, 100))
+
+	info0, _ = keeper.GetValidatorDistInfo(ctx, addrs[0])
+	info1, _ = keeper.GetValidatorDistInfo(ctx, addrs[1])
+	require.True(t, sdk.NewRat(985, 100).Equal(info0.RewardsPerShare.AmountOf("steak")))
+	require.True(t, sdk.NewRat(1015, 100).Equal(info1.RewardsPerShare.AmountOf("steak")))
+}
+
+// Test that the rewards per share are rounded and the dust is allocated
+// at the next block
+func TestAllocateRewardsRounding(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	got := stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addrs[0], pks[0], sdk.NewInt(300)))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+
+	// 100 over 300 shares, rounded down to 10 decimals
+	addProvisions(ctx, sk, 100)
+	keeper.AllocateRewards(ctx, signingValidators(300))
+	info, _ := keeper.GetValidatorDistInfo(ctx, addrs[0])
+	perShare := sdk.NewRat(3333333333, rewardsPrecision)
+	require.True(t, perShare.Equal(info.RewardsPerShare.AmountOf("steak")))
+	dust := keeper.GetUnallocatedRewards(ctx).AmountOf("steak")
+	require.True(t, sdk.NewRat(100).Sub(perShare.Mul(sdk.NewRat(300))).Equal(dust))
+
+	// the dust is allocated with the next rewards
+	addProvisions(ctx, sk, 200)
+	keeper.AllocateRewards(ctx, signingValidators(300))
+	info, _ = keeper.GetValidatorDistInfo(ctx, addrs[0])
+	require.True(t, sdk.OneRat().Equal(info.RewardsPerShare.AmountOf("steak")))
+	require.True(t, keeper.GetUnallocatedRewards(ctx).IsZero())
+}
+
+// Test that rewards are kept while no validator is bonded
+func TestAllocateRewardsWithoutValidators(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+
+	addProvisions(ctx, sk, 10)
+	keeper.AllocateRewards(ctx, nil)
+	require.Equal(t, int64(0), sk.GetPool(ctx).PendingProvisions)
+	require.True(t, sdk.NewRat(10).Equal(keeper.GetUnallocatedRewards(ctx).AmountOf("steak")))
+
+	got := stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addrs[0], pks[0], sdk.NewInt(100)))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+
+	keeper.AllocateRewards(ctx, signingValidators(100))
+	require.True(t, keeper.GetUnallocatedRewards(ctx).IsZero())
+	info, found := keeper.GetValidatorDistInfo(ctx, addrs[0])
+	require.True(t, found)
+	require.True(t, sdk.NewRat(1, 10).Equal(info.RewardsPerShare.AmountOf("steak")))
+}
+
+// Test that the begin blocker remembers the proposer, to reward it at the next block
+func TestBeginBlockerRemembersProposer(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	got := stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addrs[0], pks[0], sdk.NewInt(100)))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+
+	header := wrsp.Header{Height: 1, Proposer: sdk.WRSPValidator(sk.Validator(ctx, addrs[0]))}
+	BeginBlocker(ctx, wrsp.RequestBeginBlock{Header: header}, keeper)
+	require.Equal(t, []byte(pks[0].Address()), []byte(keeper.GetPreviousProposer(ctx)))
+
+	BeginBlocker(ctx, wrsp.RequestBeginBlock{Header: wrsp.Header{Height: 2}}, keeper)
+	require.Nil(t, keeper.GetPreviousProposer(ctx))
+}
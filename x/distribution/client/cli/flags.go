@@ -0,0 +1,6 @@
+package cli
+
+// nolint
+const (
+	FlagAddressValidator = "address-validator"
+)
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tepleton/tepleton-sdk/client/context"
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+	authcmd "github.com/tepleton/tepleton-sdk/x/auth/client/cli"
+	"github.com/tepleton/tepleton-sdk/x/distribution"
+)
+
+// create withdraw rewards command
+func GetCmdWithdrawRewards(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw-rewards",
+		Short: "withdraw the rewards of your delegations, and the commission of your validator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			delegatorAddr, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			// without a validator the rewards of all the delegations are withdrawn
+			var validatorAddr sdk.Address
+			if viper.GetString(FlagAddressValidator) != "" {
+				validatorAddr, err = sdk.GetAccAddressBech32(viper.GetString(FlagAddressValidator))
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := distribution.NewMsgWithdrawRewards(delegatorAddr, validatorAddr)
+
+			// build and sign the transaction, then broadcast to Tendermint
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+	cmd.Flags().String(FlagAddressValidator, "", "bech address of the validator to withdraw the rewards from, all validators if empty")
+	return cmd
+}
@@ -0,0 +1,101 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/tepleton/tepleton-sdk/client/context"
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+	"github.com/tepleton/tepleton-sdk/x/distribution"
+)
+
+func registerQueryRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec) {
+	r.HandleFunc(
+		"/distribution/delegators/{delegator}/rewards",
+		delegatorRewardsHandlerFn(ctx, cdc),
+	).Methods("GET")
+}
+
+// http request handler to query the rewards of all the delegations of a
+// delegator which were not withdrawn yet
+func delegatorRewardsHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// read parameters
+		vars := mux.Vars(r)
+		bech32delegator := vars["delegator"]
+
+		delegatorAddr, err := sdk.GetAccAddressBech32(bech32delegator)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		data, err := cdc.MarshalJSON(distribution.QueryDelegatorRewardsParams{delegatorAddr})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		res, err := ctx.QueryWithData(fmt.Sprintf("/custom/distribution/%s", distribution.QueryDelegatorRewards), data)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query the rewards of the delegator. Error: %s", err.Error())))
+			return
+		}
+
+		var rewards []distribution.DelegationRewards
+		err = cdc.UnmarshalJSON(res, &rewards)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't decode the rewards of the delegator. Error: %s", err.Error())))
+			return
+		}
+
+		// the query will return empty if there are no rewards
+		if len(rewards) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		outputs := make([]DelegationRewardsOutput, len(rewards))
+		for i, reward := range rewards {
+			outputs[i], err = bech32DelegationRewardsOutput(reward)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(err.Error()))
+				return
+			}
+		}
+
+		output, err := cdc.MarshalJSON(outputs)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
+// DelegationRewardsOutput - the rewards of a delegation with a bech32 validator address
+type DelegationRewardsOutput struct {
+	ValidatorAddr string                `json:"validator_addr"` // in bech32
+	Rewards       distribution.RatCoins `json:"rewards"`
+}
+
+func bech32DelegationRewardsOutput(rewards distribution.DelegationRewards) (DelegationRewardsOutput, error) {
+	bechValidator, err := sdk.Bech32ifyVal(rewards.ValidatorAddr)
+	if err != nil {
+		return DelegationRewardsOutput{}, err
+	}
+	return DelegationRewardsOutput{
+		ValidatorAddr: bechValidator,
+		Rewards:       rewards.Rewards,
+	}, nil
+}
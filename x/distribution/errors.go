@@ -0,0 +1,24 @@
+//nolint
+package distribution
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default distribution codespace
+	DefaultCodespace sdk.CodespaceType = 11
+
+	CodeInvalidInput CodeType = 101
+	CodeNoRewards    CodeType = 102
+)
+
+func ErrNilDelegatorAddr(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, "delegator address is nil")
+}
+func ErrNoRewards(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeNoRewards, "no rewards for that delegator and validator")
+}
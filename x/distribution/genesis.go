@@ -0,0 +1,67 @@
+package distribution
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// GenesisState - all distribution state that must be provided at genesis
+type GenesisState struct {
+	UnallocatedRewards RatCoins            `json:"unallocated_rewards"`
+	PreviousProposer   sdk.Address         `json:"previous_proposer"`
+	ValidatorDistInfos []ValidatorDistInfo `json:"validator_dist_infos"`
+	DelegatorDistInfos []DelegatorDistInfo `json:"delegator_dist_infos"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		UnallocatedRewards: RatCoins{},
+		ValidatorDistInfos: []ValidatorDistInfo{},
+		DelegatorDistInfos: []DelegatorDistInfo{},
+	}
+}
+
+// InitGenesis - store genesis rewards of validators and delegations
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	k.setUnallocatedRewards(ctx, data.UnallocatedRewards)
+	k.setPreviousProposer(ctx, data.PreviousProposer)
+	for _, info := range data.ValidatorDistInfos {
+		k.setValidatorDistInfo(ctx, info)
+	}
+	for _, info := range data.DelegatorDistInfos {
+		k.setDelegatorDistInfo(ctx, info)
+	}
+}
+
+// WriteGenesis - output all rewards of validators and delegations
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	store := ctx.KVStore(k.storeKey)
+
+	validatorDistInfos := []ValidatorDistInfo{}
+	iterator := sdk.KVStorePrefixIterator(store, ValidatorDistInfoKey)
+	for ; iterator.Valid(); iterator.Next() {
+		var info ValidatorDistInfo
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &info)
+		validatorDistInfos = append(validatorDistInfos, info)
+	}
+	iterator.Close()
+
+	delegatorDistInfos := []DelegatorDistInfo{}
+	iterator = sdk.KVStorePrefixIterator(store, DelegatorDistInfoKey)
+	for ; iterator.Valid(); iterator.Next() {
+		var info DelegatorDistInfo
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &info)
+		delegatorDistInfos = append(delegatorDistInfos, info)
+	}
+	iterator.Close()
+
+	// the fees collected during the last block are not allocated yet
+	unallocatedRewards := k.GetUnallocatedRewards(ctx).Plus(NewRatCoins(k.feeKeeper.GetCollectedFees(ctx)))
+
+	return GenesisState{
+		UnallocatedRewards: unallocatedRewards,
+		PreviousProposer:   k.GetPreviousProposer(ctx),
+		ValidatorDistInfos: validatorDistInfos,
+		DelegatorDistInfos: delegatorDistInfos,
+	}
+}
@@ -0,0 +1,47 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/x/stake"
+)
+
+func TestGenesisRoundTrip(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	sh := stake.NewHandler(sk)
+	got := sh(ctx, newTestMsgCreateValidator(addrs[0], pks[0], sdk.NewInt(100)))
+	require.True(t, got.IsOK())
+	got = sh(ctx, newTestMsgDelegate(addrs[1], addrs[0], sdk.NewInt(200)))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+	setCommission(ctx, sk, addrs[0], sdk.NewRat(1, 3))
+
+	// accumulate commission and fractional rewards
+	keeper.setPreviousProposer(ctx, pks[0].Address())
+	addProvisions(ctx, sk, 10)
+	keeper.AllocateRewards(ctx, signingValidators(300))
+	_, err := keeper.WithdrawDelegationRewards(ctx, addrs[1], addrs[0])
+	require.Nil(t, err)
+
+	genesis := WriteGenesis(ctx, keeper)
+	require.Equal(t, 1, len(genesis.ValidatorDistInfos))
+	require.Equal(t, 2, len(genesis.DelegatorDistInfos))
+
+	// import into a fresh store
+	ctx2, _, _, keeper2 := createTestInput(t)
+	InitGenesis(ctx2, keeper2, genesis)
+	require.Equal(t, genesis, WriteGenesis(ctx2, keeper2))
+
+	valInfo, _ := keeper.GetValidatorDistInfo(ctx, addrs[0])
+	valInfo2, found := keeper2.GetValidatorDistInfo(ctx2, addrs[0])
+	require.True(t, found)
+	require.Equal(t, valInfo, valInfo2)
+	delInfo, _ := keeper.GetDelegatorDistInfo(ctx, addrs[1], addrs[0])
+	delInfo2, found := keeper2.GetDelegatorDistInfo(ctx2, addrs[1], addrs[0])
+	require.True(t, found)
+	require.Equal(t, delInfo, delInfo2)
+	require.Equal(t, keeper.GetPreviousProposer(ctx), keeper2.GetPreviousProposer(ctx2))
+}
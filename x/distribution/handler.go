@@ -0,0 +1,62 @@
+package distribution
+
+import (
+	"bytes"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		// NOTE msg already has validate basic run
+		switch msg := msg.(type) {
+		case MsgWithdrawRewards:
+			return handleMsgWithdrawRewards(ctx, msg, k)
+		default:
+			return sdk.ErrTxDecode("invalid message parse in distribution module").Result()
+		}
+	}
+}
+
+// Delegators withdraw the rewards accumulated by their delegations, and the
+// owners of validators the commission of their validator
+func handleMsgWithdrawRewards(ctx sdk.Context, msg MsgWithdrawRewards, k Keeper) sdk.Result {
+
+	validatorAddrs := []sdk.Address{msg.ValidatorAddr}
+	if len(msg.ValidatorAddr) == 0 {
+		validatorAddrs = k.getDelegatorValidators(ctx, msg.DelegatorAddr)
+	} else {
+		_, found := k.GetDelegatorDistInfo(ctx, msg.DelegatorAddr, msg.ValidatorAddr)
+		if !found && k.stakeKeeper.Delegation(ctx, msg.DelegatorAddr, msg.ValidatorAddr) == nil &&
+			!bytes.Equal(msg.ValidatorAddr, msg.DelegatorAddr) {
+			return ErrNoRewards(k.codespace).Result()
+		}
+	}
+
+	withdrawn := sdk.Coins{}
+	for _, validatorAddr := range validatorAddrs {
+		rewards, err := k.WithdrawDelegationRewards(ctx, msg.DelegatorAddr, validatorAddr)
+		if err != nil {
+			return err.Result()
+		}
+		withdrawn = withdrawn.Plus(rewards)
+	}
+
+	// the owner of a validator also withdraws its commission
+	if len(msg.ValidatorAddr) == 0 || bytes.Equal(msg.ValidatorAddr, msg.DelegatorAddr) {
+		commission, err := k.WithdrawValidatorCommission(ctx, msg.DelegatorAddr)
+		if err != nil {
+			return err.Result()
+		}
+		withdrawn = withdrawn.Plus(commission)
+	}
+
+	tags := sdk.NewTags(
+		sdk.TagAction, []byte("withdraw-rewards"),
+		sdk.TagDelegator, []byte(msg.DelegatorAddr.String()),
+		"rewards", []byte(withdrawn.String()),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
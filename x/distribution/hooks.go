@@ -0,0 +1,25 @@
+package distribution
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// Hooks settling the rewards of delegations, to be set on the stake keeper
+type Hooks struct {
+	k Keeper
+}
+
+var _ sdk.StakingHooks = Hooks{}
+
+// Hooks returns the staking hooks of the distribution keeper
+func (k Keeper) Hooks() Hooks {
+	return Hooks{k}
+}
+
+// The rewards accrued so far were earned by the current shares of the
+// delegation, so settle them before the shares change. They are kept until
+// the delegator withdraws them.
+func (h Hooks) BeforeDelegationSharesModified(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) {
+	delInfo := h.k.settleDelegation(ctx, delegatorAddr, validatorAddr)
+	h.k.setDelegatorDistInfo(ctx, delInfo)
+}
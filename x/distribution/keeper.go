@@ -0,0 +1,213 @@
+package distribution
+
+import (
+	"bytes"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+	"github.com/tepleton/tepleton-sdk/x/auth"
+	"github.com/tepleton/tepleton-sdk/x/bank"
+	"github.com/tepleton/tepleton-sdk/x/params"
+	"github.com/tepleton/tepleton-sdk/x/stake"
+)
+
+// Keeper of the distribution store
+type Keeper struct {
+	storeKey    sdk.StoreKey
+	cdc         *wire.Codec
+	coinKeeper  bank.Keeper
+	feeKeeper   auth.FeeCollectionKeeper
+	stakeKeeper stake.Keeper
+	params      params.Getter
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper creates a distribution keeper
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, fck auth.FeeCollectionKeeper,
+	sk stake.Keeper, params params.Getter, codespace sdk.CodespaceType) Keeper {
+
+	keeper := Keeper{
+		storeKey:    key,
+		cdc:         cdc,
+		coinKeeper:  ck,
+		feeKeeper:   fck,
+		stakeKeeper: sk,
+		params:      params,
+		codespace:   codespace,
+	}
+	keeper.registerParams()
+	return keeper
+}
+
+//______________________________________________________________________
+
+// get the rewards which could not be allocated, as no validator was bonded
+func (k Keeper) GetUnallocatedRewards(ctx sdk.Context) (rewards RatCoins) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(UnallocatedRewardsKey)
+	if bz == nil {
+		return RatCoins{}
+	}
+	k.cdc.MustUnmarshalBinary(bz, &rewards)
+	return
+}
+
+// set the rewards which could not be allocated
+func (k Keeper) setUnallocatedRewards(ctx sdk.Context, rewards RatCoins) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(rewards)
+	store.Set(UnallocatedRewardsKey, bz)
+}
+
+// get the *validator* address of the proposer of the previous block
+func (k Keeper) GetPreviousProposer(ctx sdk.Context) sdk.Address {
+	store := ctx.KVStore(k.storeKey)
+	return store.Get(PreviousProposerKey)
+}
+
+// set the *validator* address of the proposer of the previous block
+func (k Keeper) setPreviousProposer(ctx sdk.Context, address sdk.Address) {
+	store := ctx.KVStore(k.storeKey)
+	if address == nil {
+		store.Delete(PreviousProposerKey)
+		return
+	}
+	store.Set(PreviousProposerKey, address)
+}
+
+// get the rewards accumulated by a validator, stored by owner address
+func (k Keeper) GetValidatorDistInfo(ctx sdk.Context, validatorAddr sdk.Address) (info ValidatorDistInfo, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetValidatorDistInfoKey(validatorAddr))
+	if bz == nil {
+		return NewValidatorDistInfo(validatorAddr), false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &info)
+	return info, true
+}
+
+// set the rewards accumulated by a validator
+func (k Keeper) setValidatorDistInfo(ctx sdk.Context, info ValidatorDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(info)
+	store.Set(GetValidatorDistInfoKey(info.ValidatorAddr), bz)
+}
+
+// get the rewards accumulated by a delegation
+func (k Keeper) GetDelegatorDistInfo(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) (info DelegatorDistInfo, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetDelegatorDistInfoKey(delegatorAddr, validatorAddr))
+	if bz == nil {
+		return NewDelegatorDistInfo(delegatorAddr, validatorAddr), false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &info)
+	return info, true
+}
+
+// set the rewards accumulated by a delegation
+func (k Keeper) setDelegatorDistInfo(ctx sdk.Context, info DelegatorDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(info)
+	store.Set(GetDelegatorDistInfoKey(info.DelegatorAddr, info.ValidatorAddr), bz)
+}
+
+// remove the rewards accumulated by a delegation
+func (k Keeper) removeDelegatorDistInfo(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetDelegatorDistInfoKey(delegatorAddr, validatorAddr))
+}
+
+//______________________________________________________________________
+
+// Settle the rewards accumulated by a delegation since it was last settled,
+// at the current rewards per share of its validator. Must be called before
+// the shares of the delegation change.
+func (k Keeper) settleDelegation(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) DelegatorDistInfo {
+	valInfo, _ := k.GetValidatorDistInfo(ctx, validatorAddr)
+	delInfo, _ := k.GetDelegatorDistInfo(ctx, delegatorAddr, validatorAddr)
+
+	delegation := k.stakeKeeper.Delegation(ctx, delegatorAddr, validatorAddr)
+	if delegation != nil {
+		perShare := valInfo.RewardsPerShare.Minus(delInfo.RewardsPerShare)
+		delInfo.Rewards = delInfo.Rewards.Plus(perShare.MulRat(delegation.GetBondShares()))
+	}
+	delInfo.RewardsPerShare = valInfo.RewardsPerShare
+	return delInfo
+}
+
+// Pay out the rewards of a delegation to the delegator. The fractions of
+// coins are kept for the next withdrawal.
+func (k Keeper) WithdrawDelegationRewards(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) (sdk.Coins, sdk.Error) {
+	delInfo := k.settleDelegation(ctx, delegatorAddr, validatorAddr)
+
+	withdrawn, remainder := delInfo.Rewards.TruncateDecimal()
+	delInfo.Rewards = remainder
+	if remainder.IsZero() && k.stakeKeeper.Delegation(ctx, delegatorAddr, validatorAddr) == nil {
+		// nothing left to keep track of for a removed delegation
+		k.removeDelegatorDistInfo(ctx, delegatorAddr, validatorAddr)
+	} else {
+		k.setDelegatorDistInfo(ctx, delInfo)
+	}
+
+	if withdrawn.IsZero() {
+		return withdrawn, nil
+	}
+	_, _, err := k.coinKeeper.AddCoins(ctx, delegatorAddr, withdrawn)
+	if err != nil {
+		return nil, err
+	}
+	return withdrawn, nil
+}
+
+// Pay out the commission and proposer rewards of a validator to its owner.
+// The fractions of coins are kept for the next withdrawal.
+func (k Keeper) WithdrawValidatorCommission(ctx sdk.Context, validatorAddr sdk.Address) (sdk.Coins, sdk.Error) {
+	valInfo, found := k.GetValidatorDistInfo(ctx, validatorAddr)
+	if !found {
+		return sdk.Coins{}, nil
+	}
+
+	withdrawn, remainder := valInfo.Commission.TruncateDecimal()
+	valInfo.Commission = remainder
+	k.setValidatorDistInfo(ctx, valInfo)
+
+	if withdrawn.IsZero() {
+		return withdrawn, nil
+	}
+	_, _, err := k.coinKeeper.AddCoins(ctx, validatorAddr, withdrawn)
+	if err != nil {
+		return nil, err
+	}
+	return withdrawn, nil
+}
+
+// Owner addresses of the validators a delegator may have rewards with, either
+// through a delegation or through settled rewards it has not withdrawn yet
+func (k Keeper) getDelegatorValidators(ctx sdk.Context, delegatorAddr sdk.Address) (validatorAddrs []sdk.Address) {
+	seen := make(map[string]bool)
+	add := func(validatorAddr sdk.Address) {
+		if !seen[string(validatorAddr)] {
+			seen[string(validatorAddr)] = true
+			validatorAddrs = append(validatorAddrs, validatorAddr)
+		}
+	}
+
+	k.stakeKeeper.IterateDelegations(ctx, delegatorAddr, func(_ int64, delegation sdk.Delegation) (stop bool) {
+		add(delegation.GetValidator())
+		return false
+	})
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetDelegatorDistInfosKey(delegatorAddr))
+	for ; iterator.Valid(); iterator.Next() {
+		var info DelegatorDistInfo
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &info)
+		if bytes.Equal(info.DelegatorAddr, delegatorAddr) {
+			add(info.ValidatorAddr)
+		}
+	}
+	iterator.Close()
+	return
+}
@@ -0,0 +1,137 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/x/stake"
+)
+
+// Test that the rewards earned by a delegation are settled before its shares
+// change, so later rewards are accounted with the new shares
+func TestDelegationRewardsSettledOnShareChange(t *testing.T) {
+	ctx, ck, sk, keeper := createTestInput(t)
+	sh := stake.NewHandler(sk)
+	got := sh(ctx, newTestMsgCreateValidator(addrs[0], pks[0], sdk.NewInt(100)))
+	require.True(t, got.IsOK())
+	got = sh(ctx, newTestMsgDelegate(addrs[1], addrs[0], sdk.NewInt(100)))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+
+	// one reward per share
+	addProvisions(ctx, sk, 200)
+	keeper.AllocateRewards(ctx, signingValidators(200))
+
+	// delegating again settles the rewards of the previous shares
+	got = sh(ctx, newTestMsgDelegate(addrs[1], addrs[0], sdk.NewInt(100)))
+	require.True(t, got.IsOK())
+	delInfo, found := keeper.GetDelegatorDistInfo(ctx, addrs[1], addrs[0])
+	require.True(t, found)
+	require.True(t, sdk.NewRat(100).Equal(delInfo.Rewards.AmountOf("steak")))
+	require.True(t, sdk.OneRat().Equal(delInfo.RewardsPerShare.AmountOf("steak")))
+
+	// one more reward per share
+	addProvisions(ctx, sk, 300)
+	keeper.AllocateRewards(ctx, signingValidators(300))
+
+	withdrawn, err := keeper.WithdrawDelegationRewards(ctx, addrs[1], addrs[0])
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 300)}, withdrawn)
+	require.Equal(t, int64(200-200+300), ck.GetCoins(ctx, addrs[1]).AmountOf("steak").Int64())
+
+	withdrawn, err = keeper.WithdrawDelegationRewards(ctx, addrs[0], addrs[0])
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 200)}, withdrawn)
+
+	// nothing left to withdraw
+	withdrawn, err = keeper.WithdrawDelegationRewards(ctx, addrs[1], addrs[0])
+	require.Nil(t, err)
+	require.True(t, withdrawn.IsZero())
+
+	// unbonding settles the rewards, which stay withdrawable
+	addProvisions(ctx, sk, 300)
+	keeper.AllocateRewards(ctx, signingValidators(300))
+	got = sh(ctx, stake.NewMsgBeginUnbonding(addrs[1], addrs[0], sdk.NewRat(200)))
+	require.True(t, got.IsOK())
+	withdrawn, err = keeper.WithdrawDelegationRewards(ctx, addrs[1], addrs[0])
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 200)}, withdrawn)
+	_, found = keeper.GetDelegatorDistInfo(ctx, addrs[1], addrs[0])
+	require.False(t, found)
+}
+
+// Test that fractions of coins are kept for later withdrawals
+func TestWithdrawKeepsFractions(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	sh := stake.NewHandler(sk)
+	got := sh(ctx, newTestMsgCreateValidator(addrs[0], pks[0], sdk.NewInt(100)))
+	require.True(t, got.IsOK())
+	got = sh(ctx, newTestMsgDelegate(addrs[1], addrs[0], sdk.NewInt(200)))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+	setCommission(ctx, sk, addrs[0], sdk.NewRat(1, 3))
+
+	// 10 split as 10/3 commission and 20/3 to the delegators, rounded down
+	// to 10 decimals: 3.3333333333 of commission, 0.0222222222 per share
+	// and 0.0000000067 of dust left for the next block
+	addProvisions(ctx, sk, 10)
+	keeper.AllocateRewards(ctx, signingValidators(300))
+
+	withdrawn, err := keeper.WithdrawDelegationRewards(ctx, addrs[1], addrs[0])
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 4)}, withdrawn)
+	withdrawn, err = keeper.WithdrawValidatorCommission(ctx, addrs[0])
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 3)}, withdrawn)
+
+	// 10 plus the dust split as 3.3333333355 of commission and 0.0222222222
+	// per share, the fractions withdrawn before are kept
+	addProvisions(ctx, sk, 10)
+	keeper.AllocateRewards(ctx, signingValidators(300))
+
+	withdrawn, err = keeper.WithdrawDelegationRewards(ctx, addrs[1], addrs[0])
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 4)}, withdrawn)
+	delInfo, _ := keeper.GetDelegatorDistInfo(ctx, addrs[1], addrs[0])
+	require.True(t, sdk.NewRat(88888888, 100000000).Equal(delInfo.Rewards.AmountOf("steak")))
+	withdrawn, err = keeper.WithdrawValidatorCommission(ctx, addrs[0])
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 3)}, withdrawn)
+	valInfo, _ := keeper.GetValidatorDistInfo(ctx, addrs[0])
+	require.True(t, sdk.NewRat(6666666688, rewardsPrecision).Equal(valInfo.Commission.AmountOf("steak")))
+}
+
+// Test withdrawing the rewards of all delegations and the commission at once
+func TestHandleMsgWithdrawRewards(t *testing.T) {
+	ctx, ck, sk, keeper := createTestInput(t)
+	sh := stake.NewHandler(sk)
+	h := NewHandler(keeper)
+	for i := 0; i < 2; i++ {
+		got := sh(ctx, newTestMsgCreateValidator(addrs[i], pks[i], sdk.NewInt(100)))
+		require.True(t, got.IsOK())
+	}
+	got := sh(ctx, newTestMsgDelegate(addrs[0], addrs[1], sdk.NewInt(100)))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+	setCommission(ctx, sk, addrs[0], sdk.NewRat(1, 2))
+
+	addProvisions(ctx, sk, 300)
+	keeper.AllocateRewards(ctx, signingValidators(100, 200))
+
+	// no delegation with the third validator
+	got = h(ctx, NewMsgWithdrawRewards(addrs[0], addrs[2]))
+	require.False(t, got.IsOK())
+
+	// 50 of commission and 50 of rewards from the first validator,
+	// 100 of rewards from the second
+	got = h(ctx, NewMsgWithdrawRewards(addrs[0], nil))
+	require.True(t, got.IsOK())
+	require.Equal(t, int64(200-100-100+200), ck.GetCoins(ctx, addrs[0]).AmountOf("steak").Int64())
+
+	// the second validator only withdraws from its own delegation
+	got = h(ctx, NewMsgWithdrawRewards(addrs[1], addrs[1]))
+	require.True(t, got.IsOK())
+	require.Equal(t, int64(200-100+100), ck.GetCoins(ctx, addrs[1]).AmountOf("steak").Int64())
+}
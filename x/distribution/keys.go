@@ -0,0 +1,28 @@
+package distribution
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// nolint - keys and prefixes of the distribution store
+var (
+	UnallocatedRewardsKey = []byte{0x00}
+	PreviousProposerKey   = []byte{0x01}
+	ValidatorDistInfoKey  = []byte{0x02}
+	DelegatorDistInfoKey  = []byte{0x03}
+)
+
+// Stored by owner address of the validator
+func GetValidatorDistInfoKey(validatorAddr sdk.Address) []byte {
+	return append(ValidatorDistInfoKey, validatorAddr.Bytes()...)
+}
+
+// Stored by delegator address then owner address of the validator
+func GetDelegatorDistInfoKey(delegatorAddr, validatorAddr sdk.Address) []byte {
+	return append(GetDelegatorDistInfosKey(delegatorAddr), validatorAddr.Bytes()...)
+}
+
+// Prefix of the rewards of all the delegations of a delegator
+func GetDelegatorDistInfosKey(delegatorAddr sdk.Address) []byte {
+	return append(DelegatorDistInfoKey, delegatorAddr.Bytes()...)
+}
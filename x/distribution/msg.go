@@ -0,0 +1,60 @@
+package distribution
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+)
+
+var cdc = wire.NewCodec()
+
+// name to identify transaction types
+const MsgType = "distribution"
+
+// verify interface at compile time
+var _ sdk.Msg = &MsgWithdrawRewards{}
+
+// MsgWithdrawRewards - struct for withdrawing the rewards of a delegator. If
+// no validator is given, the rewards of all its delegations are withdrawn.
+// The owner of a validator also withdraws the commission of its validator.
+type MsgWithdrawRewards struct {
+	DelegatorAddr sdk.Address `json:"delegator_addr"`
+	ValidatorAddr sdk.Address `json:"validator_addr"`
+}
+
+func NewMsgWithdrawRewards(delegatorAddr, validatorAddr sdk.Address) MsgWithdrawRewards {
+	return MsgWithdrawRewards{
+		DelegatorAddr: delegatorAddr,
+		ValidatorAddr: validatorAddr,
+	}
+}
+
+//nolint
+func (msg MsgWithdrawRewards) Type() string              { return MsgType }
+func (msg MsgWithdrawRewards) GetSigners() []sdk.Address { return []sdk.Address{msg.DelegatorAddr} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgWithdrawRewards) GetSignBytes() []byte {
+	validatorAddr := ""
+	if len(msg.ValidatorAddr) > 0 {
+		validatorAddr = sdk.MustBech32ifyVal(msg.ValidatorAddr)
+	}
+	b, err := cdc.MarshalJSON(struct {
+		DelegatorAddr string `json:"delegator_addr"`
+		ValidatorAddr string `json:"validator_addr"`
+	}{
+		DelegatorAddr: sdk.MustBech32ifyAcc(msg.DelegatorAddr),
+		ValidatorAddr: validatorAddr,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgWithdrawRewards) ValidateBasic() sdk.Error {
+	if len(msg.DelegatorAddr) == 0 {
+		return ErrNilDelegatorAddr(DefaultCodespace)
+	}
+	return nil
+}
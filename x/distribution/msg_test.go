@@ -0,0 +1,25 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+func TestMsgWithdrawRewardsValidateBasic(t *testing.T) {
+	require.Nil(t, NewMsgWithdrawRewards(sdk.Address("abcd"), sdk.Address("efgh")).ValidateBasic())
+	require.Nil(t, NewMsgWithdrawRewards(sdk.Address("abcd"), nil).ValidateBasic())
+	require.NotNil(t, NewMsgWithdrawRewards(nil, sdk.Address("efgh")).ValidateBasic())
+}
+
+func TestMsgWithdrawRewardsGetSignBytes(t *testing.T) {
+	msg := NewMsgWithdrawRewards(sdk.Address("abcd"), sdk.Address("efgh"))
+	bytes := msg.GetSignBytes()
+	require.Equal(t, `{"delegator_addr":"tepletonaccaddr1v93xxeqcczmh2","validator_addr":"tepletonvaladdr1v4nxw6qdy5cwr"}`, string(bytes))
+
+	msg = NewMsgWithdrawRewards(sdk.Address("abcd"), nil)
+	bytes = msg.GetSignBytes()
+	require.Equal(t, `{"delegator_addr":"tepletonaccaddr1v93xxeqcczmh2","validator_addr":""}`, string(bytes))
+}
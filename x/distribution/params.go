@@ -0,0 +1,60 @@
+package distribution
+
+import (
+	"fmt"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/x/params"
+)
+
+// nolint - keys of the distribution params within the global param store
+var (
+	ProposerRewardBaseKey  = params.ComposeKey("distribution", "proposerrewardbase")
+	ProposerRewardBonusKey = params.ComposeKey("distribution", "proposerrewardbonus")
+)
+
+// declare the distribution params to the param store. The proposer reward
+// fractions are checked together with the current value of the other one.
+func (k Keeper) registerParams() {
+	k.params.Register(ProposerRewardBaseKey, sdk.Rat{}, func(ctx sdk.Context, value interface{}) error {
+		return checkProposerReward(value.(sdk.Rat), k.ProposerRewardBonus(ctx))
+	})
+	k.params.Register(ProposerRewardBonusKey, sdk.Rat{}, func(ctx sdk.Context, value interface{}) error {
+		return checkProposerReward(k.ProposerRewardBase(ctx), value.(sdk.Rat))
+	})
+}
+
+// the proposer reward fractions must be within [0, 1], and so must be their sum
+func checkProposerReward(base, bonus sdk.Rat) error {
+	zero, one := sdk.ZeroRat(), sdk.OneRat()
+	if err := params.CheckRatBetween(base, zero, one); err != nil {
+		return fmt.Errorf("invalid proposer reward base: %v", err)
+	}
+	if err := params.CheckRatBetween(bonus, zero, one); err != nil {
+		return fmt.Errorf("invalid proposer reward bonus: %v", err)
+	}
+	if base.Add(bonus).GT(one) {
+		return fmt.Errorf("proposer reward base %v and bonus %v add up to more than 1", base, bonus)
+	}
+	return nil
+}
+
+// ProposerRewardBase - fraction of the rewards given to the proposer of a
+// block regardless of the precommits it included, currently default 1%
+func (k Keeper) ProposerRewardBase(ctx sdk.Context) sdk.Rat {
+	return k.params.GetRatWithDefault(ctx, ProposerRewardBaseKey, defaultProposerRewardBase)
+}
+
+// ProposerRewardBonus - fraction of the rewards given to the proposer of a
+// block if it included the precommits of the whole validator set, scaled by
+// the power of the precommits it did include, currently default 4%
+func (k Keeper) ProposerRewardBonus(ctx sdk.Context) sdk.Rat {
+	return k.params.GetRatWithDefault(ctx, ProposerRewardBonusKey, defaultProposerRewardBonus)
+}
+
+// defaults used while a param has not been set in the param store
+var (
+	defaultProposerRewardBase = sdk.NewRat(1, 100)
+
+	defaultProposerRewardBonus = sdk.NewRat(4, 100)
+)
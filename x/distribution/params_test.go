@@ -0,0 +1,36 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test that the proposer reward fractions are kept within their ranges
+func TestProposerRewardParamsValidation(t *testing.T) {
+	ctx, _, _, keeper := createTestInput(t)
+
+	cases := []struct {
+		key   string
+		value string
+		valid bool
+	}{
+		{ProposerRewardBaseKey, `"1/10"`, true},
+		{ProposerRewardBaseKey, `"0/1"`, true},
+		{ProposerRewardBaseKey, `"-1/10"`, false},
+		{ProposerRewardBaseKey, `"11/10"`, false},
+		{ProposerRewardBonusKey, `"1/2"`, true},
+		{ProposerRewardBonusKey, `"3/2"`, false},
+		// the default base of 1% and a bonus of 100% add up to more than 1
+		{ProposerRewardBonusKey, `"1/1"`, false},
+		{ProposerRewardBaseKey, `"97/100"`, false},
+	}
+	for i, tc := range cases {
+		err := keeper.params.ValidateChange(ctx, tc.key, []byte(tc.value))
+		if tc.valid {
+			require.Nil(t, err, "case %d", i)
+		} else {
+			require.NotNil(t, err, "case %d", i)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package distribution
+
+import (
+	"fmt"
+
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// query endpoints supported by the distribution querier
+const (
+	QueryDelegatorRewards = "delegatorRewards"
+)
+
+// QueryDelegatorRewardsParams - params of the delegator rewards query, as JSON data
+type QueryDelegatorRewardsParams struct {
+	DelegatorAddr sdk.Address `json:"delegator_addr"`
+}
+
+// DelegationRewards - rewards of a delegator with a validator which were not
+// withdrawn yet, including the fractions of coins
+type DelegationRewards struct {
+	ValidatorAddr sdk.Address `json:"validator_addr"`
+	Rewards       RatCoins    `json:"rewards"`
+}
+
+// NewQuerier answers the custom distribution queries, with JSON responses
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req wrsp.RequestQuery) (res []byte, err sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no distribution query endpoint")
+		}
+		switch path[0] {
+		case QueryDelegatorRewards:
+			return queryDelegatorRewards(ctx, k, req)
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown distribution query endpoint %s", path[0]))
+		}
+	}
+}
+
+func queryDelegatorRewards(ctx sdk.Context, k Keeper, req wrsp.RequestQuery) ([]byte, sdk.Error) {
+	var params QueryDelegatorRewardsParams
+	if err := sdk.UnmarshalQueryParams(k.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	// the rewards are settled as a withdrawal would, without storing them
+	rewards := []DelegationRewards{}
+	for _, validatorAddr := range k.getDelegatorValidators(ctx, params.DelegatorAddr) {
+		delInfo := k.settleDelegation(ctx, params.DelegatorAddr, validatorAddr)
+		if delInfo.Rewards.IsZero() {
+			continue
+		}
+		rewards = append(rewards, DelegationRewards{validatorAddr, delInfo.Rewards})
+	}
+	return sdk.MarshalQueryResult(k.cdc, rewards)
+}
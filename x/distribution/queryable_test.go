@@ -0,0 +1,49 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/x/stake"
+)
+
+func TestQueryDelegatorRewards(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	querier := NewQuerier(keeper)
+	sh := stake.NewHandler(sk)
+	got := sh(ctx, newTestMsgCreateValidator(addrs[0], pks[0], sdk.NewInt(100)))
+	require.True(t, got.IsOK())
+	got = sh(ctx, newTestMsgDelegate(addrs[1], addrs[0], sdk.NewInt(200)))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+	setCommission(ctx, sk, addrs[0], sdk.NewRat(1, 2))
+
+	// 15 of commission and 0.05 per share to the delegators
+	addProvisions(ctx, sk, 30)
+	keeper.AllocateRewards(ctx, signingValidators(300))
+
+	query := func(delegatorAddr sdk.Address) (rewards []DelegationRewards) {
+		data, err := keeper.cdc.MarshalJSON(QueryDelegatorRewardsParams{delegatorAddr})
+		require.Nil(t, err)
+		res, queryErr := querier(ctx, []string{QueryDelegatorRewards}, wrsp.RequestQuery{Data: data})
+		require.Nil(t, queryErr)
+		require.Nil(t, keeper.cdc.UnmarshalJSON(res, &rewards))
+		return rewards
+	}
+	rewards := query(addrs[1])
+	require.Len(t, rewards, 1)
+	require.Equal(t, addrs[0], rewards[0].ValidatorAddr)
+	require.True(t, sdk.NewRat(10).Equal(rewards[0].Rewards.AmountOf("steak")))
+
+	// querying doesn't settle the rewards
+	delInfo, _ := keeper.GetDelegatorDistInfo(ctx, addrs[1], addrs[0])
+	require.True(t, delInfo.Rewards.IsZero())
+
+	require.Len(t, query(addrs[2]), 0)
+
+	_, queryErr := querier(ctx, []string{"unknown"}, wrsp.RequestQuery{})
+	require.Equal(t, sdk.CodeUnknownRequest, queryErr.Code())
+}
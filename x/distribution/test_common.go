@@ -0,0 +1,146 @@
+package distribution
+
+import (
+	"encoding/hex"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+	"github.com/tepleton/tepleton/crypto"
+	dbm "github.com/tepleton/tepleton/libs/db"
+	"github.com/tepleton/tepleton/libs/log"
+
+	"github.com/tepleton/tepleton-sdk/store"
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+	"github.com/tepleton/tepleton-sdk/x/auth"
+	"github.com/tepleton/tepleton-sdk/x/bank"
+	"github.com/tepleton/tepleton-sdk/x/params"
+	"github.com/tepleton/tepleton-sdk/x/stake"
+)
+
+var (
+	addrs = []sdk.Address{
+		testAddr("A58856F0FD53BF058B4909A21AEC019107BA6160"),
+		testAddr("A58856F0FD53BF058B4909A21AEC019107BA6161"),
+		testAddr("A58856F0FD53BF058B4909A21AEC019107BA6162"),
+	}
+	pks = []crypto.PubKey{
+		newPubKey("0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AFB50"),
+		newPubKey("0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AFB51"),
+		newPubKey("0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AFB52"),
+	}
+	initCoins sdk.Int = sdk.NewInt(200)
+)
+
+func createTestCodec() *wire.Codec {
+	cdc := wire.NewCodec()
+	sdk.RegisterWire(cdc)
+	auth.RegisterWire(cdc)
+	bank.RegisterWire(cdc)
+	stake.RegisterWire(cdc)
+	RegisterWire(cdc)
+	wire.RegisterCrypto(cdc)
+	return cdc
+}
+
+func createTestInput(t *testing.T) (sdk.Context, bank.Keeper, stake.Keeper, Keeper) {
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keyStake := sdk.NewKVStoreKey("stake")
+	keyDistribution := sdk.NewKVStoreKey("distribution")
+	keyFeeCollection := sdk.NewKVStoreKey("fee")
+	keyParams := sdk.NewKVStoreKey("params")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyStake, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyDistribution, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyFeeCollection, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+	ctx := sdk.NewContext(ms, wrsp.Header{}, false, log.NewTMLogger(os.Stdout))
+	cdc := createTestCodec()
+	accountMapper := auth.NewAccountMapper(cdc, keyAcc, &auth.BaseAccount{})
+	ck := bank.NewKeeper(accountMapper)
+	fck := auth.NewFeeCollectionKeeper(cdc, keyFeeCollection)
+	paramsKeeper := params.NewKeeper(cdc, keyParams)
+	sk := stake.NewKeeper(cdc, keyStake, ck, paramsKeeper.Setter(), stake.DefaultCodespace)
+	genesis := stake.DefaultGenesisState()
+	genesis.Pool.LooseTokens = initCoins.MulRaw(int64(len(addrs))).Int64()
+	stake.InitGenesis(ctx, sk, genesis)
+	for _, addr := range addrs {
+		_, _, err = ck.AddCoins(ctx, addr, sdk.Coins{
+			{sk.GetParams(ctx).BondDenom, initCoins},
+		})
+	}
+	require.Nil(t, err)
+
+	keeper := NewKeeper(cdc, keyDistribution, ck, fck, sk, paramsKeeper.Getter(), DefaultCodespace)
+	sk = sk.WithHooks(keeper.Hooks())
+	return ctx, ck, sk, keeper
+}
+
+func newPubKey(pk string) (res crypto.PubKey) {
+	pkBytes, err := hex.DecodeString(pk)
+	if err != nil {
+		panic(err)
+	}
+	var pkEd crypto.PubKeyEd25519
+	copy(pkEd[:], pkBytes[:])
+	return pkEd
+}
+
+func testAddr(addr string) sdk.Address {
+	res := []byte(addr)
+	return res
+}
+
+func newTestMsgCreateValidator(address sdk.Address, pubKey crypto.PubKey, amt sdk.Int) stake.MsgCreateValidator {
+	return stake.MsgCreateValidator{
+		Description:    stake.Description{},
+		Commission:     stake.NewCommissionMsg(sdk.ZeroRat(), sdk.ZeroRat(), sdk.ZeroRat()),
+		ValidatorAddr:  address,
+		PubKey:         pubKey,
+		SelfDelegation: sdk.Coin{"steak", amt},
+	}
+}
+
+func newTestMsgDelegate(delegatorAddr, validatorAddr sdk.Address, amt sdk.Int) stake.MsgDelegate {
+	return stake.MsgDelegate{
+		DelegatorAddr: delegatorAddr,
+		ValidatorAddr: validatorAddr,
+		Bond:          sdk.Coin{"steak", amt},
+	}
+}
+
+// set the commission rate of a validator
+func setCommission(ctx sdk.Context, sk stake.Keeper, validatorAddr sdk.Address, commission sdk.Rat) {
+	validator, found := sk.GetValidator(ctx, validatorAddr)
+	if !found {
+		panic("validator not found")
+	}
+	validator.Commission = commission
+	sk.SetValidator(ctx, validator)
+}
+
+// add provisions to be distributed at the next allocation
+func addProvisions(ctx sdk.Context, sk stake.Keeper, provisions int64) {
+	pool := sk.GetPool(ctx)
+	pool.PendingProvisions += provisions
+	sk.SetPool(ctx, pool)
+}
+
+// signing validators, all of them having signed the previous block
+func signingValidators(powers ...int64) []wrsp.SigningValidator {
+	validators := make([]wrsp.SigningValidator, len(powers))
+	for i, power := range powers {
+		validators[i] = wrsp.SigningValidator{
+			Validator:       wrsp.Validator{Power: power},
+			SignedLastBlock: true,
+		}
+	}
+	return validators
+}
@@ -0,0 +1,23 @@
+package distribution
+
+import (
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+	tmtypes "github.com/tepleton/tepleton/types"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// distribution begin block functionality
+func BeginBlocker(ctx sdk.Context, req wrsp.RequestBeginBlock, k Keeper) {
+	// The signing validators are the ones which precommitted the previous
+	// block, so its rewards go to its proposer
+	k.AllocateRewards(ctx, req.Validators)
+
+	// Remember the proposer of this block, it is rewarded at the next one
+	var proposer sdk.Address
+	pubkey, err := tmtypes.PB2TM.PubKey(req.Header.Proposer.PubKey)
+	if err == nil {
+		proposer = pubkey.Address()
+	}
+	k.setPreviousProposer(ctx, proposer)
+}
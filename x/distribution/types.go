@@ -0,0 +1,197 @@
+package distribution
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// RatCoin - coin whose amount is kept as a fraction, so that rewards can be
+// split between validators and delegators without losing any dust
+type RatCoin struct {
+	Denom  string  `json:"denom"`
+	Amount sdk.Rat `json:"amount"`
+}
+
+func NewRatCoin(coin sdk.Coin) RatCoin {
+	return RatCoin{
+		Denom:  coin.Denom,
+		Amount: sdk.NewRatFromInt(coin.Amount),
+	}
+}
+
+// nolint
+func (coin RatCoin) String() string {
+	return fmt.Sprintf("%v%v", coin.Amount, coin.Denom)
+}
+
+// RatCoins - set of fractional coins, sorted by denomination and never
+// holding zero amounts
+type RatCoins []RatCoin
+
+// NewRatCoins converts the coins to fractional coins
+func NewRatCoins(coins sdk.Coins) RatCoins {
+	res := RatCoins{}
+	for _, coin := range coins {
+		if coin.IsZero() {
+			continue
+		}
+		res = append(res, NewRatCoin(coin))
+	}
+	return res.sort()
+}
+
+// nolint
+func (coins RatCoins) String() string {
+	if len(coins) == 0 {
+		return ""
+	}
+	out := make([]string, len(coins))
+	for i, coin := range coins {
+		out[i] = coin.String()
+	}
+	return strings.Join(out, ",")
+}
+
+// IsZero returns true if there are no coins
+func (coins RatCoins) IsZero() bool {
+	return len(coins) == 0
+}
+
+// AmountOf returns the amount of a denomination
+func (coins RatCoins) AmountOf(denom string) sdk.Rat {
+	for _, coin := range coins {
+		if coin.Denom == denom {
+			return coin.Amount
+		}
+	}
+	return sdk.ZeroRat()
+}
+
+// Plus combines two sets of coins
+func (coins RatCoins) Plus(coinsB RatCoins) RatCoins {
+	sum := RatCoins{}
+	i, j := 0, 0
+	for i < len(coins) || j < len(coinsB) {
+		switch {
+		case j == len(coinsB) || (i < len(coins) && coins[i].Denom < coinsB[j].Denom):
+			sum = append(sum, coins[i])
+			i++
+		case i == len(coins) || coinsB[j].Denom < coins[i].Denom:
+			sum = append(sum, coinsB[j])
+			j++
+		default:
+			amount := coins[i].Amount.Add(coinsB[j].Amount)
+			if !amount.IsZero() {
+				sum = append(sum, RatCoin{coins[i].Denom, amount})
+			}
+			i++
+			j++
+		}
+	}
+	return sum
+}
+
+// Minus subtracts a set of coins from another
+func (coins RatCoins) Minus(coinsB RatCoins) RatCoins {
+	return coins.Plus(coinsB.MulRat(sdk.NewRat(-1)))
+}
+
+// MulRat multiplies all the amounts by a fraction
+func (coins RatCoins) MulRat(r sdk.Rat) RatCoins {
+	res := RatCoins{}
+	if r.IsZero() {
+		return res
+	}
+	for _, coin := range coins {
+		res = append(res, RatCoin{coin.Denom, coin.Amount.Mul(r)})
+	}
+	return res
+}
+
+// RoundDown rounds all the amounts down to a multiple of 1/precision
+func (coins RatCoins) RoundDown(precision int64) RatCoins {
+	res := RatCoins{}
+	p := big.NewInt(precision)
+	for _, coin := range coins {
+		// big.Int division rounds towards negative infinity for a positive divisor
+		num := new(big.Int).Mul(coin.Amount.Num().BigInt(), p)
+		num.Div(num, coin.Amount.Denom().BigInt())
+		amount := sdk.NewRatFromBigInt(num, p)
+		if !amount.IsZero() {
+			res = append(res, RatCoin{coin.Denom, amount})
+		}
+	}
+	return res
+}
+
+// QuoRat divides all the amounts by a fraction
+func (coins RatCoins) QuoRat(r sdk.Rat) RatCoins {
+	res := RatCoins{}
+	for _, coin := range coins {
+		res = append(res, RatCoin{coin.Denom, coin.Amount.Quo(r)})
+	}
+	return res
+}
+
+// TruncateDecimal splits the coins into the whole coins which can be paid out
+// and the fractional remainder
+func (coins RatCoins) TruncateDecimal() (sdk.Coins, RatCoins) {
+	whole := sdk.Coins{}
+	remainder := RatCoins{}
+	for _, coin := range coins {
+		amount := coin.Amount.Num().Div(coin.Amount.Denom())
+		if !amount.IsZero() {
+			whole = append(whole, sdk.Coin{coin.Denom, amount})
+		}
+		change := coin.Amount.Sub(sdk.NewRatFromInt(amount))
+		if !change.IsZero() {
+			remainder = append(remainder, RatCoin{coin.Denom, change})
+		}
+	}
+	return whole, remainder
+}
+
+func (coins RatCoins) sort() RatCoins {
+	sort.Slice(coins, func(i, j int) bool { return coins[i].Denom < coins[j].Denom })
+	return coins
+}
+
+//__________________________________________________________________________
+
+// ValidatorDistInfo - rewards accumulated by a validator
+type ValidatorDistInfo struct {
+	ValidatorAddr   sdk.Address `json:"validator_addr"`    // owner address of the validator
+	Commission      RatCoins    `json:"commission"`        // commission and proposer rewards not yet withdrawn by the owner
+	RewardsPerShare RatCoins    `json:"rewards_per_share"` // cumulative rewards of the delegators per delegator share
+}
+
+// NewValidatorDistInfo - initialize a validator which did not receive rewards yet
+func NewValidatorDistInfo(validatorAddr sdk.Address) ValidatorDistInfo {
+	return ValidatorDistInfo{
+		ValidatorAddr:   validatorAddr,
+		Commission:      RatCoins{},
+		RewardsPerShare: RatCoins{},
+	}
+}
+
+// DelegatorDistInfo - rewards accumulated by a delegation
+type DelegatorDistInfo struct {
+	DelegatorAddr   sdk.Address `json:"delegator_addr"`
+	ValidatorAddr   sdk.Address `json:"validator_addr"`
+	RewardsPerShare RatCoins    `json:"rewards_per_share"` // rewards per share of the validator when the delegation was last settled
+	Rewards         RatCoins    `json:"rewards"`           // settled rewards which were not paid out, being fractions of coins
+}
+
+// NewDelegatorDistInfo - initialize a delegation which did not settle rewards yet
+func NewDelegatorDistInfo(delegatorAddr, validatorAddr sdk.Address) DelegatorDistInfo {
+	return DelegatorDistInfo{
+		DelegatorAddr:   delegatorAddr,
+		ValidatorAddr:   validatorAddr,
+		RewardsPerShare: RatCoins{},
+		Rewards:         RatCoins{},
+	}
+}
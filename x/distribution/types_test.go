@@ -0,0 +1,60 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+func TestRatCoinsArithmetic(t *testing.T) {
+	a := NewRatCoins(sdk.Coins{sdk.NewCoin("atom", 3), sdk.NewCoin("steak", 10)})
+	b := NewRatCoins(sdk.Coins{sdk.NewCoin("photon", 1), sdk.NewCoin("steak", 5)})
+
+	sum := a.Plus(b)
+	require.Equal(t, 3, len(sum))
+	require.Equal(t, "atom", sum[0].Denom)
+	require.Equal(t, "photon", sum[1].Denom)
+	require.True(t, sdk.NewRat(15).Equal(sum.AmountOf("steak")))
+
+	// zero amounts are dropped
+	diff := sum.Minus(b)
+	require.Equal(t, 2, len(diff))
+	require.True(t, sdk.NewRat(3).Equal(diff.AmountOf("atom")))
+	require.True(t, diff.Minus(a).IsZero())
+
+	third := a.QuoRat(sdk.NewRat(3))
+	require.True(t, sdk.NewRat(10, 3).Equal(third.AmountOf("steak")))
+	require.True(t, a.MulRat(sdk.ZeroRat()).IsZero())
+	require.True(t, third.MulRat(sdk.NewRat(3)).Minus(a).IsZero())
+}
+
+func TestRatCoinsTruncateDecimal(t *testing.T) {
+	coins := RatCoins{
+		{"atom", sdk.NewRat(1, 3)},
+		{"steak", sdk.NewRat(22, 7)},
+		{"tree", sdk.NewRat(2)},
+	}
+	whole, remainder := coins.TruncateDecimal()
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 3), sdk.NewCoin("tree", 2)}, whole)
+	require.Equal(t, 2, len(remainder))
+	require.True(t, sdk.NewRat(1, 3).Equal(remainder.AmountOf("atom")))
+	require.True(t, sdk.NewRat(1, 7).Equal(remainder.AmountOf("steak")))
+
+	// nothing is lost
+	require.True(t, NewRatCoins(whole).Plus(remainder).Minus(coins).IsZero())
+}
+
+func TestRatCoinsRoundDown(t *testing.T) {
+	coins := RatCoins{
+		{"atom", sdk.NewRat(1, 3)},
+		{"steak", sdk.NewRat(5, 4)},
+		{"tree", sdk.NewRat(1, 1000)},
+	}
+	rounded := coins.RoundDown(100)
+	require.Equal(t, 2, len(rounded))
+	require.True(t, sdk.NewRat(33, 100).Equal(rounded.AmountOf("atom")))
+	require.True(t, sdk.NewRat(125, 100).Equal(rounded.AmountOf("steak")))
+	require.True(t, rounded.AmountOf("tree").IsZero())
+}
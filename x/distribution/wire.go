@@ -0,0 +1,10 @@
+package distribution
+
+import (
+	"github.com/tepleton/tepleton-sdk/wire"
+)
+
+// Register concrete types on wire codec
+func RegisterWire(cdc *wire.Codec) {
+	cdc.RegisterConcrete(MsgWithdrawRewards{}, "tepleton-sdk/MsgWithdrawRewards", nil)
+}
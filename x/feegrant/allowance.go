@@ -0,0 +1,31 @@
+package feegrant
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// FeeAllowance - fees a granter accepts to pay for the txs of a grantee
+type FeeAllowance struct {
+	SpendLimit sdk.Coins `json:"spend_limit"` // fees left to pay, no limit if empty
+	Expiration int64     `json:"expiration"`  // unix time from which the allowance can't be used, never if zero
+}
+
+// NewFeeAllowance creates a fee allowance
+func NewFeeAllowance(spendLimit sdk.Coins, expiration int64) FeeAllowance {
+	return FeeAllowance{
+		SpendLimit: spendLimit,
+		Expiration: expiration,
+	}
+}
+
+// IsExpired returns true if the allowance can't be used anymore at the block time
+func (allowance FeeAllowance) IsExpired(blockTime int64) bool {
+	return allowance.Expiration != 0 && blockTime >= allowance.Expiration
+}
+
+// FeeGrant - the fee allowance a granter gave to a grantee
+type FeeGrant struct {
+	Granter   sdk.Address  `json:"granter"`
+	Grantee   sdk.Address  `json:"grantee"`
+	Allowance FeeAllowance `json:"allowance"`
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tepleton/tepleton-sdk/client/context"
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+	"github.com/tepleton/tepleton-sdk/x/feegrant"
+)
+
+// get the command to query the fee allowance of a granter to a grantee
+func GetCmdQueryFeeAllowance(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fee-allowance [granter] [grantee]",
+		Short: "Query the fee allowance a granter gave to a grantee",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			granter, err := sdk.GetAccAddressBech32(args[0])
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.GetAccAddressBech32(args[1])
+			if err != nil {
+				return err
+			}
+			key := feegrant.GetFeeAllowanceKey(granter, grantee)
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.QueryStore(key, storeName)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return errors.Errorf("no fee allowance from %s to %s", args[0], args[1])
+			}
+			var allowance feegrant.FeeAllowance
+			cdc.MustUnmarshalBinary(res, &allowance)
+
+			output, err := wire.MarshalJSONIndent(cdc, allowance)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,50 @@
+//nolint
+package feegrant
+
+import (
+	"fmt"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default feegrant codespace
+	DefaultCodespace sdk.CodespaceType = 12
+
+	CodeInvalidAddress     CodeType = 101
+	CodeInvalidAllowance   CodeType = 102
+	CodeNoAllowance        CodeType = 103
+	CodeAllowanceExpired   CodeType = 104
+	CodeSpendLimitExceeded CodeType = 105
+)
+
+func ErrNilGranterAddr(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAddress, "granter address is nil")
+}
+func ErrNilGranteeAddr(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAddress, "grantee address is nil")
+}
+func ErrAddrTooLong(codespace sdk.CodespaceType, addr sdk.Address) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAddress, fmt.Sprintf("address %s is longer than %d bytes", addr, maxAddrLen))
+}
+func ErrSelfGrant(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAddress, "cannot grant a fee allowance to oneself")
+}
+func ErrInvalidSpendLimit(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAllowance, "spend limit must be valid positive coins")
+}
+func ErrInvalidExpiration(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAllowance, "expiration must not be negative")
+}
+func ErrNoAllowance(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeNoAllowance, "no fee allowance from the granter to the grantee")
+}
+func ErrAllowanceExpired(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeAllowanceExpired, "fee allowance expired")
+}
+func ErrSpendLimitExceeded(codespace sdk.CodespaceType, fee, spendLimit sdk.Coins) sdk.Error {
+	return sdk.NewError(codespace, CodeSpendLimitExceeded, fmt.Sprintf("fee %s exceeds the spend limit %s left", fee, spendLimit))
+}
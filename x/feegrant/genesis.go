@@ -0,0 +1,36 @@
+package feegrant
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// GenesisState - all feegrant state that must be provided at genesis
+type GenesisState struct {
+	FeeGrants []FeeGrant `json:"fee_grants"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		FeeGrants: []FeeGrant{},
+	}
+}
+
+// InitGenesis - store the genesis fee grants
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	for _, grant := range data.FeeGrants {
+		k.GrantFeeAllowance(ctx, grant.Granter, grant.Grantee, grant.Allowance)
+	}
+}
+
+// WriteGenesis - output all the fee grants
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	grants := []FeeGrant{}
+	k.IterateFeeGrants(ctx, func(grant FeeGrant) (stop bool) {
+		grants = append(grants, grant)
+		return false
+	})
+	return GenesisState{
+		FeeGrants: grants,
+	}
+}
@@ -0,0 +1,54 @@
+package feegrant
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// NewHandler handles the feegrant messages
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		// NOTE msg already has validate basic run
+		switch msg := msg.(type) {
+		case MsgGrantFeeAllowance:
+			return handleMsgGrantFeeAllowance(ctx, msg, k)
+		case MsgRevokeFeeAllowance:
+			return handleMsgRevokeFeeAllowance(ctx, msg, k)
+		default:
+			return sdk.ErrTxDecode("invalid message parse in feegrant module").Result()
+		}
+	}
+}
+
+func handleMsgGrantFeeAllowance(ctx sdk.Context, msg MsgGrantFeeAllowance, k Keeper) sdk.Result {
+	if msg.Allowance.IsExpired(ctx.BlockHeader().Time) {
+		return ErrAllowanceExpired(k.codespace).Result()
+	}
+
+	k.GrantFeeAllowance(ctx, msg.Granter, msg.Grantee, msg.Allowance)
+
+	tags := sdk.NewTags(
+		"action", []byte("grantFeeAllowance"),
+		"granter", []byte(msg.Granter.String()),
+		"grantee", []byte(msg.Grantee.String()),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+func handleMsgRevokeFeeAllowance(ctx sdk.Context, msg MsgRevokeFeeAllowance, k Keeper) sdk.Result {
+	if _, found := k.GetFeeAllowance(ctx, msg.Granter, msg.Grantee); !found {
+		return ErrNoAllowance(k.codespace).Result()
+	}
+
+	k.RevokeFeeAllowance(ctx, msg.Granter, msg.Grantee)
+
+	tags := sdk.NewTags(
+		"action", []byte("revokeFeeAllowance"),
+		"granter", []byte(msg.Granter.String()),
+		"grantee", []byte(msg.Grantee.String()),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
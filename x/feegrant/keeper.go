@@ -0,0 +1,119 @@
+package feegrant
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+)
+
+// Keeper of the feegrant store
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *wire.Codec
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper creates a feegrant keeper
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:  key,
+		cdc:       cdc,
+		codespace: codespace,
+	}
+}
+
+// the addresses in the keys are prefixed with their length in a single byte
+const maxAddrLen = 255
+
+// Key for the fee allowance of a granter to a grantee, each address is
+// prefixed with its length to split them back
+func GetFeeAllowanceKey(granter, grantee sdk.Address) []byte {
+	key := append([]byte{0x00}, byte(len(granter)))
+	key = append(key, granter.Bytes()...)
+	key = append(key, byte(len(grantee)))
+	return append(key, grantee.Bytes()...)
+}
+
+// split a fee allowance key into the granter and grantee addresses
+func splitFeeAllowanceKey(key []byte) (granter, grantee sdk.Address) {
+	granterLen := int(key[1])
+	granter = sdk.Address(key[2 : 2+granterLen])
+	return granter, sdk.Address(key[3+granterLen:])
+}
+
+// GetFeeAllowance returns the fee allowance of a granter to a grantee
+func (k Keeper) GetFeeAllowance(ctx sdk.Context, granter, grantee sdk.Address) (allowance FeeAllowance, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetFeeAllowanceKey(granter, grantee))
+	if bz == nil {
+		return allowance, false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &allowance)
+	return allowance, true
+}
+
+// GrantFeeAllowance sets the fee allowance of a granter to a grantee,
+// replacing the former one if any
+func (k Keeper) GrantFeeAllowance(ctx sdk.Context, granter, grantee sdk.Address, allowance FeeAllowance) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(allowance)
+	store.Set(GetFeeAllowanceKey(granter, grantee), bz)
+}
+
+// RevokeFeeAllowance removes the fee allowance of a granter to a grantee
+func (k Keeper) RevokeFeeAllowance(ctx sdk.Context, granter, grantee sdk.Address) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetFeeAllowanceKey(granter, grantee))
+}
+
+// IterateFeeGrants iterates over all the fee grants
+func (k Keeper) IterateFeeGrants(ctx sdk.Context, fn func(grant FeeGrant) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, []byte{0x00})
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var allowance FeeAllowance
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &allowance)
+		granter, grantee := splitFeeAllowanceKey(iterator.Key())
+		grant := FeeGrant{
+			Granter:   granter,
+			Grantee:   grantee,
+			Allowance: allowance,
+		}
+		if fn(grant) {
+			return
+		}
+	}
+}
+
+// UseGrantedFees deducts the fee of a tx of the grantee from the allowance of
+// the granter. Implements auth.FeeGrantKeeper, the caller deducts the fee from
+// the account of the granter.
+func (k Keeper) UseGrantedFees(ctx sdk.Context, granter, grantee sdk.Address, fee sdk.Coins) sdk.Error {
+	allowance, found := k.GetFeeAllowance(ctx, granter, grantee)
+	if !found {
+		return ErrNoAllowance(k.codespace)
+	}
+	if allowance.IsExpired(ctx.BlockHeader().Time) {
+		return ErrAllowanceExpired(k.codespace)
+	}
+
+	// an allowance without spend limit doesn't change
+	if allowance.SpendLimit.IsZero() {
+		return nil
+	}
+	left := allowance.SpendLimit.Minus(fee)
+	if !left.IsNotNegative() {
+		return ErrSpendLimitExceeded(k.codespace, fee, allowance.SpendLimit)
+	}
+
+	// the allowance is used up once its spend limit is spent
+	if left.IsZero() {
+		k.RevokeFeeAllowance(ctx, granter, grantee)
+		return nil
+	}
+	allowance.SpendLimit = left
+	k.GrantFeeAllowance(ctx, granter, grantee, allowance)
+	return nil
+}
@@ -0,0 +1,133 @@
+package feegrant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+	"github.com/tepleton/tepleton/crypto"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+	"github.com/tepleton/tepleton-sdk/x/mock"
+)
+
+var (
+	granter = sdk.Address(crypto.GenPrivKeyEd25519().PubKey().Address())
+	grantee = sdk.Address(crypto.GenPrivKeyEd25519().PubKey().Address())
+)
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper) {
+	keyFeeGrant := sdk.NewKVStoreKey("feegrant")
+	ctx := mock.NewTestContext(t, 1000, keyFeeGrant)
+	cdc := wire.NewCodec()
+	RegisterWire(cdc)
+	return ctx, NewKeeper(cdc, keyFeeGrant, DefaultCodespace)
+}
+
+func TestUseGrantedFees(t *testing.T) {
+	ctx, k := createTestInput(t)
+	fee := sdk.Coins{sdk.NewCoin("steak", 10)}
+
+	// no allowance
+	err := k.UseGrantedFees(ctx, granter, grantee, fee)
+	require.Equal(t, CodeNoAllowance, err.Code())
+
+	// the spend limit decreases, the allowance is removed once used up
+	k.GrantFeeAllowance(ctx, granter, grantee, NewFeeAllowance(sdk.Coins{sdk.NewCoin("steak", 25)}, 0))
+	require.Nil(t, k.UseGrantedFees(ctx, granter, grantee, fee))
+	allowance, found := k.GetFeeAllowance(ctx, granter, grantee)
+	require.True(t, found)
+	require.True(t, allowance.SpendLimit.IsEqual(sdk.Coins{sdk.NewCoin("steak", 15)}))
+	require.Nil(t, k.UseGrantedFees(ctx, granter, grantee, fee))
+	err = k.UseGrantedFees(ctx, granter, grantee, fee)
+	require.Equal(t, CodeSpendLimitExceeded, err.Code())
+	require.Nil(t, k.UseGrantedFees(ctx, granter, grantee, sdk.Coins{sdk.NewCoin("steak", 5)}))
+	_, found = k.GetFeeAllowance(ctx, granter, grantee)
+	require.False(t, found)
+
+	// other denominations aren't granted
+	k.GrantFeeAllowance(ctx, granter, grantee, NewFeeAllowance(sdk.Coins{sdk.NewCoin("steak", 25)}, 0))
+	err = k.UseGrantedFees(ctx, granter, grantee, sdk.Coins{sdk.NewCoin("photino", 1)})
+	require.Equal(t, CodeSpendLimitExceeded, err.Code())
+
+	// the grant is directed
+	err = k.UseGrantedFees(ctx, grantee, granter, fee)
+	require.Equal(t, CodeNoAllowance, err.Code())
+
+	// no spend limit
+	k.GrantFeeAllowance(ctx, granter, grantee, NewFeeAllowance(nil, 2000))
+	require.Nil(t, k.UseGrantedFees(ctx, granter, grantee, sdk.Coins{sdk.NewCoin("steak", 1000)}))
+	allowance, found = k.GetFeeAllowance(ctx, granter, grantee)
+	require.True(t, found)
+	require.True(t, allowance.SpendLimit.IsZero())
+
+	// expired
+	ctx = ctx.WithBlockHeader(wrsp.Header{Time: 2000})
+	err = k.UseGrantedFees(ctx, granter, grantee, fee)
+	require.Equal(t, CodeAllowanceExpired, err.Code())
+}
+
+func TestHandleMsgFeeAllowance(t *testing.T) {
+	ctx, k := createTestInput(t)
+	handler := NewHandler(k)
+	allowance := NewFeeAllowance(sdk.Coins{sdk.NewCoin("steak", 25)}, 1500)
+
+	res := handler(ctx, NewMsgRevokeFeeAllowance(granter, grantee))
+	require.False(t, res.IsOK())
+
+	res = handler(ctx, NewMsgGrantFeeAllowance(granter, grantee, NewFeeAllowance(nil, 1000)))
+	require.False(t, res.IsOK(), "expired allowance granted")
+
+	res = handler(ctx, NewMsgGrantFeeAllowance(granter, grantee, allowance))
+	require.True(t, res.IsOK(), res.Log)
+	stored, found := k.GetFeeAllowance(ctx, granter, grantee)
+	require.True(t, found)
+	require.Equal(t, allowance.Expiration, stored.Expiration)
+	require.True(t, allowance.SpendLimit.IsEqual(stored.SpendLimit))
+
+	genesis := WriteGenesis(ctx, k)
+	require.Equal(t, 1, len(genesis.FeeGrants))
+	require.Equal(t, granter, genesis.FeeGrants[0].Granter)
+	require.Equal(t, grantee, genesis.FeeGrants[0].Grantee)
+
+	res = handler(ctx, NewMsgRevokeFeeAllowance(granter, grantee))
+	require.True(t, res.IsOK(), res.Log)
+	_, found = k.GetFeeAllowance(ctx, granter, grantee)
+	require.False(t, found)
+
+	// addresses of different lengths are split back from the keys
+	shortGrantee := sdk.Address("grantee")
+	k.GrantFeeAllowance(ctx, granter, shortGrantee, allowance)
+	genesis = WriteGenesis(ctx, k)
+	require.Equal(t, 1, len(genesis.FeeGrants))
+	require.Equal(t, granter, genesis.FeeGrants[0].Granter)
+	require.Equal(t, shortGrantee, genesis.FeeGrants[0].Grantee)
+}
+
+func TestMsgGrantFeeAllowanceValidation(t *testing.T) {
+	cases := []struct {
+		granter, grantee sdk.Address
+		allowance        FeeAllowance
+		valid            bool
+	}{
+		{granter, grantee, NewFeeAllowance(sdk.Coins{sdk.NewCoin("steak", 1)}, 100), true},
+		{granter, grantee, NewFeeAllowance(nil, 0), true},
+		{nil, grantee, NewFeeAllowance(nil, 0), false},
+		{granter, nil, NewFeeAllowance(nil, 0), false},
+		{granter, granter, NewFeeAllowance(nil, 0), false},
+		{granter, grantee, NewFeeAllowance(sdk.Coins{sdk.NewCoin("steak", 0)}, 0), false},
+		{granter, grantee, NewFeeAllowance(sdk.Coins{sdk.NewCoin("steak", -1)}, 0), false},
+		{granter, grantee, NewFeeAllowance(nil, -1), false},
+		{granter, sdk.Address(make([]byte, maxAddrLen+1)), NewFeeAllowance(nil, 0), false},
+	}
+	for i, tc := range cases {
+		msg := NewMsgGrantFeeAllowance(tc.granter, tc.grantee, tc.allowance)
+		if tc.valid {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package feegrant
+
+import (
+	"bytes"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// name to identify transaction types
+const MsgType = "feegrant"
+
+// verify interface at compile time
+var _, _ sdk.Msg = MsgGrantFeeAllowance{}, MsgRevokeFeeAllowance{}
+
+//______________________________________________________________________
+
+// MsgGrantFeeAllowance - struct for granting a fee allowance to an account
+type MsgGrantFeeAllowance struct {
+	Granter   sdk.Address  `json:"granter"`
+	Grantee   sdk.Address  `json:"grantee"`
+	Allowance FeeAllowance `json:"allowance"`
+}
+
+func NewMsgGrantFeeAllowance(granter, grantee sdk.Address, allowance FeeAllowance) MsgGrantFeeAllowance {
+	return MsgGrantFeeAllowance{
+		Granter:   granter,
+		Grantee:   grantee,
+		Allowance: allowance,
+	}
+}
+
+//nolint
+func (msg MsgGrantFeeAllowance) Type() string              { return MsgType }
+func (msg MsgGrantFeeAllowance) GetSigners() []sdk.Address { return []sdk.Address{msg.Granter} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgGrantFeeAllowance) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Granter   string       `json:"granter"`
+		Grantee   string       `json:"grantee"`
+		Allowance FeeAllowance `json:"allowance"`
+	}{
+		Granter:   sdk.MustBech32ifyAcc(msg.Granter),
+		Grantee:   sdk.MustBech32ifyAcc(msg.Grantee),
+		Allowance: msg.Allowance,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgGrantFeeAllowance) ValidateBasic() sdk.Error {
+	if len(msg.Granter) == 0 {
+		return ErrNilGranterAddr(DefaultCodespace)
+	}
+	if len(msg.Grantee) == 0 {
+		return ErrNilGranteeAddr(DefaultCodespace)
+	}
+	if len(msg.Granter) > maxAddrLen {
+		return ErrAddrTooLong(DefaultCodespace, msg.Granter)
+	}
+	if len(msg.Grantee) > maxAddrLen {
+		return ErrAddrTooLong(DefaultCodespace, msg.Grantee)
+	}
+	if bytes.Equal(msg.Granter, msg.Grantee) {
+		return ErrSelfGrant(DefaultCodespace)
+	}
+	spendLimit := msg.Allowance.SpendLimit
+	if len(spendLimit) != 0 && (!spendLimit.IsValid() || !spendLimit.IsPositive()) {
+		return ErrInvalidSpendLimit(DefaultCodespace)
+	}
+	if msg.Allowance.Expiration < 0 {
+		return ErrInvalidExpiration(DefaultCodespace)
+	}
+	return nil
+}
+
+//______________________________________________________________________
+
+// MsgRevokeFeeAllowance - struct for revoking the fee allowance granted to an account
+type MsgRevokeFeeAllowance struct {
+	Granter sdk.Address `json:"granter"`
+	Grantee sdk.Address `json:"grantee"`
+}
+
+func NewMsgRevokeFeeAllowance(granter, grantee sdk.Address) MsgRevokeFeeAllowance {
+	return MsgRevokeFeeAllowance{
+		Granter: granter,
+		Grantee: grantee,
+	}
+}
+
+//nolint
+func (msg MsgRevokeFeeAllowance) Type() string              { return MsgType }
+func (msg MsgRevokeFeeAllowance) GetSigners() []sdk.Address { return []sdk.Address{msg.Granter} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgRevokeFeeAllowance) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Granter string `json:"granter"`
+		Grantee string `json:"grantee"`
+	}{
+		Granter: sdk.MustBech32ifyAcc(msg.Granter),
+		Grantee: sdk.MustBech32ifyAcc(msg.Grantee),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgRevokeFeeAllowance) ValidateBasic() sdk.Error {
+	if len(msg.Granter) == 0 {
+		return ErrNilGranterAddr(DefaultCodespace)
+	}
+	if len(msg.Grantee) == 0 {
+		return ErrNilGranteeAddr(DefaultCodespace)
+	}
+	if len(msg.Granter) > maxAddrLen {
+		return ErrAddrTooLong(DefaultCodespace, msg.Granter)
+	}
+	if len(msg.Grantee) > maxAddrLen {
+		return ErrAddrTooLong(DefaultCodespace, msg.Grantee)
+	}
+	return nil
+}
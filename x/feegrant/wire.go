@@ -0,0 +1,17 @@
+package feegrant
+
+import (
+	"github.com/tepleton/tepleton-sdk/wire"
+)
+
+// Register concrete types on wire codec
+func RegisterWire(cdc *wire.Codec) {
+	cdc.RegisterConcrete(MsgGrantFeeAllowance{}, "tepleton-sdk/MsgGrantFeeAllowance", nil)
+	cdc.RegisterConcrete(MsgRevokeFeeAllowance{}, "tepleton-sdk/MsgRevokeFeeAllowance", nil)
+}
+
+var msgCdc = wire.NewCodec()
+
+func init() {
+	RegisterWire(msgCdc)
+}
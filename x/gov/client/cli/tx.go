@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -11,19 +12,24 @@ import (
 	"github.com/tepleton/tepleton-sdk/wire"
 	authcmd "github.com/tepleton/tepleton-sdk/x/auth/client/cli"
 	"github.com/tepleton/tepleton-sdk/x/gov"
+	"github.com/tepleton/tepleton-sdk/x/upgrade"
 	"github.com/pkg/errors"
 )
 
 const (
-	flagProposalID   = "proposalID"
-	flagTitle        = "title"
-	flagDescription  = "description"
-	flagProposalType = "type"
-	flagDeposit      = "deposit"
-	flagProposer     = "proposer"
-	flagDepositer    = "depositer"
-	flagVoter        = "voter"
-	flagOption       = "option"
+	flagProposalID    = "proposalID"
+	flagTitle         = "title"
+	flagDescription   = "description"
+	flagProposalType  = "type"
+	flagDeposit       = "deposit"
+	flagProposer      = "proposer"
+	flagDepositer     = "depositer"
+	flagVoter         = "voter"
+	flagOption        = "option"
+	flagParamChanges  = "paramChanges"
+	flagUpgradeName   = "upgradeName"
+	flagUpgradeHeight = "upgradeHeight"
+	flagUpgradeInfo   = "upgradeInfo"
 )
 
 // submit a proposal tx
@@ -55,6 +61,19 @@ func GetCmdSubmitProposal(cdc *wire.Codec) *cobra.Command {
 
 			// create the message
 			msg := gov.NewMsgSubmitProposal(title, description, proposalType, from, amount)
+			if strChanges := viper.GetString(flagParamChanges); strChanges != "" {
+				err = json.Unmarshal([]byte(strChanges), &msg.ParamChanges)
+				if err != nil {
+					return err
+				}
+			}
+			if proposalType == gov.ProposalTypeSoftwareUpgrade {
+				msg.UpgradePlan = upgrade.Plan{
+					Name:   viper.GetString(flagUpgradeName),
+					Height: viper.GetInt64(flagUpgradeHeight),
+					Info:   viper.GetString(flagUpgradeInfo),
+				}
+			}
 
 			err = msg.ValidateBasic()
 			if err != nil {
@@ -79,6 +98,10 @@ func GetCmdSubmitProposal(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().String(flagProposalType, "", "proposalType of proposal")
 	cmd.Flags().String(flagDeposit, "", "deposit of proposal")
 	cmd.Flags().String(flagProposer, "", "proposer of proposal")
+	cmd.Flags().String(flagParamChanges, "", "json list of {subspace, key, value} param changes for a ParameterChange proposal")
+	cmd.Flags().String(flagUpgradeName, "", "name of the upgrade handler for a SoftwareUpgrade proposal")
+	cmd.Flags().Int64(flagUpgradeHeight, 0, "height at which the chain halts for a SoftwareUpgrade proposal")
+	cmd.Flags().String(flagUpgradeInfo, "", "info about the new binary for a SoftwareUpgrade proposal")
 
 	return cmd
 }
@@ -9,6 +9,7 @@ import (
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	"github.com/tepleton/tepleton-sdk/wire"
 	"github.com/tepleton/tepleton-sdk/x/gov"
+	"github.com/tepleton/tepleton-sdk/x/upgrade"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 )
@@ -36,12 +37,14 @@ func RegisterRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec) {
 }
 
 type postProposalReq struct {
-	BaseReq        baseReq   `json:"base_req"`
-	Title          string    `json:"title"`           //  Title of the proposal
-	Description    string    `json:"description"`     //  Description of the proposal
-	ProposalType   string    `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Proposer       string    `json:"proposer"`        //  Address of the proposer
-	InitialDeposit sdk.Coins `json:"initial_deposit"` // Coins to add to the proposal's deposit
+	BaseReq        baseReq           `json:"base_req"`
+	Title          string            `json:"title"`           //  Title of the proposal
+	Description    string            `json:"description"`     //  Description of the proposal
+	ProposalType   string            `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Proposer       string            `json:"proposer"`        //  Address of the proposer
+	InitialDeposit sdk.Coins         `json:"initial_deposit"` // Coins to add to the proposal's deposit
+	ParamChanges   []gov.ParamChange `json:"param_changes"`   // Param changes applied if a ParameterChange proposal passes
+	UpgradePlan    upgrade.Plan      `json:"upgrade_plan"`    // Upgrade plan scheduled if a SoftwareUpgrade proposal passes
 }
 
 type depositReq struct {
@@ -82,6 +85,8 @@ func postProposalHandlerFn(cdc *wire.Codec, ctx context.CoreContext) http.Handle
 
 		// create the message
 		msg := gov.NewMsgSubmitProposal(req.Title, req.Description, proposalTypeByte, proposer, req.InitialDeposit)
+		msg.ParamChanges = req.ParamChanges
+		msg.UpgradePlan = req.UpgradePlan
 		err = msg.ValidateBasic()
 		if err != nil {
 			writeErr(&w, http.StatusBadRequest, err.Error())
@@ -7,6 +7,10 @@ import (
 
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	wrsp "github.com/tepleton/tepleton/wrsp/types"
+	"github.com/tepleton/tepleton/crypto"
+
+	"github.com/tepleton/tepleton-sdk/x/stake"
+	"github.com/tepleton/tepleton-sdk/x/upgrade"
 )
 
 func TestTickExpiredDepositPeriod(t *testing.T) {
@@ -166,3 +170,133 @@ func TestTickPassedVotingPeriod(t *testing.T) {
 	depositsIterator.Close()
 	require.Equal(t, StatusRejected, keeper.GetProposal(ctx, proposalID).GetStatus())
 }
+
+func TestTickPassedParameterChangeProposal(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(wrsp.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, wrsp.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	dummyDescription := stake.NewDescription("T", "E", "S", "T")
+	dummyCommission := stake.NewCommissionMsg(sdk.ZeroRat(), sdk.ZeroRat(), sdk.ZeroRat())
+	valCreateMsg := stake.NewMsgCreateValidator(addrs[0], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 5), dummyDescription, dummyCommission, 0)
+	res := stakeHandler(ctx, valCreateMsg)
+	require.True(t, res.IsOK())
+
+	// changes which do not match the type or the range of a registered
+	// param, or of unknown params, are rejected on submission
+	badChanges := []ParamChange{
+		{"gov", "votingprocedure", `{"period":"100"}`},
+		{"gov", "votingprocedure", `{"voting_period":"0"}`},
+		{"gov", "tallyingprocedure", `{"quorum":"-1/3","threshold":"1/2","veto":"1/3","governance_penalty":"1/100"}`},
+		{"gov", "tallyingprocedure", `{"quorum":"1/0","threshold":"1/2","veto":"1/3","governance_penalty":"1/100"}`},
+		{"gov", "votingprocedur", `{"voting_period":"100"}`},
+	}
+	for _, badChange := range badChanges {
+		res = govHandler(ctx, NewMsgSubmitParamChangeProposal("Test", "test", []ParamChange{badChange}, addrs[1], sdk.Coins{sdk.NewCoin("steak", 10)}))
+		require.False(t, res.IsOK(), badChange.Value)
+	}
+
+	changes := []ParamChange{
+		{"gov", "votingprocedure", `{"voting_period":"100"}`},
+		{"gov", "depositprocedure", `{"min_deposit":[{"denom":"steak","amount":"20"}],"max_deposit_period":"100"}`},
+	}
+	res = govHandler(ctx, NewMsgSubmitParamChangeProposal("Test", "test", changes, addrs[1], sdk.Coins{sdk.NewCoin("steak", 10)}))
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+	require.Equal(t, StatusVotingPeriod, keeper.GetProposal(ctx, proposalID).GetStatus())
+
+	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionYes)
+	require.Nil(t, err)
+
+	ctx = ctx.WithBlockHeight(200)
+	EndBlocker(ctx, keeper)
+	require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+
+	require.Equal(t, int64(100), keeper.GetVotingProcedure(ctx).VotingPeriod)
+	depositProcedure := keeper.GetDepositProcedure(ctx)
+	require.Equal(t, int64(100), depositProcedure.MaxDepositPeriod)
+	require.True(t, depositProcedure.MinDeposit.IsEqual(sdk.Coins{sdk.NewCoin("steak", 20)}))
+}
+
+func TestTickPassedSoftwareUpgradeProposal(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(wrsp.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, wrsp.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	dummyDescription := stake.NewDescription("T", "E", "S", "T")
+	dummyCommission := stake.NewCommissionMsg(sdk.ZeroRat(), sdk.ZeroRat(), sdk.ZeroRat())
+	valCreateMsg := stake.NewMsgCreateValidator(addrs[0], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 5), dummyDescription, dummyCommission, 0)
+	res := stakeHandler(ctx, valCreateMsg)
+	require.True(t, res.IsOK())
+
+	plan := upgrade.Plan{Name: "v2", Height: 500, Info: "binary at https://example.com/v2"}
+	res = govHandler(ctx, NewMsgSubmitSoftwareUpgradeProposal("Test", "test", plan, addrs[1], sdk.Coins{sdk.NewCoin("steak", 10)}))
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+
+	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionYes)
+	require.Nil(t, err)
+
+	_, found := keeper.uk.GetUpgradePlan(ctx)
+	require.False(t, found)
+
+	ctx = ctx.WithBlockHeight(200)
+	EndBlocker(ctx, keeper)
+	require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+
+	scheduled, found := keeper.uk.GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, plan, scheduled)
+}
+
+func TestTickPenalizeNonVotingValidators(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(wrsp.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, wrsp.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	tallyingProcedure := keeper.GetTallyingProcedure(ctx)
+	tallyingProcedure.GovernancePenalty = sdk.NewRat(1, 10)
+	keeper.setTallyingProcedure(ctx, tallyingProcedure)
+
+	dummyDescription := stake.NewDescription("T", "E", "S", "T")
+	dummyCommission := stake.NewCommissionMsg(sdk.ZeroRat(), sdk.ZeroRat(), sdk.ZeroRat())
+	res := stakeHandler(ctx, stake.NewMsgCreateValidator(addrs[0], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 40), dummyDescription, dummyCommission, 0))
+	require.True(t, res.IsOK())
+	res = stakeHandler(ctx, stake.NewMsgCreateValidator(addrs[1], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 40), dummyDescription, dummyCommission, 0))
+	require.True(t, res.IsOK())
+
+	res = govHandler(ctx, NewMsgSubmitProposal("Test", "test", ProposalTypeText, addrs[2], sdk.Coins{sdk.NewCoin("steak", 10)}))
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+
+	err := keeper.AddVote(ctx, proposalID, addrs[1], OptionYes)
+	require.Nil(t, err)
+
+	nonVoterPower := keeper.vs.Validator(ctx, addrs[0]).GetPower()
+	voterPower := keeper.vs.Validator(ctx, addrs[1]).GetPower()
+
+	ctx = ctx.WithBlockHeight(200)
+	tags := EndBlocker(ctx, keeper)
+	require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+
+	// only the validator which did not vote is slashed
+	require.True(t, keeper.vs.Validator(ctx, addrs[0]).GetPower().LT(nonVoterPower))
+	require.True(t, keeper.vs.Validator(ctx, addrs[1]).GetPower().Equal(voterPower))
+
+	penalized := false
+	for _, tag := range tags {
+		if string(tag.Key) == "validator" && string(tag.Value) == addrs[0].String() {
+			penalized = true
+		}
+	}
+	require.True(t, penalized)
+}
@@ -20,6 +20,8 @@ const (
 	CodeInvalidProposalType     sdk.CodeType = 8
 	CodeInvalidVote             sdk.CodeType = 9
 	CodeInvalidGenesis          sdk.CodeType = 10
+	CodeInvalidParamChange      sdk.CodeType = 11
+	CodeInvalidUpgradePlan      sdk.CodeType = 12
 )
 
 //----------------------------------------
@@ -65,3 +67,11 @@ func ErrInvalidVote(codespace sdk.CodespaceType, strOption string) sdk.Error {
 func ErrInvalidGenesis(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidVote, msg)
 }
+
+func ErrInvalidParamChange(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidParamChange, fmt.Sprintf("Invalid parameter change: %s", msg))
+}
+
+func ErrInvalidUpgradePlan(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidUpgradePlan, fmt.Sprintf("Invalid upgrade plan: %s", msg))
+}
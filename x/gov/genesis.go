@@ -4,14 +4,28 @@ import (
 	sdk "github.com/tepleton/tepleton-sdk/types"
 )
 
-// GenesisState - all staking state that must be provided at genesis
+// GenesisState - all governance state that must be provided at genesis. The
+// heights of the proposals are relative to the height of the export, so that
+// a chain restarted from an export keeps the remaining deposit and voting
+// periods.
 type GenesisState struct {
-	StartingProposalID int64 `json:"starting_proposalID"`
+	StartingProposalID    int64             `json:"starting_proposalID"`
+	DepositProcedure      DepositProcedure  `json:"deposit_procedure"`
+	VotingProcedure       VotingProcedure   `json:"voting_procedure"`
+	TallyingProcedure     TallyingProcedure `json:"tallying_procedure"`
+	Proposals             []Proposal        `json:"proposals"`
+	Deposits              []Deposit         `json:"deposits"`
+	Votes                 []Vote            `json:"votes"`
+	ActiveProposalQueue   ProposalQueue     `json:"active_proposal_queue"`
+	InactiveProposalQueue ProposalQueue     `json:"inactive_proposal_queue"`
 }
 
-func NewGenesisState(startingProposalID int64) GenesisState {
+func NewGenesisState(startingProposalID int64, dp DepositProcedure, vp VotingProcedure, tp TallyingProcedure) GenesisState {
 	return GenesisState{
 		StartingProposalID: startingProposalID,
+		DepositProcedure:   dp,
+		VotingProcedure:    vp,
+		TallyingProcedure:  tp,
 	}
 }
 
@@ -19,6 +33,9 @@ func NewGenesisState(startingProposalID int64) GenesisState {
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
 		StartingProposalID: 1,
+		DepositProcedure:   DefaultDepositProcedure(),
+		VotingProcedure:    DefaultVotingProcedure(),
+		TallyingProcedure:  DefaultTallyingProcedure(),
 	}
 }
 
@@ -29,13 +46,79 @@ func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
 		// TODO: Handle this with #870
 		panic(err)
 	}
+	k.setDepositProcedure(ctx, data.DepositProcedure)
+	k.setVotingProcedure(ctx, data.VotingProcedure)
+	k.setTallyingProcedure(ctx, data.TallyingProcedure)
+
+	for _, proposal := range data.Proposals {
+		shiftProposalHeights(proposal, ctx.BlockHeight())
+		k.SetProposal(ctx, proposal)
+	}
+	for _, deposit := range data.Deposits {
+		k.setDeposit(ctx, deposit.ProposalID, deposit.Depositer, deposit)
+	}
+	for _, vote := range data.Votes {
+		k.setVote(ctx, vote.ProposalID, vote.Voter, vote)
+	}
+	k.setActiveProposalQueue(ctx, data.ActiveProposalQueue)
+	k.setInactiveProposalQueue(ctx, data.InactiveProposalQueue)
 }
 
 // WriteGenesis - output genesis parameters
 func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
-	initalProposalID, _ := k.getNewProposalID(ctx)
+	startingProposalID, _ := k.peekNextProposalID(ctx)
+	store := ctx.KVStore(k.storeKey)
+
+	proposals := []Proposal{}
+	iterator := sdk.KVStorePrefixIterator(store, KeyProposalsSubspace)
+	for ; iterator.Valid(); iterator.Next() {
+		var proposal Proposal
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &proposal)
+		shiftProposalHeights(proposal, -ctx.BlockHeight())
+		proposals = append(proposals, proposal)
+	}
+	iterator.Close()
+
+	deposits := []Deposit{}
+	iterator = sdk.KVStorePrefixIterator(store, KeyDepositsPrefix)
+	for ; iterator.Valid(); iterator.Next() {
+		var deposit Deposit
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &deposit)
+		deposits = append(deposits, deposit)
+	}
+	iterator.Close()
+
+	votes := []Vote{}
+	iterator = sdk.KVStorePrefixIterator(store, KeyVotesPrefix)
+	for ; iterator.Valid(); iterator.Next() {
+		var vote Vote
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &vote)
+		votes = append(votes, vote)
+	}
+	iterator.Close()
 
 	return GenesisState{
-		initalProposalID,
+		StartingProposalID:    startingProposalID,
+		DepositProcedure:      k.GetDepositProcedure(ctx),
+		VotingProcedure:       k.GetVotingProcedure(ctx),
+		TallyingProcedure:     k.GetTallyingProcedure(ctx),
+		Proposals:             proposals,
+		Deposits:              deposits,
+		Votes:                 votes,
+		ActiveProposalQueue:   k.getActiveProposalQueue(ctx),
+		InactiveProposalQueue: k.getInactiveProposalQueue(ctx),
+	}
+}
+
+// shift the heights of a proposal by an offset, including the height of the
+// plan of a software upgrade proposal. The voting start height is only set
+// once the voting period started, it is -1 before.
+func shiftProposalHeights(proposal Proposal, offset int64) {
+	proposal.SetSubmitBlock(proposal.GetSubmitBlock() + offset)
+	if proposal.GetStatus() != StatusDepositPeriod {
+		proposal.SetVotingStartBlock(proposal.GetVotingStartBlock() + offset)
+	}
+	if upgradeProposal, ok := proposal.(*SoftwareUpgradeProposal); ok {
+		upgradeProposal.Plan.Height += offset
 	}
 }
@@ -1,6 +1,8 @@
 package gov
 
 import (
+	"fmt"
+
 	sdk "github.com/tepleton/tepleton-sdk/types"
 )
 
@@ -23,7 +25,22 @@ func NewHandler(keeper Keeper) sdk.Handler {
 
 func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitProposal) sdk.Result {
 
-	proposal := keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType)
+	var proposal Proposal
+	switch msg.ProposalType {
+	case ProposalTypeParameterChange:
+		err := keeper.ValidateParamChanges(ctx, msg.ParamChanges)
+		if err != nil {
+			return err.Result()
+		}
+		proposal = keeper.NewParameterChangeProposal(ctx, msg.Title, msg.Description, msg.ParamChanges)
+	case ProposalTypeSoftwareUpgrade:
+		if msg.UpgradePlan.Height <= ctx.BlockHeight() {
+			return ErrInvalidUpgradePlan(keeper.codespace, "upgrade height must be in the future").Result()
+		}
+		proposal = keeper.NewSoftwareUpgradeProposal(ctx, msg.Title, msg.Description, msg.UpgradePlan)
+	default:
+		proposal = keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType)
+	}
 
 	err, votingStarted := keeper.AddDeposit(ctx, proposal.GetProposalID(), msg.Proposer, msg.InitialDeposit)
 	if err != nil {
@@ -93,7 +110,7 @@ func handleMsgVote(ctx sdk.Context, keeper Keeper, msg MsgVote) sdk.Result {
 }
 
 // Called every block, process inflation, update validator set
-func EndBlocker(ctx sdk.Context, keeper Keeper) (tags sdk.Tags, nonVotingVals []sdk.Address) {
+func EndBlocker(ctx sdk.Context, keeper Keeper) (tags sdk.Tags) {
 
 	tags = sdk.NewTags()
 
@@ -103,40 +120,79 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) (tags sdk.Tags, nonVotingVals []
 		if inactiveProposal.GetStatus() == StatusDepositPeriod {
 			proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(inactiveProposal.GetProposalID())
 			keeper.DeleteProposal(ctx, inactiveProposal)
-			tags.AppendTag("action", []byte("proposalDropped"))
-			tags.AppendTag("proposalId", proposalIDBytes)
+			tags = tags.AppendTag("action", []byte("proposalDropped"))
+			tags = tags.AppendTag("proposalId", proposalIDBytes)
 		}
 	}
 
 	var passes bool
+	var nonVotingVals []sdk.Address
 
 	// Check if earliest Active Proposal ended voting period yet
 	for shouldPopActiveProposalQueue(ctx, keeper) {
 		activeProposal := keeper.ActiveProposalQueuePop(ctx)
 
-		if ctx.BlockHeight() >= activeProposal.GetVotingStartBlock()+keeper.GetVotingProcedure().VotingPeriod {
+		if ctx.BlockHeight() >= activeProposal.GetVotingStartBlock()+keeper.GetVotingProcedure(ctx).VotingPeriod {
 			passes, nonVotingVals = tally(ctx, keeper, activeProposal)
 			proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(activeProposal.GetProposalID())
 			if passes {
 				keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
 				activeProposal.SetStatus(StatusPassed)
-				tags.AppendTag("action", []byte("proposalPassed"))
-				tags.AppendTag("proposalId", proposalIDBytes)
+				tags = tags.AppendTag("action", []byte("proposalPassed"))
+				tags = tags.AppendTag("proposalId", proposalIDBytes)
+
+				err := executeProposal(ctx, keeper, activeProposal)
+				if err != nil {
+					ctx.Logger().With("module", "x/gov").Error(fmt.Sprintf("failed to execute proposal %d: %s", activeProposal.GetProposalID(), err.Error()))
+					tags = tags.AppendTag("action", []byte("proposalExecutionFailed"))
+					tags = tags.AppendTag("proposalId", proposalIDBytes)
+				}
 			} else {
 				keeper.DeleteDeposits(ctx, activeProposal.GetProposalID())
 				activeProposal.SetStatus(StatusRejected)
-				tags.AppendTag("action", []byte("proposalRejected"))
-				tags.AppendTag("proposalId", proposalIDBytes)
+				tags = tags.AppendTag("action", []byte("proposalRejected"))
+				tags = tags.AppendTag("proposalId", proposalIDBytes)
 			}
 
 			keeper.SetProposal(ctx, activeProposal)
+
+			penalizeNonVotingValidators(ctx, keeper, nonVotingVals)
+			for _, valAddr := range nonVotingVals {
+				tags = tags.AppendTag("action", []byte("validatorPenalized"))
+				tags = tags.AppendTag("validator", []byte(valAddr.String()))
+			}
+		}
+	}
+
+	return tags
+}
+
+// Slash the bonded validators which did not vote on a proposal by the governance penalty
+func penalizeNonVotingValidators(ctx sdk.Context, keeper Keeper, nonVotingVals []sdk.Address) {
+	governancePenalty := keeper.GetTallyingProcedure(ctx).GovernancePenalty
+	for _, valAddr := range nonVotingVals {
+		validator := keeper.vs.Validator(ctx, valAddr)
+		if validator == nil {
+			continue
 		}
+		keeper.vs.Slash(ctx, validator.GetPubKey(), ctx.BlockHeight(), validator.GetPower().RoundInt64(), governancePenalty)
 	}
+}
 
-	return tags, nonVotingVals
+// Apply the effects of a passed proposal
+func executeProposal(ctx sdk.Context, keeper Keeper, proposal Proposal) sdk.Error {
+	switch proposal := proposal.(type) {
+	case *ParameterChangeProposal:
+		return keeper.ApplyParamChanges(ctx, proposal.Changes)
+	case *SoftwareUpgradeProposal:
+		return keeper.ScheduleUpgrade(ctx, proposal.Plan)
+	default:
+		return nil
+	}
 }
+
 func shouldPopInactiveProposalQueue(ctx sdk.Context, keeper Keeper) bool {
-	depositProcedure := keeper.GetDepositProcedure()
+	depositProcedure := keeper.GetDepositProcedure(ctx)
 	peekProposal := keeper.InactiveProposalQueuePeek(ctx)
 
 	if peekProposal == nil {
@@ -150,7 +206,7 @@ func shouldPopInactiveProposalQueue(ctx sdk.Context, keeper Keeper) bool {
 }
 
 func shouldPopActiveProposalQueue(ctx sdk.Context, keeper Keeper) bool {
-	votingProcedure := keeper.GetVotingProcedure()
+	votingProcedure := keeper.GetVotingProcedure(ctx)
 	peekProposal := keeper.ActiveProposalQueuePeek(ctx)
 
 	if peekProposal == nil {
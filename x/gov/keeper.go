@@ -4,10 +4,22 @@ import (
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	wire "github.com/tepleton/tepleton-sdk/wire"
 	"github.com/tepleton/tepleton-sdk/x/bank"
+	"github.com/tepleton/tepleton-sdk/x/params"
+	"github.com/tepleton/tepleton-sdk/x/upgrade"
+)
+
+// nolint - keys of the governance procedures within the global param store
+var (
+	ParamStoreKeyDepositProcedure  = params.ComposeKey("gov", "depositprocedure")
+	ParamStoreKeyVotingProcedure   = params.ComposeKey("gov", "votingprocedure")
+	ParamStoreKeyTallyingProcedure = params.ComposeKey("gov", "tallyingprocedure")
 )
 
 // Governance Keeper
 type Keeper struct {
+	// The reference to the ParamSetter to get and set global params
+	ps params.Setter
+
 	// The reference to the CoinKeeper to modify balances
 	ck bank.Keeper
 
@@ -17,6 +29,9 @@ type Keeper struct {
 	// The reference to the DelegationSet to get information about delegators
 	ds sdk.DelegationSet
 
+	// The reference to the UpgradeKeeper to schedule software upgrades
+	uk upgrade.Keeper
+
 	// The (unexposed) keys used to access the stores from the Context.
 	storeKey sdk.StoreKey
 
@@ -28,12 +43,17 @@ type Keeper struct {
 }
 
 // NewGovernanceMapper returns a mapper that uses go-wire to (binary) encode and decode gov types.
-func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, ds sdk.DelegationSet, codespace sdk.CodespaceType) Keeper {
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ps params.Setter, ck bank.Keeper, ds sdk.DelegationSet, uk upgrade.Keeper, codespace sdk.CodespaceType) Keeper {
+	ps.Register(ParamStoreKeyDepositProcedure, DepositProcedure{}, validateDepositProcedure)
+	ps.Register(ParamStoreKeyVotingProcedure, VotingProcedure{}, validateVotingProcedure)
+	ps.Register(ParamStoreKeyTallyingProcedure, TallyingProcedure{}, validateTallyingProcedure)
 	return Keeper{
 		storeKey:  key,
+		ps:        ps,
 		ck:        ck,
 		ds:        ds,
 		vs:        ds.GetValidatorSet(),
+		uk:        uk,
 		cdc:       cdc,
 		codespace: codespace,
 	}
@@ -68,6 +88,54 @@ func (keeper Keeper) NewTextProposal(ctx sdk.Context, title string, description
 	return proposal
 }
 
+// Creates a new ParameterChangeProposal
+func (keeper Keeper) NewParameterChangeProposal(ctx sdk.Context, title string, description string, changes []ParamChange) Proposal {
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+	var proposal Proposal = &ParameterChangeProposal{
+		TextProposal: TextProposal{
+			ProposalID:       proposalID,
+			Title:            title,
+			Description:      description,
+			ProposalType:     ProposalTypeParameterChange,
+			Status:           StatusDepositPeriod,
+			TotalDeposit:     sdk.Coins{},
+			SubmitBlock:      ctx.BlockHeight(),
+			VotingStartBlock: -1, // TODO: Make Time
+		},
+		Changes: changes,
+	}
+	keeper.SetProposal(ctx, proposal)
+	keeper.InactiveProposalQueuePush(ctx, proposal)
+	return proposal
+}
+
+// Creates a new SoftwareUpgradeProposal
+func (keeper Keeper) NewSoftwareUpgradeProposal(ctx sdk.Context, title string, description string, plan upgrade.Plan) Proposal {
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+	var proposal Proposal = &SoftwareUpgradeProposal{
+		TextProposal: TextProposal{
+			ProposalID:       proposalID,
+			Title:            title,
+			Description:      description,
+			ProposalType:     ProposalTypeSoftwareUpgrade,
+			Status:           StatusDepositPeriod,
+			TotalDeposit:     sdk.Coins{},
+			SubmitBlock:      ctx.BlockHeight(),
+			VotingStartBlock: -1, // TODO: Make Time
+		},
+		Plan: plan,
+	}
+	keeper.SetProposal(ctx, proposal)
+	keeper.InactiveProposalQueuePush(ctx, proposal)
+	return proposal
+}
+
 // Get Proposal from store by ProposalID
 func (keeper Keeper) GetProposal(ctx sdk.Context, proposalID int64) Proposal {
 	store := ctx.KVStore(keeper.storeKey)
@@ -118,6 +186,16 @@ func (keeper Keeper) getNewProposalID(ctx sdk.Context) (proposalID int64, err sd
 	return proposalID, nil
 }
 
+func (keeper Keeper) peekNextProposalID(ctx sdk.Context) (proposalID int64, err sdk.Error) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(KeyNextProposalID)
+	if bz == nil {
+		return -1, ErrInvalidGenesis(keeper.codespace, "InitialProposalID never set")
+	}
+	keeper.cdc.MustUnmarshalBinary(bz, &proposalID)
+	return proposalID, nil
+}
+
 func (keeper Keeper) activateVotingPeriod(ctx sdk.Context, proposal Proposal) {
 	proposal.SetVotingStartBlock(ctx.BlockHeight())
 	proposal.SetStatus(StatusVotingPeriod)
@@ -128,28 +206,93 @@ func (keeper Keeper) activateVotingPeriod(ctx sdk.Context, proposal Proposal) {
 // =====================================================
 // Procedures
 
-// Gets procedure from store. TODO: move to global param store and allow for updating of this
-func (keeper Keeper) GetDepositProcedure() DepositProcedure {
-	return DepositProcedure{
-		MinDeposit:       sdk.Coins{sdk.NewCoin("steak", 10)},
-		MaxDepositPeriod: 200,
+// Gets procedure from the global param store
+func (keeper Keeper) GetDepositProcedure(ctx sdk.Context) (depositProcedure DepositProcedure) {
+	err := keeper.ps.Get(ctx, ParamStoreKeyDepositProcedure, &depositProcedure)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// Gets procedure from the global param store
+func (keeper Keeper) GetVotingProcedure(ctx sdk.Context) (votingProcedure VotingProcedure) {
+	err := keeper.ps.Get(ctx, ParamStoreKeyVotingProcedure, &votingProcedure)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// Gets procedure from the global param store
+func (keeper Keeper) GetTallyingProcedure(ctx sdk.Context) (tallyingProcedure TallyingProcedure) {
+	err := keeper.ps.Get(ctx, ParamStoreKeyTallyingProcedure, &tallyingProcedure)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+func (keeper Keeper) setDepositProcedure(ctx sdk.Context, depositProcedure DepositProcedure) {
+	err := keeper.ps.Set(ctx, ParamStoreKeyDepositProcedure, depositProcedure)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (keeper Keeper) setVotingProcedure(ctx sdk.Context, votingProcedure VotingProcedure) {
+	err := keeper.ps.Set(ctx, ParamStoreKeyVotingProcedure, votingProcedure)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (keeper Keeper) setTallyingProcedure(ctx sdk.Context, tallyingProcedure TallyingProcedure) {
+	err := keeper.ps.Set(ctx, ParamStoreKeyTallyingProcedure, tallyingProcedure)
+	if err != nil {
+		panic(err)
 	}
 }
 
-// Gets procedure from store. TODO: move to global param store and allow for updating of this
-func (keeper Keeper) GetVotingProcedure() VotingProcedure {
-	return VotingProcedure{
-		VotingPeriod: 200,
+// =====================================================
+// Param Changes
+
+// Checks that the changes could currently be applied to the param store,
+// each one validated after the previous ones as when they are applied
+func (keeper Keeper) ValidateParamChanges(ctx sdk.Context, changes []ParamChange) sdk.Error {
+	cacheCtx, _ := ctx.CacheContext()
+	for _, change := range changes {
+		err := keeper.ps.Change(cacheCtx, change.StoreKey(), []byte(change.Value))
+		if err != nil {
+			return ErrInvalidParamChange(keeper.codespace, err.Error())
+		}
 	}
+	return nil
 }
 
-// Gets procedure from store. TODO: move to global param store and allow for updating of this
-func (keeper Keeper) GetTallyingProcedure() TallyingProcedure {
-	return TallyingProcedure{
-		Threshold:         sdk.NewRat(1, 2),
-		Veto:              sdk.NewRat(1, 3),
-		GovernancePenalty: sdk.NewRat(1, 100),
+// Applies all the changes to the param store, or none of them if any fails
+func (keeper Keeper) ApplyParamChanges(ctx sdk.Context, changes []ParamChange) sdk.Error {
+	cacheCtx, write := ctx.CacheContext()
+	for _, change := range changes {
+		err := keeper.ps.Change(cacheCtx, change.StoreKey(), []byte(change.Value))
+		if err != nil {
+			return ErrInvalidParamChange(keeper.codespace, err.Error())
+		}
 	}
+	write()
+	return nil
+}
+
+// =====================================================
+// Software Upgrades
+
+// Schedules the upgrade plan of a passed SoftwareUpgrade proposal
+func (keeper Keeper) ScheduleUpgrade(ctx sdk.Context, plan upgrade.Plan) sdk.Error {
+	err := keeper.uk.ScheduleUpgrade(ctx, plan)
+	if err != nil {
+		return ErrInvalidUpgradePlan(keeper.codespace, err.Error())
+	}
+	return nil
 }
 
 // =====================================================
@@ -256,7 +399,7 @@ func (keeper Keeper) AddDeposit(ctx sdk.Context, proposalID int64, depositerAddr
 	// Check if deposit tipped proposal into voting period
 	// Active voting period if so
 	activatedVotingPeriod := false
-	if proposal.GetStatus() == StatusDepositPeriod && proposal.GetTotalDeposit().IsGTE(keeper.GetDepositProcedure().MinDeposit) {
+	if proposal.GetStatus() == StatusDepositPeriod && proposal.GetTotalDeposit().IsGTE(keeper.GetDepositProcedure(ctx).MinDeposit) {
 		keeper.activateVotingPeriod(ctx, proposal)
 		activatedVotingPeriod = true
 	}
@@ -13,6 +13,11 @@ var (
 	KeyNextProposalID        = []byte("newProposalID")
 	KeyActiveProposalQueue   = []byte("activeProposalQueue")
 	KeyInactiveProposalQueue = []byte("inactiveProposalQueue")
+
+	// Prefixes for iterating over all proposals, deposits and votes
+	KeyProposalsSubspace = []byte("proposals:")
+	KeyDepositsPrefix    = []byte("deposits:")
+	KeyVotesPrefix       = []byte("votes:")
 )
 
 // Key for getting a specific proposal from the store
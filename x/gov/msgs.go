@@ -1,9 +1,11 @@
 package gov
 
 import (
+	"encoding/json"
 	"fmt"
 
 	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/x/upgrade"
 )
 
 // name to idetify transaction types
@@ -12,11 +14,13 @@ const MsgType = "gov"
 //-----------------------------------------------------------
 // MsgSubmitProposal
 type MsgSubmitProposal struct {
-	Title          string       //  Title of the proposal
-	Description    string       //  Description of the proposal
-	ProposalType   ProposalKind //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Proposer       sdk.Address  //  Address of the proposer
-	InitialDeposit sdk.Coins    //  Initial deposit paid by sender. Must be strictly positive.
+	Title          string        //  Title of the proposal
+	Description    string        //  Description of the proposal
+	ProposalType   ProposalKind  //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Proposer       sdk.Address   //  Address of the proposer
+	InitialDeposit sdk.Coins     //  Initial deposit paid by sender. Must be strictly positive.
+	ParamChanges   []ParamChange //  Param store changes of a ParameterChange proposal
+	UpgradePlan    upgrade.Plan  //  Upgrade plan of a SoftwareUpgrade proposal
 }
 
 func NewMsgSubmitProposal(title string, description string, proposalType ProposalKind, proposer sdk.Address, initialDeposit sdk.Coins) MsgSubmitProposal {
@@ -29,6 +33,28 @@ func NewMsgSubmitProposal(title string, description string, proposalType Proposa
 	}
 }
 
+func NewMsgSubmitParamChangeProposal(title string, description string, changes []ParamChange, proposer sdk.Address, initialDeposit sdk.Coins) MsgSubmitProposal {
+	return MsgSubmitProposal{
+		Title:          title,
+		Description:    description,
+		ProposalType:   ProposalTypeParameterChange,
+		Proposer:       proposer,
+		InitialDeposit: initialDeposit,
+		ParamChanges:   changes,
+	}
+}
+
+func NewMsgSubmitSoftwareUpgradeProposal(title string, description string, plan upgrade.Plan, proposer sdk.Address, initialDeposit sdk.Coins) MsgSubmitProposal {
+	return MsgSubmitProposal{
+		Title:          title,
+		Description:    description,
+		ProposalType:   ProposalTypeSoftwareUpgrade,
+		Proposer:       proposer,
+		InitialDeposit: initialDeposit,
+		UpgradePlan:    plan,
+	}
+}
+
 // Implements Msg.
 func (msg MsgSubmitProposal) Type() string { return MsgType }
 
@@ -52,6 +78,28 @@ func (msg MsgSubmitProposal) ValidateBasic() sdk.Error {
 	if !msg.InitialDeposit.IsNotNegative() {
 		return sdk.ErrInvalidCoins(msg.InitialDeposit.String())
 	}
+	if msg.ProposalType == ProposalTypeParameterChange {
+		if len(msg.ParamChanges) == 0 {
+			return ErrInvalidParamChange(DefaultCodespace, "no changes")
+		}
+		for _, change := range msg.ParamChanges {
+			if len(change.Subspace) == 0 || len(change.Key) == 0 {
+				return ErrInvalidParamChange(DefaultCodespace, "empty subspace or key")
+			}
+			if !json.Valid([]byte(change.Value)) {
+				return ErrInvalidParamChange(DefaultCodespace, fmt.Sprintf("value of %s is not valid json", change.StoreKey()))
+			}
+		}
+	} else if len(msg.ParamChanges) != 0 {
+		return ErrInvalidParamChange(DefaultCodespace, "changes are only allowed for ParameterChange proposals")
+	}
+	if msg.ProposalType == ProposalTypeSoftwareUpgrade {
+		if err := msg.UpgradePlan.ValidateBasic(); err != nil {
+			return ErrInvalidUpgradePlan(DefaultCodespace, err.Error())
+		}
+	} else if !msg.UpgradePlan.IsEmpty() {
+		return ErrInvalidUpgradePlan(DefaultCodespace, "plans are only allowed for SoftwareUpgrade proposals")
+	}
 	return nil
 }
 
@@ -66,18 +114,26 @@ func (msg MsgSubmitProposal) Get(key interface{}) (value interface{}) {
 
 // Implements Msg.
 func (msg MsgSubmitProposal) GetSignBytes() []byte {
+	var upgradePlan *upgrade.Plan
+	if !msg.UpgradePlan.IsEmpty() {
+		upgradePlan = &msg.UpgradePlan
+	}
 	b, err := msgCdc.MarshalJSON(struct {
-		Title          string    `json:"title"`
-		Description    string    `json:"description"`
-		ProposalType   string    `json:"proposal_type"`
-		Proposer       string    `json:"proposer"`
-		InitialDeposit sdk.Coins `json:"deposit"`
+		Title          string        `json:"title"`
+		Description    string        `json:"description"`
+		ProposalType   string        `json:"proposal_type"`
+		Proposer       string        `json:"proposer"`
+		InitialDeposit sdk.Coins     `json:"deposit"`
+		ParamChanges   []ParamChange `json:"param_changes,omitempty"`
+		UpgradePlan    *upgrade.Plan `json:"upgrade_plan,omitempty"`
 	}{
 		Title:          msg.Title,
 		Description:    msg.Description,
 		ProposalType:   ProposalTypeToString(msg.ProposalType),
 		Proposer:       sdk.MustBech32ifyVal(msg.Proposer),
 		InitialDeposit: msg.InitialDeposit,
+		ParamChanges:   msg.ParamChanges,
+		UpgradePlan:    upgradePlan,
 	})
 	if err != nil {
 		panic(err)
@@ -7,6 +7,7 @@ import (
 
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	"github.com/tepleton/tepleton-sdk/x/auth/mock"
+	"github.com/tepleton/tepleton-sdk/x/upgrade"
 )
 
 var (
@@ -30,8 +31,8 @@ func TestMsgSubmitProposal(t *testing.T) {
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, true},
 		{"", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, false},
 		{"Test Proposal", "", ProposalTypeText, addrs[0], coinsPos, false},
-		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeParameterChange, addrs[0], coinsPos, true},
-		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeSoftwareUpgrade, addrs[0], coinsPos, true},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeParameterChange, addrs[0], coinsPos, false},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeSoftwareUpgrade, addrs[0], coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", 0x05, addrs[0], coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, sdk.Address{}, coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsZero, true},
@@ -49,6 +50,65 @@ func TestMsgSubmitProposal(t *testing.T) {
 	}
 }
 
+// test ValidateBasic for MsgSubmitProposal carrying param changes
+func TestMsgSubmitParamChangeProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	tests := []struct {
+		changes    []ParamChange
+		expectPass bool
+	}{
+		{[]ParamChange{{"gov", "votingprocedure", `{"voting_period":"100"}`}}, true},
+		{[]ParamChange{{"gov", "votingprocedure", `{"voting_period":"100"}`}, {"stake", "params", `{}`}}, true},
+		{nil, false},
+		{[]ParamChange{{"", "votingprocedure", `{"voting_period":"100"}`}}, false},
+		{[]ParamChange{{"gov", "", `{"voting_period":"100"}`}}, false},
+		{[]ParamChange{{"gov", "votingprocedure", `{"voting_period":`}}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitParamChangeProposal("Test Proposal", "the purpose of this proposal is to test", tc.changes, addrs[0], coinsPos)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+
+	// changes are only allowed on parameter change proposals
+	msg := NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos)
+	msg.ParamChanges = tests[0].changes
+	require.NotNil(t, msg.ValidateBasic())
+}
+
+// test ValidateBasic for MsgSubmitProposal carrying an upgrade plan
+func TestMsgSubmitSoftwareUpgradeProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	tests := []struct {
+		plan       upgrade.Plan
+		expectPass bool
+	}{
+		{upgrade.Plan{Name: "v2", Height: 100, Info: "binary at https://example.com/v2"}, true},
+		{upgrade.Plan{Name: "v2", Height: 100}, true},
+		{upgrade.Plan{Name: "", Height: 100}, false},
+		{upgrade.Plan{Name: "v2", Height: 0}, false},
+		{upgrade.Plan{Name: "v2", Height: -1}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitSoftwareUpgradeProposal("Test Proposal", "the purpose of this proposal is to test", tc.plan, addrs[0], coinsPos)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+
+	// plans are only allowed on software upgrade proposals
+	msg := NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos)
+	msg.UpgradePlan = tests[0].plan
+	require.NotNil(t, msg.ValidateBasic())
+}
+
 // test ValidateBasic for MsgDeposit
 func TestMsgDeposit(t *testing.T) {
 	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
@@ -1,7 +1,10 @@
 package gov
 
 import (
+	"fmt"
+
 	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/x/params"
 )
 
 // Procedure around Deposits for governance
@@ -12,6 +15,7 @@ type DepositProcedure struct {
 
 // Procedure around Tallying votes in governance
 type TallyingProcedure struct {
+	Quorum            sdk.Rat `json:"quorum"`             //  Minimum proportion of the total bonded power which must vote for a result to be valid. Initial value: 1/3
 	Threshold         sdk.Rat `json:"threshold"`          //  Minimum propotion of Yes votes for proposal to pass. Initial value: 0.5
 	Veto              sdk.Rat `json:"veto"`               //  Minimum value of Veto votes to Total votes ratio for proposal to be vetoed. Initial value: 1/3
 	GovernancePenalty sdk.Rat `json:"governance_penalty"` //  Penalty if validator does not vote
@@ -21,3 +25,53 @@ type TallyingProcedure struct {
 type VotingProcedure struct {
 	VotingPeriod int64 `json:"voting_period"` //  Length of the voting period.
 }
+
+// validators of the procedures changed in the param store
+func validateDepositProcedure(ctx sdk.Context, value interface{}) error {
+	depositProcedure := value.(DepositProcedure)
+	if !depositProcedure.MinDeposit.IsValid() || !depositProcedure.MinDeposit.IsNotNegative() {
+		return fmt.Errorf("invalid min deposit %v", depositProcedure.MinDeposit)
+	}
+	return params.PositiveInt64(ctx, depositProcedure.MaxDepositPeriod)
+}
+
+func validateVotingProcedure(ctx sdk.Context, value interface{}) error {
+	return params.PositiveInt64(ctx, value.(VotingProcedure).VotingPeriod)
+}
+
+func validateTallyingProcedure(ctx sdk.Context, value interface{}) error {
+	tallyingProcedure := value.(TallyingProcedure)
+	zero, one := sdk.ZeroRat(), sdk.OneRat()
+	for _, rat := range []sdk.Rat{tallyingProcedure.Quorum, tallyingProcedure.Threshold,
+		tallyingProcedure.Veto, tallyingProcedure.GovernancePenalty} {
+		if err := params.CheckRatBetween(rat, zero, one); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Default procedures set at genesis
+func DefaultDepositProcedure() DepositProcedure {
+	return DepositProcedure{
+		MinDeposit:       sdk.Coins{sdk.NewCoin("steak", 10)},
+		MaxDepositPeriod: 200,
+	}
+}
+
+// nolint
+func DefaultVotingProcedure() VotingProcedure {
+	return VotingProcedure{
+		VotingPeriod: 200,
+	}
+}
+
+// nolint
+func DefaultTallyingProcedure() TallyingProcedure {
+	return TallyingProcedure{
+		Quorum:            sdk.NewRat(1, 3),
+		Threshold:         sdk.NewRat(1, 2),
+		Veto:              sdk.NewRat(1, 3),
+		GovernancePenalty: sdk.NewRat(1, 100),
+	}
+}
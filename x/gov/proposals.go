@@ -2,6 +2,8 @@ package gov
 
 import (
 	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/x/params"
+	"github.com/tepleton/tepleton-sdk/x/upgrade"
 )
 
 // Type that represents Status as a byte
@@ -104,6 +106,44 @@ func (tp *TextProposal) SetVotingStartBlock(votingStartBlock int64) {
 	tp.VotingStartBlock = votingStartBlock
 }
 
+//-----------------------------------------------------------
+// Parameter Change Proposals
+
+// ParamChange is a single change to the global param store
+type ParamChange struct {
+	Subspace string `json:"subspace"` //  Module subspace of the param, e.g. "stake"
+	Key      string `json:"key"`      //  Key of the param within the subspace
+	Value    string `json:"value"`    //  New json encoded value of the param
+}
+
+// Store key of the changed param within the global param store
+func (pc ParamChange) StoreKey() string {
+	return params.ComposeKey(pc.Subspace, pc.Key)
+}
+
+// ParameterChangeProposal is a TextProposal which, once passed,
+// applies a set of changes to the global param store
+type ParameterChangeProposal struct {
+	TextProposal
+	Changes []ParamChange `json:"changes"` //  Changes applied atomically when the proposal passes
+}
+
+// Implements Proposal Interface
+var _ Proposal = (*ParameterChangeProposal)(nil)
+
+//-----------------------------------------------------------
+// Software Upgrade Proposals
+
+// SoftwareUpgradeProposal is a TextProposal which, once passed,
+// schedules an upgrade plan with the upgrade keeper
+type SoftwareUpgradeProposal struct {
+	TextProposal
+	Plan upgrade.Plan `json:"plan"` //  Upgrade plan scheduled when the proposal passes
+}
+
+// Implements Proposal Interface
+var _ Proposal = (*SoftwareUpgradeProposal)(nil)
+
 // Current Active Proposals
 type ProposalQueue []int64
 
@@ -187,11 +227,14 @@ type ProposalRest struct {
 	SubmitBlock      int64     `json:"submit_block"`       //  Height of the block where TxGovSubmitProposal was included
 	TotalDeposit     sdk.Coins `json:"total_deposit"`      //  Current deposit on this proposal. Initial value is set at InitialDeposit
 	VotingStartBlock int64     `json:"voting_start_block"` //  Height of the block where MinDeposit was reached. -1 if MinDeposit is not reached
+
+	ParamChanges []ParamChange `json:"param_changes,omitempty"` //  Changes of a ParameterChange proposal
+	UpgradePlan  *upgrade.Plan `json:"upgrade_plan,omitempty"`  //  Plan of a SoftwareUpgrade proposal
 }
 
 // Turn any Proposal to a ProposalRest
 func ProposalToRest(proposal Proposal) ProposalRest {
-	return ProposalRest{
+	proposalRest := ProposalRest{
 		ProposalID:       proposal.GetProposalID(),
 		Title:            proposal.GetTitle(),
 		Description:      proposal.GetDescription(),
@@ -201,4 +244,11 @@ func ProposalToRest(proposal Proposal) ProposalRest {
 		TotalDeposit:     proposal.GetTotalDeposit(),
 		VotingStartBlock: proposal.GetVotingStartBlock(),
 	}
+	if paramChangeProposal, ok := proposal.(*ParameterChangeProposal); ok {
+		proposalRest.ParamChanges = paramChangeProposal.Changes
+	}
+	if upgradeProposal, ok := proposal.(*SoftwareUpgradeProposal); ok {
+		proposalRest.UpgradePlan = &upgradeProposal.Plan
+	}
+	return proposalRest
 }
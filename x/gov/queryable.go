@@ -0,0 +1,105 @@
+package gov
+
+import (
+	"fmt"
+
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// query endpoints supported by the gov querier
+const (
+	QueryProposal = "proposal"
+	QueryDeposits = "deposits"
+	QueryVotes    = "votes"
+	QueryTally    = "tally"
+)
+
+// QueryProposalParams - params of the queries about a proposal, as JSON data
+type QueryProposalParams struct {
+	ProposalID int64 `json:"proposal_id"`
+}
+
+// NewQuerier answers the custom gov queries, with JSON responses
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req wrsp.RequestQuery) (res []byte, err sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no gov query endpoint")
+		}
+		switch path[0] {
+		case QueryProposal:
+			return queryProposal(ctx, keeper, req)
+		case QueryDeposits:
+			return queryDeposits(ctx, keeper, req)
+		case QueryVotes:
+			return queryVotes(ctx, keeper, req)
+		case QueryTally:
+			return queryTally(ctx, keeper, req)
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown gov query endpoint %s", path[0]))
+		}
+	}
+}
+
+// get the proposal named in the query params
+func getQueryProposal(ctx sdk.Context, keeper Keeper, req wrsp.RequestQuery) (Proposal, sdk.Error) {
+	var params QueryProposalParams
+	if err := sdk.UnmarshalQueryParams(keeper.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+	proposal := keeper.GetProposal(ctx, params.ProposalID)
+	if proposal == nil {
+		return nil, ErrUnknownProposal(keeper.codespace, params.ProposalID)
+	}
+	return proposal, nil
+}
+
+func queryProposal(ctx sdk.Context, keeper Keeper, req wrsp.RequestQuery) ([]byte, sdk.Error) {
+	proposal, err := getQueryProposal(ctx, keeper, req)
+	if err != nil {
+		return nil, err
+	}
+	return sdk.MarshalQueryResult(keeper.cdc, proposal)
+}
+
+func queryDeposits(ctx sdk.Context, keeper Keeper, req wrsp.RequestQuery) ([]byte, sdk.Error) {
+	proposal, err := getQueryProposal(ctx, keeper, req)
+	if err != nil {
+		return nil, err
+	}
+	deposits := []Deposit{}
+	depositsIterator := keeper.GetDeposits(ctx, proposal.GetProposalID())
+	for ; depositsIterator.Valid(); depositsIterator.Next() {
+		var deposit Deposit
+		keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), &deposit)
+		deposits = append(deposits, deposit)
+	}
+	depositsIterator.Close()
+	return sdk.MarshalQueryResult(keeper.cdc, deposits)
+}
+
+func queryVotes(ctx sdk.Context, keeper Keeper, req wrsp.RequestQuery) ([]byte, sdk.Error) {
+	proposal, err := getQueryProposal(ctx, keeper, req)
+	if err != nil {
+		return nil, err
+	}
+	votes := []Vote{}
+	votesIterator := keeper.GetVotes(ctx, proposal.GetProposalID())
+	for ; votesIterator.Valid(); votesIterator.Next() {
+		var vote Vote
+		keeper.cdc.MustUnmarshalBinary(votesIterator.Value(), &vote)
+		votes = append(votes, vote)
+	}
+	votesIterator.Close()
+	return sdk.MarshalQueryResult(keeper.cdc, votes)
+}
+
+func queryTally(ctx sdk.Context, keeper Keeper, req wrsp.RequestQuery) ([]byte, sdk.Error) {
+	proposal, err := getQueryProposal(ctx, keeper, req)
+	if err != nil {
+		return nil, err
+	}
+	tallyResult, _, _ := tallyVotes(ctx, keeper, proposal)
+	return sdk.MarshalQueryResult(keeper.cdc, tallyResult)
+}
@@ -0,0 +1,75 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+	"github.com/tepleton/tepleton/crypto"
+
+	"github.com/tepleton/tepleton-sdk/x/stake"
+)
+
+func TestQuerier(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(wrsp.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, wrsp.Header{})
+	stakeHandler := stake.NewHandler(sk)
+	querier := NewQuerier(keeper)
+
+	dummyDescription := stake.NewDescription("T", "E", "S", "T")
+	dummyCommission := stake.NewCommissionMsg(sdk.ZeroRat(), sdk.ZeroRat(), sdk.ZeroRat())
+	val1CreateMsg := stake.NewMsgCreateValidator(addrs[0], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 5), dummyDescription, dummyCommission, 0)
+	stakeHandler(ctx, val1CreateMsg)
+	val2CreateMsg := stake.NewMsgCreateValidator(addrs[1], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 7), dummyDescription, dummyCommission, 0)
+	stakeHandler(ctx, val2CreateMsg)
+
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
+	proposalID := proposal.GetProposalID()
+	proposal.SetStatus(StatusVotingPeriod)
+	keeper.SetProposal(ctx, proposal)
+	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionYes)
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], OptionNo)
+	require.Nil(t, err)
+
+	data, jsonErr := keeper.cdc.MarshalJSON(QueryProposalParams{ProposalID: proposalID})
+	require.Nil(t, jsonErr)
+	req := wrsp.RequestQuery{Data: data}
+
+	// the tally in progress doesn't remove the votes
+	res, queryErr := querier(ctx, []string{QueryTally}, req)
+	require.Nil(t, queryErr)
+	var tallyResult TallyResult
+	require.Nil(t, keeper.cdc.UnmarshalJSON(res, &tallyResult))
+	require.True(t, tallyResult.Yes.Equal(sdk.NewRat(5)))
+	require.True(t, tallyResult.No.Equal(sdk.NewRat(7)))
+	require.True(t, tallyResult.NoWithVeto.IsZero())
+
+	res, queryErr = querier(ctx, []string{QueryVotes}, req)
+	require.Nil(t, queryErr)
+	var votes []Vote
+	require.Nil(t, keeper.cdc.UnmarshalJSON(res, &votes))
+	require.Len(t, votes, 2)
+
+	res, queryErr = querier(ctx, []string{QueryProposal}, req)
+	require.Nil(t, queryErr)
+	var queried Proposal
+	require.Nil(t, keeper.cdc.UnmarshalJSON(res, &queried))
+	require.Equal(t, proposalID, queried.GetProposalID())
+
+	// unknown proposal or endpoint
+	data, jsonErr = keeper.cdc.MarshalJSON(QueryProposalParams{ProposalID: 100})
+	require.Nil(t, jsonErr)
+	_, queryErr = querier(ctx, []string{QueryTally}, wrsp.RequestQuery{Data: data})
+	require.Equal(t, CodeUnknownProposal, queryErr.Code())
+	_, queryErr = querier(ctx, []string{"other"}, req)
+	require.NotNil(t, queryErr)
+
+	// the endpoint is checked before the params
+	_, queryErr = querier(ctx, []string{"other"}, wrsp.RequestQuery{})
+	require.Equal(t, sdk.CodeUnknownRequest, queryErr.Code())
+	require.Contains(t, queryErr.Error(), "unknown gov query endpoint")
+}
@@ -13,14 +13,64 @@ type validatorGovInfo struct {
 	Vote            VoteOption  // Vote of the validator
 }
 
+// TallyResult - voting power for each option of a proposal
+type TallyResult struct {
+	Yes        sdk.Rat `json:"yes"`
+	Abstain    sdk.Rat `json:"abstain"`
+	No         sdk.Rat `json:"no"`
+	NoWithVeto sdk.Rat `json:"no_with_veto"`
+}
+
+// tally the votes of a proposal and remove them, returning whether it passes
 func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, nonVoting []sdk.Address) {
+	results, totalVotingPower, nonVoting := tallyVotes(ctx, keeper, proposal)
+
+	// the votes are not needed anymore
+	var voters []sdk.Address
+	votesIterator := keeper.GetVotes(ctx, proposal.GetProposalID())
+	for ; votesIterator.Valid(); votesIterator.Next() {
+		vote := &Vote{}
+		keeper.cdc.MustUnmarshalBinary(votesIterator.Value(), vote)
+		voters = append(voters, vote.Voter)
+	}
+	votesIterator.Close()
+	for _, voter := range voters {
+		keeper.deleteVote(ctx, proposal.GetProposalID(), voter)
+	}
+
+	tallyingProcedure := keeper.GetTallyingProcedure(ctx)
+
+	// If there is not enough quorum of votes, proposal fails
+	totalBondedPower := keeper.vs.TotalPower(ctx)
+	if totalBondedPower.Equal(sdk.ZeroRat()) || totalVotingPower.Quo(totalBondedPower).LT(tallyingProcedure.Quorum) {
+		return false, nonVoting
+	}
+	// If no one votes, proposal fails
+	if totalVotingPower.Sub(results.Abstain).Equal(sdk.ZeroRat()) {
+		return false, nonVoting
+	}
+	// If more than 1/3 of voters veto, proposal fails
+	if results.NoWithVeto.Quo(totalVotingPower).GT(tallyingProcedure.Veto) {
+		return false, nonVoting
+	}
+	// If more than 1/2 of non-abstaining voters vote Yes, proposal passes
+	if results.Yes.Quo(totalVotingPower.Sub(results.Abstain)).GT(tallyingProcedure.Threshold) {
+		return true, nonVoting
+	}
+	// If more than 1/2 of non-abstaining voters vote No, proposal fails
+	return false, nonVoting
+}
+
+// tally the voting power of each option of a proposal with the current
+// validator set, without changing the state
+func tallyVotes(ctx sdk.Context, keeper Keeper, proposal Proposal) (tallyResult TallyResult, totalVotingPower sdk.Rat, nonVoting []sdk.Address) {
 	results := make(map[VoteOption]sdk.Rat)
 	results[OptionYes] = sdk.ZeroRat()
 	results[OptionAbstain] = sdk.ZeroRat()
 	results[OptionNo] = sdk.ZeroRat()
 	results[OptionNoWithVeto] = sdk.ZeroRat()
 
-	totalVotingPower := sdk.ZeroRat()
+	totalVotingPower = sdk.ZeroRat()
 	currValidators := make(map[string]validatorGovInfo)
 
 	keeper.vs.IterateValidatorsBonded(ctx, func(index int64, validator sdk.Validator) (stop bool) {
@@ -61,8 +111,6 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, nonV
 				return false
 			})
 		}
-
-		keeper.deleteVote(ctx, vote.ProposalID, vote.Voter)
 	}
 	votesIterator.Close()
 
@@ -81,20 +129,11 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, nonV
 		totalVotingPower = totalVotingPower.Add(votingPower)
 	}
 
-	tallyingProcedure := keeper.GetTallyingProcedure()
-
-	// If no one votes, proposal fails
-	if totalVotingPower.Sub(results[OptionAbstain]).Equal(sdk.ZeroRat()) {
-		return false, nonVoting
+	tallyResult = TallyResult{
+		Yes:        results[OptionYes],
+		Abstain:    results[OptionAbstain],
+		No:         results[OptionNo],
+		NoWithVeto: results[OptionNoWithVeto],
 	}
-	// If more than 1/3 of voters veto, proposal fails
-	if results[OptionNoWithVeto].Quo(totalVotingPower).GT(tallyingProcedure.Veto) {
-		return false, nonVoting
-	}
-	// If more than 1/2 of non-abstaining voters vote Yes, proposal passes
-	if results[OptionYes].Quo(totalVotingPower.Sub(results[OptionAbstain])).GT(tallyingProcedure.Threshold) {
-		return true, nonVoting
-	}
-	// If more than 1/2 of non-abstaining voters vote No, proposal fails
-	return false, nonVoting
+	return tallyResult, totalVotingPower, nonVoting
 }
@@ -14,7 +14,9 @@ import (
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	"github.com/tepleton/tepleton-sdk/x/auth/mock"
 	"github.com/tepleton/tepleton-sdk/x/bank"
+	"github.com/tepleton/tepleton-sdk/x/params"
 	"github.com/tepleton/tepleton-sdk/x/stake"
+	"github.com/tepleton/tepleton-sdk/x/upgrade"
 )
 
 // initialize the mock application for this module
@@ -26,13 +28,17 @@ func getMockApp(t *testing.T, numGenAccs int64) (*mock.App, Keeper, stake.Keeper
 
 	keyStake := sdk.NewKVStoreKey("stake")
 	keyGov := sdk.NewKVStoreKey("gov")
+	keyParams := sdk.NewKVStoreKey("params")
+	keyUpgrade := sdk.NewKVStoreKey("upgrade")
 
+	pk := params.NewKeeper(mapp.Cdc, keyParams)
 	ck := bank.NewKeeper(mapp.AccountMapper)
-	sk := stake.NewKeeper(mapp.Cdc, keyStake, ck, mapp.RegisterCodespace(stake.DefaultCodespace))
-	keeper := NewKeeper(mapp.Cdc, keyGov, ck, sk, DefaultCodespace)
+	sk := stake.NewKeeper(mapp.Cdc, keyStake, ck, pk.Setter(), mapp.RegisterCodespace(stake.DefaultCodespace))
+	uk := upgrade.NewKeeper(mapp.Cdc, keyUpgrade)
+	keeper := NewKeeper(mapp.Cdc, keyGov, pk.Setter(), ck, sk, uk, DefaultCodespace)
 	mapp.Router().AddRoute("gov", NewHandler(keeper))
 
-	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{keyStake, keyGov}))
+	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{keyStake, keyGov, keyParams, keyUpgrade}))
 
 	mapp.SetEndBlocker(getEndBlocker(keeper))
 	mapp.SetInitChainer(getInitChainer(mapp, keeper, sk))
@@ -46,7 +52,7 @@ func getMockApp(t *testing.T, numGenAccs int64) (*mock.App, Keeper, stake.Keeper
 // gov and stake endblocker
 func getEndBlocker(keeper Keeper) sdk.EndBlocker {
 	return func(ctx sdk.Context, req wrsp.RequestEndBlock) wrsp.ResponseEndBlock {
-		tags, _ := EndBlocker(ctx, keeper)
+		tags := EndBlocker(ctx, keeper)
 		return wrsp.ResponseEndBlock{
 			Tags: tags,
 		}
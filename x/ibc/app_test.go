@@ -22,7 +22,8 @@ func getMockApp(t *testing.T) *mock.App {
 	keyIBC := sdk.NewKVStoreKey("ibc")
 	ibcMapper := NewMapper(mapp.Cdc, keyIBC, mapp.RegisterCodespace(DefaultCodespace))
 	coinKeeper := bank.NewKeeper(mapp.AccountMapper)
-	mapp.Router().AddRoute("ibc", NewHandler(ibcMapper, coinKeeper))
+	ibcRouter := NewRouter().AddRoute(TransferRoute, NewTransferHandler(coinKeeper))
+	mapp.Router().AddRoute("ibc", NewHandler(ibcMapper, coinKeeper, ibcRouter))
 
 	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{keyIBC}))
 	return mapp
@@ -31,7 +32,8 @@ func getMockApp(t *testing.T) *mock.App {
 func TestIBCMsgs(t *testing.T) {
 	mapp := getMockApp(t)
 
-	sourceChain := "source-chain"
+	// the mock app runs with an empty chain-id
+	sourceChain := ""
 	destChain := "dest-chain"
 
 	priv1 := crypto.GenPrivKeyEd25519()
@@ -53,9 +55,7 @@ func TestIBCMsgs(t *testing.T) {
 	require.Equal(t, acc, res1)
 
 	packet := IBCPacket{
-		SrcAddr:   addr1,
-		DestAddr:  addr1,
-		Coins:     coins,
+		Payload:   TransferPayload{addr1, addr1, coins},
 		SrcChain:  sourceChain,
 		DestChain: destChain,
 	}
@@ -68,12 +68,15 @@ func TestIBCMsgs(t *testing.T) {
 		IBCPacket: packet,
 		Relayer:   addr1,
 		Sequence:  0,
+		Proof:     []byte("proof"),
+		Height:    1,
 	}
 
 	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{transferMsg}, []int64{0}, []int64{0}, true, priv1)
 	mock.CheckBalance(t, mapp, addr1, emptyCoins)
 	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{transferMsg}, []int64{0}, []int64{1}, false, priv1)
-	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{receiveMsg}, []int64{0}, []int64{2}, true, priv1)
-	mock.CheckBalance(t, mapp, addr1, coins)
+
+	// without a proof against a registered source chain the relayer cannot mint coins
 	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{receiveMsg}, []int64{0}, []int64{2}, false, priv1)
+	mock.CheckBalance(t, mapp, addr1, emptyCoins)
 }
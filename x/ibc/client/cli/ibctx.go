@@ -18,9 +18,10 @@ import (
 )
 
 const (
-	flagTo     = "to"
-	flagAmount = "amount"
-	flagChain  = "chain"
+	flagTo      = "to"
+	flagAmount  = "amount"
+	flagChain   = "chain"
+	flagTimeout = "timeout"
 )
 
 // IBC transfer command
@@ -56,6 +57,7 @@ func IBCTransferCmd(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().String(flagTo, "", "Address to send coins")
 	cmd.Flags().String(flagAmount, "", "Amount of coins to send")
 	cmd.Flags().String(flagChain, "", "Destination chain to send coins")
+	cmd.Flags().Int64(flagTimeout, 0, "Height of the destination chain from which the transfer can be refunded instead (0 for no timeout)")
 	return cmd
 }
 
@@ -73,8 +75,13 @@ func buildMsg(from sdk.Address) (sdk.Msg, error) {
 	}
 	to := sdk.Address(bz)
 
-	packet := ibc.NewIBCPacket(from, to, coins, viper.GetString(client.FlagChainID),
-		viper.GetString(flagChain))
+	payload := ibc.TransferPayload{
+		SrcAddr:  from,
+		DestAddr: to,
+		Coins:    coins,
+	}
+	packet := ibc.NewIBCPacket(payload, viper.GetString(client.FlagChainID),
+		viper.GetString(flagChain), viper.GetInt64(flagTimeout))
 
 	msg := ibc.IBCTransferMsg{
 		IBCPacket: packet,
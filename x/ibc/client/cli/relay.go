@@ -1,13 +1,20 @@
 package cli
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/tepleton/tepleton/libs/cli"
 	"github.com/tepleton/tepleton/libs/log"
+	tmtypes "github.com/tepleton/tepleton/types"
 
 	"github.com/tepleton/tepleton-sdk/client/context"
 	sdk "github.com/tepleton/tepleton-sdk/types"
@@ -23,8 +30,30 @@ const (
 	FlagFromChainNode = "from-chain-node"
 	FlagToChainID     = "to-chain-id"
 	FlagToChainNode   = "to-chain-node"
+	FlagBatchSize     = "batch-size"
+	FlagProgressFile  = "progress-file"
 )
 
+// relayChain is the view the relayer has of one side of the channel. All
+// queries are made against the committed state at the given height.
+type relayChain interface {
+	ChainID() string
+	LatestHeight() (int64, error)
+	Query(key []byte, storeName string, height int64) ([]byte, error)
+	QueryWithProof(key []byte, storeName string, height int64) (res []byte, proof []byte, err error)
+	FullCommit(height int64) (ibc.FullCommit, error)
+	BroadcastTx(tx []byte) error
+}
+
+// relayProgress is persisted after every relayed batch so a restarted
+// relayer resumes from the last packet it relayed.
+type relayProgress struct {
+	FromChainID string `json:"from_chain_id"`
+	ToChainID   string `json:"to_chain_id"`
+	Sequence    int64  `json:"sequence"` // next packet to relay
+	Height      int64  `json:"height"`   // source chain height of the last relayed proofs
+}
+
 type relayCommander struct {
 	cdc       *wire.Codec
 	address   sdk.Address
@@ -33,6 +62,14 @@ type relayCommander struct {
 	ibcStore  string
 	accStore  string
 
+	from         relayChain
+	to           relayChain
+	ctx          context.CoreContext // signs the transactions for the destination chain
+	passphrase   string
+	batchSize    int
+	progressFile string
+	progress     relayProgress
+
 	logger log.Logger
 }
 
@@ -49,14 +86,17 @@ func IBCRelayCmd(cdc *wire.Codec) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use: "relay",
-		Run: cmdr.runIBCRelay,
+		Use:   "relay",
+		Short: "Relay the outgoing IBC packets of one chain to another",
+		RunE:  cmdr.runIBCRelay,
 	}
 
 	cmd.Flags().String(FlagFromChainID, "", "Chain ID for ibc node to check outgoing packets")
 	cmd.Flags().String(FlagFromChainNode, "tcp://localhost:26657", "<host>:<port> to tepleton rpc interface for this chain")
 	cmd.Flags().String(FlagToChainID, "", "Chain ID for ibc node to broadcast incoming packets")
 	cmd.Flags().String(FlagToChainNode, "tcp://localhost:36657", "<host>:<port> to tepleton rpc interface for this chain")
+	cmd.Flags().Int(FlagBatchSize, 10, "Maximum number of packets relayed in one transaction")
+	cmd.Flags().String(FlagProgressFile, "", "File to persist the relayed packets in, defaults to <home>/relay/<from-chain-id>_<to-chain-id>.json")
 
 	cmd.MarkFlagRequired(FlagFromChainID)
 	cmd.MarkFlagRequired(FlagFromChainNode)
@@ -67,132 +107,329 @@ func IBCRelayCmd(cdc *wire.Codec) *cobra.Command {
 	viper.BindPFlag(FlagFromChainNode, cmd.Flags().Lookup(FlagFromChainNode))
 	viper.BindPFlag(FlagToChainID, cmd.Flags().Lookup(FlagToChainID))
 	viper.BindPFlag(FlagToChainNode, cmd.Flags().Lookup(FlagToChainNode))
+	viper.BindPFlag(FlagBatchSize, cmd.Flags().Lookup(FlagBatchSize))
+	viper.BindPFlag(FlagProgressFile, cmd.Flags().Lookup(FlagProgressFile))
 
 	return cmd
 }
 
 // nolint: unparam
-func (c relayCommander) runIBCRelay(cmd *cobra.Command, args []string) {
+func (c relayCommander) runIBCRelay(cmd *cobra.Command, args []string) error {
 	fromChainID := viper.GetString(FlagFromChainID)
 	fromChainNode := viper.GetString(FlagFromChainNode)
 	toChainID := viper.GetString(FlagToChainID)
 	toChainNode := viper.GetString(FlagToChainNode)
-	address, err := context.NewCoreContextFromViper().GetFromAddress()
+
+	ctx := context.NewCoreContextFromViper().WithChainID(toChainID)
+	address, err := ctx.GetFromAddress()
 	if err != nil {
-		panic(err)
+		return err
 	}
+	passphrase, err := ctx.GetPassphraseFromStdin(ctx.FromAddressName)
+	if err != nil {
+		return err
+	}
+
+	progressFile := viper.GetString(FlagProgressFile)
+	if progressFile == "" {
+		progressFile = filepath.Join(viper.GetString(cli.HomeFlag), "relay",
+			fmt.Sprintf("%s_%s.json", fromChainID, toChainID))
+	}
+
 	c.address = address
+	c.from = newNodeChain(c.cdc, fromChainID, fromChainNode)
+	c.to = newNodeChain(c.cdc, toChainID, toChainNode)
+	c.ctx = ctx
+	c.passphrase = passphrase
+	c.batchSize = viper.GetInt(FlagBatchSize)
+	c.progressFile = progressFile
+	c.progress, err = loadProgress(progressFile, fromChainID, toChainID)
+	if err != nil {
+		return err
+	}
 
-	c.loop(fromChainID, fromChainNode, toChainID, toChainNode)
+	c.loop()
+	return nil
 }
 
-func (c relayCommander) loop(fromChainID, fromChainNode, toChainID,
-	toChainNode string) {
+func (c *relayCommander) loop() {
+	for {
+		relayed, err := c.relay()
+		if err != nil {
+			c.logger.Error("error relaying IBC packets", "err", err)
+		}
+		if relayed == 0 {
+			time.Sleep(5 * time.Second)
+		}
+	}
+}
 
-	ctx := context.NewCoreContextFromViper()
-	// get password
-	passphrase, err := ctx.GetPassphraseFromStdin(ctx.FromAddressName)
+// relay submits the next batch of outgoing packets of the source chain to the
+// destination chain, together with the source chain commit they are proven
+// against, and returns the number of packets relayed.
+func (c *relayCommander) relay() (int, error) {
+	fromChainID, toChainID := c.from.ChainID(), c.to.ChainID()
+
+	toHeight, err := c.to.LatestHeight()
 	if err != nil {
-		panic(err)
+		return 0, errors.Wrap(err, "error querying destination height")
+	}
+	processed, err := c.queryInt64(c.to, ibc.IngressSequenceKey(fromChainID), toHeight)
+	if err != nil {
+		return 0, errors.Wrap(err, "error querying ingress sequence")
+	}
+	// resume after the last relayed packet, unless the destination chain
+	// already received more packets from another relayer
+	start := c.progress.Sequence
+	if processed > start {
+		c.logger.Info("Resuming from destination ingress sequence",
+			"persisted", c.progress.Sequence, "sequence", processed)
+		start = processed
 	}
 
-	ingressKey := ibc.IngressSequenceKey(fromChainID)
+	// packets are proven at the height before the latest block, whose app
+	// hash is committed in the latest header
+	height, err := c.from.LatestHeight()
+	if err != nil {
+		return 0, errors.Wrap(err, "error querying source height")
+	}
+	proofHeight := height - 1
 
-OUTER:
-	for {
-		time.Sleep(5 * time.Second)
+	sequences, err := c.pendingPackets(proofHeight, start)
+	if err != nil {
+		return 0, err
+	}
+	if len(sequences) == 0 {
+		return 0, nil
+	}
 
-		processedbz, err := query(toChainNode, ingressKey, c.ibcStore)
+	msgs := []sdk.Msg{}
+	stored, err := c.to.Query(ibc.CommitKey(fromChainID, height), c.ibcStore, toHeight)
+	if err != nil {
+		return 0, errors.Wrap(err, "error querying stored commit")
+	}
+	if stored == nil {
+		msg, err := c.commitMsg(height, toHeight)
 		if err != nil {
-			panic(err)
-		}
-
-		var processed int64
-		if processedbz == nil {
-			processed = 0
-		} else if err = c.cdc.UnmarshalBinary(processedbz, &processed); err != nil {
-			panic(err)
+			return 0, err
 		}
+		msgs = append(msgs, msg)
+	}
 
-		lengthKey := ibc.EgressLengthKey(toChainID)
-		egressLengthbz, err := query(fromChainNode, lengthKey, c.ibcStore)
+	relayed := 0
+	for _, seq := range sequences {
+		bz, proof, err := c.from.QueryWithProof(ibc.EgressKey(toChainID, seq), c.ibcStore, proofHeight)
 		if err != nil {
-			c.logger.Error("error querying outgoing packet list length", "err", err)
-			continue OUTER //TODO replace with continue (I think it should just to the correct place where OUTER is now)
+			return 0, errors.Wrap(err, "error querying egress packet")
 		}
-		var egressLength int64
-		if egressLengthbz == nil {
-			egressLength = 0
-		} else if err = c.cdc.UnmarshalBinary(egressLengthbz, &egressLength); err != nil {
-			panic(err)
+		if bz == nil {
+			// not committed at the proof height yet
+			break
 		}
-		if egressLength > processed {
-			c.logger.Info("Detected IBC packet", "number", egressLength-1)
+		var packet ibc.IBCPacket
+		if err = c.cdc.UnmarshalBinary(bz, &packet); err != nil {
+			return 0, err
 		}
+		msgs = append(msgs, ibc.IBCReceiveMsg{
+			IBCPacket: packet,
+			Relayer:   c.address,
+			Sequence:  seq,
+			Proof:     proof,
+			Height:    height,
+		})
+		relayed++
+	}
+	if relayed == 0 {
+		return 0, nil
+	}
 
-		seq := c.getSequence(toChainNode)
+	tx, err := c.sign(msgs, toHeight)
+	if err != nil {
+		return 0, err
+	}
+	if err = c.to.BroadcastTx(tx); err != nil {
+		// the persisted progress may be ahead of a destination chain that
+		// never committed the packets, retry from its ingress sequence
+		c.progress.Sequence = processed
+		return 0, errors.Wrap(err, "error broadcasting ingress packets")
+	}
+	c.logger.Info("Relayed IBC packets", "from", start, "to", start+int64(relayed)-1)
 
-		for i := processed; i < egressLength; i++ {
-			egressbz, err := query(fromChainNode, ibc.EgressKey(toChainID, i), c.ibcStore)
-			if err != nil {
-				c.logger.Error("error querying egress packet", "err", err)
-				continue OUTER // TODO replace to break, will break first loop then send back to the beginning (aka OUTER)
-			}
+	c.progress.Sequence = start + int64(relayed)
+	c.progress.Height = proofHeight
+	return relayed, saveProgress(c.progressFile, c.progress)
+}
 
-			err = c.broadcastTx(seq, toChainNode, c.refine(egressbz, i, passphrase))
-			seq++
-			if err != nil {
-				c.logger.Error("error broadcasting ingress packet", "err", err)
-				continue OUTER // TODO replace to break, will break first loop then send back to the beginning (aka OUTER)
-			}
+// list the sequences of the outgoing packets to the destination chain from
+// start on, in the order they have to be relayed, up to the batch size
+func (c *relayCommander) pendingPackets(height, start int64) ([]int64, error) {
+	length, err := c.queryInt64(c.from, ibc.EgressLengthKey(c.to.ChainID()), height)
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying egress length")
+	}
 
-			c.logger.Info("Relayed IBC packet", "number", i)
-		}
+	end := start + int64(c.batchSize)
+	if end > length {
+		end = length
 	}
+	sequences := []int64{}
+	for seq := start; seq < end; seq++ {
+		sequences = append(sequences, seq)
+	}
+	return sequences, nil
 }
 
-func query(node string, key []byte, storeName string) (res []byte, err error) {
-	return context.NewCoreContextFromViper().WithNodeURI(node).QueryStore(key, storeName)
+// build the message posting a commit of the source chain, registering the
+// source chain on the destination chain if it is not known yet
+func (c *relayCommander) commitMsg(height, toHeight int64) (sdk.Msg, error) {
+	fc, err := c.from.FullCommit(height)
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying commit")
+	}
+	registered, err := c.to.Query(ibc.LatestCommitHeightKey(c.from.ChainID()), c.ibcStore, toHeight)
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying registered chain")
+	}
+	if registered == nil {
+		return ibc.IBCRegisterChainMsg{
+			Commit:     fc,
+			Registrant: c.address,
+		}, nil
+	}
+	return ibc.IBCUpdateChainMsg{
+		Commit:  fc,
+		Relayer: c.address,
+	}, nil
 }
 
-func (c relayCommander) broadcastTx(seq int64, node string, tx []byte) error {
-	_, err := context.NewCoreContextFromViper().WithNodeURI(node).WithSequence(seq + 1).BroadcastTx(tx)
-	return err
+// sign the messages with the relayer key for the destination chain
+func (c *relayCommander) sign(msgs []sdk.Msg, toHeight int64) ([]byte, error) {
+	bz, err := c.to.Query(auth.AddressStoreKey(c.address), c.accStore, toHeight)
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying relayer account")
+	}
+	if bz == nil {
+		return nil, errors.Errorf("relayer account %s does not exist on %s", c.address, c.to.ChainID())
+	}
+	account, err := c.decoder(bz)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := c.ctx.WithAccountNumber(account.GetAccountNumber()).WithSequence(account.GetSequence())
+	return ctx.SignAndBuild(ctx.FromAddressName, c.passphrase, msgs, c.cdc)
+}
+
+func (c *relayCommander) queryInt64(chain relayChain, key []byte, height int64) (int64, error) {
+	bz, err := chain.Query(key, c.ibcStore, height)
+	if err != nil || bz == nil {
+		return 0, err
+	}
+	var res int64
+	err = c.cdc.UnmarshalBinary(bz, &res)
+	return res, err
 }
 
-func (c relayCommander) getSequence(node string) int64 {
-	res, err := query(node, c.address, c.accStore)
+// load the progress of the relayer, starting from scratch if it was never saved
+func loadProgress(file, fromChainID, toChainID string) (relayProgress, error) {
+	progress := relayProgress{FromChainID: fromChainID, ToChainID: toChainID}
+	bz, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return progress, nil
+	}
 	if err != nil {
-		panic(err)
+		return progress, err
 	}
-	if nil != res {
-		account, err := c.decoder(res)
-		if err != nil {
-			panic(err)
-		}
+	if err = json.Unmarshal(bz, &progress); err != nil {
+		return progress, errors.Wrapf(err, "invalid progress file %s", file)
+	}
+	if progress.FromChainID != fromChainID || progress.ToChainID != toChainID {
+		return progress, errors.Errorf("progress file %s relays %s to %s, not %s to %s",
+			file, progress.FromChainID, progress.ToChainID, fromChainID, toChainID)
+	}
+	return progress, nil
+}
 
-		return account.GetSequence()
+// save the progress of the relayer, replacing the file atomically
+func saveProgress(file string, progress relayProgress) error {
+	bz, err := json.MarshalIndent(progress, "", "  ")
+	if err != nil {
+		return err
 	}
+	if err = os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+	tmp := file + ".tmp"
+	if err = ioutil.WriteFile(tmp, bz, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
 
-	return 0
+//----------------------------------------
+// relayChain of a tepleton node
+
+type nodeChain struct {
+	cdc     *wire.Codec
+	chainID string
+	ctx     context.CoreContext
 }
 
-func (c relayCommander) refine(bz []byte, sequence int64, passphrase string) []byte {
-	var packet ibc.IBCPacket
-	if err := c.cdc.UnmarshalBinary(bz, &packet); err != nil {
-		panic(err)
+func newNodeChain(cdc *wire.Codec, chainID, node string) nodeChain {
+	return nodeChain{
+		cdc:     cdc,
+		chainID: chainID,
+		ctx:     context.NewCoreContextFromViper().WithChainID(chainID).WithNodeURI(node),
 	}
+}
 
-	msg := ibc.IBCReceiveMsg{
-		IBCPacket: packet,
-		Relayer:   c.address,
-		Sequence:  sequence,
+func (n nodeChain) ChainID() string {
+	return n.chainID
+}
+
+func (n nodeChain) LatestHeight() (int64, error) {
+	client, err := n.ctx.GetNode()
+	if err != nil {
+		return 0, err
 	}
+	status, err := client.Status()
+	if err != nil {
+		return 0, err
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
 
-	ctx := context.NewCoreContextFromViper().WithSequence(sequence)
-	res, err := ctx.SignAndBuild(ctx.FromAddressName, passphrase, []sdk.Msg{msg}, c.cdc)
+func (n nodeChain) Query(key []byte, storeName string, height int64) ([]byte, error) {
+	return n.ctx.WithHeight(height).QueryStore(key, storeName)
+}
+
+func (n nodeChain) QueryWithProof(key []byte, storeName string, height int64) (res []byte, proof []byte, err error) {
+	res, proof, _, err = n.ctx.WithHeight(height).QueryStoreWithProof(key, storeName)
+	return res, proof, err
+}
+
+// get the header of the chain at a height with its commit and validator set
+func (n nodeChain) FullCommit(height int64) (fc ibc.FullCommit, err error) {
+	client, err := n.ctx.GetNode()
+	if err != nil {
+		return fc, err
+	}
+	commit, err := client.Commit(&height)
 	if err != nil {
-		panic(err)
+		return fc, err
 	}
-	return res
+	validators, err := client.Validators(&height)
+	if err != nil {
+		return fc, err
+	}
+	return ibc.FullCommit{
+		Header:     commit.Header,
+		Commit:     commit.Commit,
+		Validators: tmtypes.NewValidatorSet(validators.Validators),
+	}, nil
+}
+
+func (n nodeChain) BroadcastTx(tx []byte) error {
+	_, err := n.ctx.BroadcastTx(tx)
+	return err
 }
@@ -0,0 +1,349 @@
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+	"github.com/tepleton/tepleton/crypto"
+	dbm "github.com/tepleton/tepleton/libs/db"
+	"github.com/tepleton/tepleton/libs/log"
+	tmtypes "github.com/tepleton/tepleton/types"
+
+	"github.com/tepleton/tepleton-sdk/client"
+	"github.com/tepleton/tepleton-sdk/client/context"
+	"github.com/tepleton/tepleton-sdk/client/keys"
+	gapp "github.com/tepleton/tepleton-sdk/cmd/ton/app"
+	crkeys "github.com/tepleton/tepleton-sdk/crypto/keys"
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+	"github.com/tepleton/tepleton-sdk/x/auth"
+	authcmd "github.com/tepleton/tepleton-sdk/x/auth/client/cli"
+	"github.com/tepleton/tepleton-sdk/x/gov"
+	"github.com/tepleton/tepleton-sdk/x/ibc"
+	"github.com/tepleton/tepleton-sdk/x/slashing"
+	"github.com/tepleton/tepleton-sdk/x/stake"
+)
+
+const (
+	relayerName     = "relayer"
+	relayerPassword = "1234567890"
+)
+
+// an in-process GaiaApp driven block by block, whose commits are signed by
+// its own set of validators
+type appChain struct {
+	chainID   string
+	cdc       *wire.Codec
+	app       *gapp.GaiaApp
+	privs     []crypto.PrivKey
+	appHashes map[int64][]byte
+}
+
+var _ relayChain = (*appChain)(nil)
+
+func newAppChain(t *testing.T, cdc *wire.Codec, chainID string, trusted []ibc.TrustedChain, accs ...*auth.BaseAccount) *appChain {
+	genaccs := make([]gapp.GenesisAccount, len(accs))
+	for i, acc := range accs {
+		genaccs[i] = gapp.NewGenesisAccount(acc)
+	}
+	genesisState := gapp.GenesisState{
+		Accounts:     genaccs,
+		StakeData:    stake.DefaultGenesisState(),
+		SlashingData: slashing.DefaultGenesisState(),
+		GovData:      gov.DefaultGenesisState(),
+		IBCData:      ibc.GenesisState{TrustedChains: trusted},
+	}
+	stateBytes, err := wire.MarshalJSONIndent(cdc, genesisState)
+	require.Nil(t, err)
+
+	c := &appChain{
+		chainID:   chainID,
+		cdc:       cdc,
+		app:       gapp.NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB()),
+		privs:     []crypto.PrivKey{crypto.GenPrivKeyEd25519(), crypto.GenPrivKeyEd25519()},
+		appHashes: make(map[int64][]byte),
+	}
+	c.app.InitChain(wrsp.RequestInitChain{ChainId: chainID, AppStateBytes: stateBytes})
+	res := c.app.Commit()
+	c.appHashes[c.app.LastBlockHeight()] = res.Data
+	return c
+}
+
+// deliver the transactions in a new block
+func (c *appChain) deliverBlock(txs ...[]byte) []wrsp.ResponseDeliverTx {
+	header := wrsp.Header{ChainID: c.chainID, Height: c.app.LastBlockHeight() + 1}
+	c.app.BeginBlock(wrsp.RequestBeginBlock{Header: header})
+	results := make([]wrsp.ResponseDeliverTx, len(txs))
+	for i, tx := range txs {
+		results[i] = c.app.DeliverTx(tx)
+	}
+	c.app.EndBlock(wrsp.RequestEndBlock{})
+	res := c.app.Commit()
+	c.appHashes[header.Height] = res.Data
+	return results
+}
+
+func (c *appChain) ChainID() string {
+	return c.chainID
+}
+
+func (c *appChain) LatestHeight() (int64, error) {
+	return c.app.LastBlockHeight(), nil
+}
+
+func (c *appChain) query(key []byte, storeName, endPath string, height int64, prove bool) (wrsp.ResponseQuery, error) {
+	res := c.app.Query(wrsp.RequestQuery{
+		Path:   fmt.Sprintf("/store/%s/%s", storeName, endPath),
+		Data:   key,
+		Height: height,
+		Prove:  prove,
+	})
+	if res.Code != uint32(0) {
+		return res, fmt.Errorf("query failed: (%d) %s", res.Code, res.Log)
+	}
+	return res, nil
+}
+
+func (c *appChain) Query(key []byte, storeName string, height int64) ([]byte, error) {
+	res, err := c.query(key, storeName, "key", height, false)
+	return res.Value, err
+}
+
+func (c *appChain) QueryWithProof(key []byte, storeName string, height int64) ([]byte, []byte, error) {
+	res, err := c.query(key, storeName, "key", height, true)
+	return res.Value, res.Proof, err
+}
+
+// the validators signing the commits of the chain
+func (c *appChain) validatorSet() *tmtypes.ValidatorSet {
+	vals := make([]*tmtypes.Validator, len(c.privs))
+	for i, priv := range c.privs {
+		vals[i] = tmtypes.NewValidator(priv.PubKey(), 10)
+	}
+	return tmtypes.NewValidatorSet(vals)
+}
+
+// the chain and its validators, to be trusted by a counterparty chain
+func (c *appChain) trustedChain() ibc.TrustedChain {
+	return ibc.TrustedChain{ChainID: c.chainID, ValidatorsHash: c.validatorSet().Hash()}
+}
+
+// the header at a height commits to the app hash of the block before
+func (c *appChain) FullCommit(height int64) (fc ibc.FullCommit, err error) {
+	appHash, ok := c.appHashes[height-1]
+	if !ok {
+		return fc, fmt.Errorf("no block at height %d", height-1)
+	}
+
+	valset := c.validatorSet()
+
+	header := &tmtypes.Header{
+		ChainID:        c.chainID,
+		Height:         height,
+		Time:           time.Now(),
+		ValidatorsHash: valset.Hash(),
+		AppHash:        appHash,
+	}
+	blockID := tmtypes.BlockID{Hash: header.Hash()}
+
+	precommits := make([]*tmtypes.Vote, len(c.privs))
+	for _, priv := range c.privs {
+		idx, val := valset.GetByAddress(priv.PubKey().Address())
+		vote := &tmtypes.Vote{
+			ValidatorAddress: val.Address,
+			ValidatorIndex:   idx,
+			Height:           height,
+			Round:            0,
+			Timestamp:        time.Now(),
+			Type:             tmtypes.VoteTypePrecommit,
+			BlockID:          blockID,
+		}
+		vote.Signature, err = priv.Sign(vote.SignBytes(c.chainID))
+		if err != nil {
+			return fc, err
+		}
+		precommits[idx] = vote
+	}
+
+	return ibc.FullCommit{
+		Header:     header,
+		Commit:     &tmtypes.Commit{BlockID: blockID, Precommits: precommits},
+		Validators: valset,
+	}, nil
+}
+
+func (c *appChain) BroadcastTx(tx []byte) error {
+	res := c.deliverBlock(tx)[0]
+	if res.Code != uint32(0) {
+		return fmt.Errorf("deliverTx failed: (%d) %s", res.Code, res.Log)
+	}
+	return nil
+}
+
+func newTestRelayer(t *testing.T, cdc *wire.Codec, relayer sdk.Address, from, to *appChain, progressFile string) *relayCommander {
+	progress, err := loadProgress(progressFile, from.chainID, to.chainID)
+	require.Nil(t, err)
+
+	return &relayCommander{
+		cdc:       cdc,
+		address:   relayer,
+		decoder:   authcmd.GetAccountDecoder(cdc),
+		ibcStore:  "ibc",
+		mainStore: "main",
+		accStore:  "acc",
+
+		from: from,
+		to:   to,
+		ctx: context.CoreContext{
+			ChainID:         to.chainID,
+			Gas:             1000000,
+			Fee:             "0steak",
+			FromAddressName: relayerName,
+		},
+		passphrase:   relayerPassword,
+		batchSize:    2,
+		progressFile: progressFile,
+		progress:     progress,
+
+		logger: log.NewNopLogger(),
+	}
+}
+
+func transferTx(t *testing.T, cdc *wire.Codec, from, to *appChain, priv crypto.PrivKey, seq int64, dest sdk.Address, coins sdk.Coins) []byte {
+	msg := ibc.IBCTransferMsg{
+		IBCPacket: ibc.NewIBCPacket(ibc.TransferPayload{
+			SrcAddr:  priv.PubKey().Address(),
+			DestAddr: dest,
+			Coins:    coins,
+		}, from.chainID, to.chainID, 0),
+	}
+	msgs := []sdk.Msg{msg}
+	fee := auth.NewStdFee(100000, sdk.NewCoin("steak", 0))
+
+	sig, err := priv.Sign(auth.StdSignBytes(from.chainID, 0, seq, fee, msgs, ""))
+	require.Nil(t, err)
+	sigs := []auth.StdSignature{{
+		PubKey:        priv.PubKey(),
+		Signature:     sig,
+		AccountNumber: 0,
+		Sequence:      seq,
+	}}
+	bz, err := cdc.MarshalBinary(auth.NewStdTx(msgs, fee, sigs, ""))
+	require.Nil(t, err)
+	return bz
+}
+
+func getCoins(t *testing.T, c *appChain, addr sdk.Address) sdk.Coins {
+	height, _ := c.LatestHeight()
+	bz, err := c.Query(auth.AddressStoreKey(addr), "acc", height)
+	require.Nil(t, err)
+	if bz == nil {
+		return nil
+	}
+	acc, err := authcmd.GetAccountDecoder(c.cdc)(bz)
+	require.Nil(t, err)
+	return acc.GetCoins()
+}
+
+func TestRelay(t *testing.T) {
+	cdc := gapp.MakeCodec()
+
+	keys.SetKeyBase(client.MockKeyBase())
+	kb, err := keys.GetKeyBase()
+	require.Nil(t, err)
+	info, _, err := kb.CreateMnemonic(relayerName, crkeys.English, relayerPassword, crkeys.Secp256k1)
+	require.Nil(t, err)
+	relayer := info.GetPubKey().Address()
+
+	sender := crypto.GenPrivKeyEd25519()
+	receiver := crypto.GenPrivKeyEd25519().PubKey().Address()
+	steaks := sdk.Coins{sdk.NewCoin("steak", 100)}
+
+	senderAcc := auth.NewBaseAccountWithAddress(sender.PubKey().Address())
+	senderAcc.Coins = steaks
+	relayerAcc := auth.NewBaseAccountWithAddress(relayer)
+	relayerAcc.Coins = steaks
+	chainA := newAppChain(t, cdc, "chain-a", nil, &senderAcc)
+	chainB := newAppChain(t, cdc, "chain-b", []ibc.TrustedChain{chainA.trustedChain()}, &relayerAcc)
+
+	dir, err := ioutil.TempDir("", "relay_test")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	progressFile := filepath.Join(dir, "relay", "chain-a_chain-b.json")
+
+	// three packets are sent in one block
+	tenSteaks := sdk.Coins{sdk.NewCoin("steak", 10)}
+	txs := [][]byte{
+		transferTx(t, cdc, chainA, chainB, sender, 0, receiver, tenSteaks),
+		transferTx(t, cdc, chainA, chainB, sender, 1, receiver, tenSteaks),
+		transferTx(t, cdc, chainA, chainB, sender, 2, receiver, tenSteaks),
+	}
+	for _, res := range chainA.deliverBlock(txs...) {
+		require.Equal(t, uint32(0), res.Code, res.Log)
+	}
+
+	// the packets can only be proven once the next header commits to them
+	r := newTestRelayer(t, cdc, relayer, chainA, chainB, progressFile)
+	relayed, err := r.relay()
+	require.Nil(t, err)
+	require.Equal(t, 0, relayed)
+	chainA.deliverBlock()
+
+	// the first batch registers chain A on chain B
+	relayed, err = r.relay()
+	require.Nil(t, err)
+	require.Equal(t, 2, relayed)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("chain-a/steak", 20)}, getCoins(t, chainB, receiver))
+	progress, err := loadProgress(progressFile, "chain-a", "chain-b")
+	require.Nil(t, err)
+	require.Equal(t, int64(2), progress.Sequence)
+
+	// a restarted relayer resumes after the relayed packets
+	r = newTestRelayer(t, cdc, relayer, chainA, chainB, progressFile)
+	require.Equal(t, int64(2), r.progress.Sequence)
+	relayed, err = r.relay()
+	require.Nil(t, err)
+	require.Equal(t, 1, relayed)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("chain-a/steak", 30)}, getCoins(t, chainB, receiver))
+	relayed, err = r.relay()
+	require.Nil(t, err)
+	require.Equal(t, 0, relayed)
+
+	// later packets are proven against an updated commit of chain A
+	chainA.deliverBlock(transferTx(t, cdc, chainA, chainB, sender, 3, receiver, tenSteaks))
+	chainA.deliverBlock()
+	relayed, err = r.relay()
+	require.Nil(t, err)
+	require.Equal(t, 1, relayed)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("chain-a/steak", 40)}, getCoins(t, chainB, receiver))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 60)}, getCoins(t, chainA, sender.PubKey().Address()))
+
+	progress, err = loadProgress(progressFile, "chain-a", "chain-b")
+	require.Nil(t, err)
+	require.Equal(t, int64(4), progress.Sequence)
+	_, err = loadProgress(progressFile, "chain-a", "chain-c")
+	require.NotNil(t, err)
+
+	// a relayer ahead of the destination chain falls back to its ingress
+	// sequence once the destination rejects the packets
+	chainA.deliverBlock(
+		transferTx(t, cdc, chainA, chainB, sender, 4, receiver, tenSteaks),
+		transferTx(t, cdc, chainA, chainB, sender, 5, receiver, tenSteaks),
+	)
+	chainA.deliverBlock()
+	r.progress.Sequence = 5
+	_, err = r.relay()
+	require.NotNil(t, err)
+	require.Equal(t, int64(4), r.progress.Sequence)
+	relayed, err = r.relay()
+	require.Nil(t, err)
+	require.Equal(t, 2, relayed)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("chain-a/steak", 60)}, getCoins(t, chainB, receiver))
+}
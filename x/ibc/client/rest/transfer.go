@@ -25,6 +25,7 @@ type transferBody struct {
 	LocalAccountName string    `json:"name"`
 	Password         string    `json:"password"`
 	SrcChainID       string    `json:"src_chain_id"`
+	TimeoutHeight    int64     `json:"timeout_height"`
 	AccountNumber    int64     `json:"account_number"`
 	Sequence         int64     `json:"sequence"`
 	Gas              int64     `json:"gas"`
@@ -76,7 +77,12 @@ func TransferRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx context.Core
 		to := sdk.Address(bz)
 
 		// build message
-		packet := ibc.NewIBCPacket(info.GetPubKey().Address(), to, m.Amount, m.SrcChainID, destChainID)
+		payload := ibc.TransferPayload{
+			SrcAddr:  info.GetPubKey().Address(),
+			DestAddr: to,
+			Coins:    m.Amount,
+		}
+		packet := ibc.NewIBCPacket(payload, m.SrcChainID, destChainID, m.TimeoutHeight)
 		msg := ibc.IBCTransferMsg{packet}
 
 		// add gas to context
@@ -0,0 +1,78 @@
+package ibc
+
+import (
+	"bytes"
+	"fmt"
+
+	tmtypes "github.com/tepleton/tepleton/types"
+)
+
+// FullCommit is a header of a counterparty chain together with the commit
+// signatures over it and the validator set that produced it. It is what a
+// relayer posts to keep the light client of a counterparty chain up to date.
+type FullCommit struct {
+	Header     *tmtypes.Header       `json:"header"`
+	Commit     *tmtypes.Commit       `json:"commit"`
+	Validators *tmtypes.ValidatorSet `json:"validators"`
+}
+
+// nolint
+func (fc FullCommit) ChainID() string { return fc.Header.ChainID }
+func (fc FullCommit) Height() int64   { return fc.Header.Height }
+func (fc FullCommit) AppHash() []byte { return fc.Header.AppHash }
+
+// ValidateBasic checks that the commit signs the header and that the
+// validator set is the one named in the header, without checking any
+// signatures.
+func (fc FullCommit) ValidateBasic() error {
+	if fc.Header == nil || fc.Commit == nil || fc.Validators == nil {
+		return fmt.Errorf("header, commit and validators must be set")
+	}
+	if fc.Header.ChainID == "" {
+		return fmt.Errorf("header has no chain-id")
+	}
+	if fc.Header.Height <= 0 {
+		return fmt.Errorf("header has invalid height %d", fc.Header.Height)
+	}
+	if !bytes.Equal(fc.Commit.BlockID.Hash, fc.Header.Hash()) {
+		return fmt.Errorf("commit signs block %X, header has hash %X",
+			fc.Commit.BlockID.Hash, fc.Header.Hash())
+	}
+	if !bytes.Equal(fc.Validators.Hash(), fc.Header.ValidatorsHash) {
+		return fmt.Errorf("validators hash %X does not match header %X",
+			fc.Validators.Hash(), fc.Header.ValidatorsHash)
+	}
+	return nil
+}
+
+// Verify checks that more than 2/3 of the validator set of the commit has
+// signed the header.
+func (fc FullCommit) Verify() error {
+	err := fc.ValidateBasic()
+	if err != nil {
+		return err
+	}
+	return fc.Validators.VerifyCommit(fc.ChainID(), fc.Commit.BlockID, fc.Height(), fc.Commit)
+}
+
+// VerifyUpdate checks a new commit against a trusted one. If the validator
+// set is unchanged the commit must be signed by more than 2/3 of it,
+// otherwise more than 2/3 of both the trusted and the new validator set must
+// have signed it.
+func (fc FullCommit) VerifyUpdate(trusted FullCommit) error {
+	err := fc.ValidateBasic()
+	if err != nil {
+		return err
+	}
+	if fc.ChainID() != trusted.ChainID() {
+		return fmt.Errorf("commit is for chain %s, expected %s", fc.ChainID(), trusted.ChainID())
+	}
+	if fc.Height() <= trusted.Height() {
+		return fmt.Errorf("commit height %d is not after trusted height %d", fc.Height(), trusted.Height())
+	}
+
+	if bytes.Equal(fc.Header.ValidatorsHash, trusted.Header.ValidatorsHash) {
+		return trusted.Validators.VerifyCommit(fc.ChainID(), fc.Commit.BlockID, fc.Height(), fc.Commit)
+	}
+	return trusted.Validators.VerifyCommitAny(fc.Validators, fc.ChainID(), fc.Commit.BlockID, fc.Height(), fc.Commit)
+}
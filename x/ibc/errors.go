@@ -1,6 +1,8 @@
 package ibc
 
 import (
+	"fmt"
+
 	sdk "github.com/tepleton/tepleton-sdk/types"
 )
 
@@ -11,6 +13,17 @@ const (
 	// IBC errors reserve 200 - 299.
 	CodeInvalidSequence sdk.CodeType = 200
 	CodeIdenticalChains sdk.CodeType = 201
+	CodeChainRegistered sdk.CodeType = 202
+	CodeChainNotFound   sdk.CodeType = 203
+	CodeInvalidCommit   sdk.CodeType = 204
+	CodeCommitNotFound  sdk.CodeType = 205
+	CodeInvalidProof    sdk.CodeType = 206
+	CodeInvalidChain    sdk.CodeType = 207
+	CodeUnknownPacket   sdk.CodeType = 208
+	CodeInvalidTimeout  sdk.CodeType = 209
+	CodePacketSettled   sdk.CodeType = 210
+	CodeInvalidPayload  sdk.CodeType = 211
+	CodeUntrustedChain  sdk.CodeType = 212
 	CodeUnknownRequest  sdk.CodeType = sdk.CodeUnknownRequest
 )
 
@@ -20,6 +33,28 @@ func codeToDefaultMsg(code sdk.CodeType) string {
 		return "invalid IBC packet sequence"
 	case CodeIdenticalChains:
 		return "source and destination chain cannot be identical"
+	case CodeChainRegistered:
+		return "chain is already registered"
+	case CodeChainNotFound:
+		return "chain is not registered"
+	case CodeInvalidCommit:
+		return "invalid commit of counterparty chain"
+	case CodeCommitNotFound:
+		return "no commit of counterparty chain stored at this height"
+	case CodeInvalidProof:
+		return "invalid proof of IBC packet"
+	case CodeInvalidChain:
+		return "IBC packet chain does not match this chain"
+	case CodeUnknownPacket:
+		return "IBC packet was not sent by this chain"
+	case CodeInvalidTimeout:
+		return "IBC packet has not timed out"
+	case CodePacketSettled:
+		return "IBC packet was already acknowledged or refunded"
+	case CodeInvalidPayload:
+		return "invalid IBC packet payload"
+	case CodeUntrustedChain:
+		return "chain is not trusted to be registered"
 	default:
 		return sdk.CodeToDefaultMsg(code)
 	}
@@ -32,6 +67,40 @@ func ErrInvalidSequence(codespace sdk.CodespaceType) sdk.Error {
 func ErrIdenticalChains(codespace sdk.CodespaceType) sdk.Error {
 	return newError(codespace, CodeIdenticalChains, "")
 }
+func ErrChainRegistered(codespace sdk.CodespaceType, chainID string) sdk.Error {
+	return newError(codespace, CodeChainRegistered, fmt.Sprintf("chain %s is already registered", chainID))
+}
+func ErrChainNotFound(codespace sdk.CodespaceType, chainID string) sdk.Error {
+	return newError(codespace, CodeChainNotFound, fmt.Sprintf("chain %s is not registered", chainID))
+}
+func ErrInvalidCommit(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidCommit, msg)
+}
+func ErrCommitNotFound(codespace sdk.CodespaceType, chainID string, height int64) sdk.Error {
+	return newError(codespace, CodeCommitNotFound, fmt.Sprintf("no commit of chain %s stored at height %d", chainID, height))
+}
+func ErrInvalidProof(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidProof, msg)
+}
+func ErrInvalidChain(codespace sdk.CodespaceType, chainID string) sdk.Error {
+	return newError(codespace, CodeInvalidChain, fmt.Sprintf("IBC packet chain %s does not match this chain", chainID))
+}
+func ErrUnknownPacket(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeUnknownPacket, "")
+}
+func ErrInvalidTimeout(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidTimeout, msg)
+}
+func ErrPacketSettled(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodePacketSettled, "")
+}
+func ErrInvalidPayload(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidPayload, msg)
+}
+
+func ErrUntrustedChain(codespace sdk.CodespaceType, chainID string) sdk.Error {
+	return newError(codespace, CodeUntrustedChain, fmt.Sprintf("chain %s is not trusted with this validator set", chainID))
+}
 
 // -------------------------
 // Helpers
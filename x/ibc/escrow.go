@@ -0,0 +1,114 @@
+package ibc
+
+import (
+	"strings"
+
+	"github.com/tepleton/tepleton/crypto/tmhash"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/x/bank"
+)
+
+// EscrowAddress returns the address of the account holding the coins sent to
+// a counterparty chain until they are sent back or refunded.
+func EscrowAddress(chainID string) sdk.Address {
+	return sdk.Address(tmhash.Sum([]byte("ibc/escrow/" + chainID)))
+}
+
+// VoucherDenom returns the denomination of the vouchers minted for coins of
+// the given denomination received from a counterparty chain.
+func VoucherDenom(chainID, denom string) string {
+	return chainID + "/" + denom
+}
+
+// split coins into the ones native to this side of the channel and the
+// vouchers issued by the given chain, which go back home on transfer
+func splitVouchers(coins sdk.Coins, chainID string) (native, vouchers sdk.Coins) {
+	prefix := VoucherDenom(chainID, "")
+	for _, coin := range coins {
+		if strings.HasPrefix(coin.Denom, prefix) {
+			vouchers = append(vouchers, coin)
+		} else {
+			native = append(native, coin)
+		}
+	}
+	return
+}
+
+// strip the voucher prefix of the given chain from the coins
+func unwrapVouchers(vouchers sdk.Coins, chainID string) sdk.Coins {
+	prefix := VoucherDenom(chainID, "")
+	coins := make(sdk.Coins, len(vouchers))
+	for i, coin := range vouchers {
+		coins[i] = sdk.Coin{Denom: strings.TrimPrefix(coin.Denom, prefix), Amount: coin.Amount}
+	}
+	return coins.Sort()
+}
+
+// prefix the coins with the voucher prefix of the given chain
+func wrapVouchers(coins sdk.Coins, chainID string) sdk.Coins {
+	vouchers := make(sdk.Coins, len(coins))
+	for i, coin := range coins {
+		vouchers[i] = sdk.Coin{Denom: VoucherDenom(chainID, coin.Denom), Amount: coin.Amount}
+	}
+	return vouchers.Sort()
+}
+
+// Takes the coins of an outgoing packet from the sender. Coins native to this
+// chain are held in the escrow account of the destination chain, vouchers of
+// the destination chain are burned as they are going back home.
+func sendPacketCoins(ctx sdk.Context, ck bank.Keeper, packet IBCPacket, payload TransferPayload) sdk.Error {
+	native, vouchers := splitVouchers(payload.Coins, packet.DestChain)
+	if len(vouchers) > 0 {
+		_, _, err := ck.SubtractCoins(ctx, payload.SrcAddr, vouchers)
+		if err != nil {
+			return err
+		}
+	}
+	if len(native) > 0 {
+		_, err := ck.SendCoins(ctx, payload.SrcAddr, EscrowAddress(packet.DestChain), native)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Gives the coins of an incoming packet to the receiver. Coins coming back
+// home are released from the escrow account of the source chain, other coins
+// are minted as vouchers of the source chain.
+func receivePacketCoins(ctx sdk.Context, ck bank.Keeper, packet IBCPacket, payload TransferPayload) sdk.Error {
+	foreign, returning := splitVouchers(payload.Coins, packet.DestChain)
+	if len(returning) > 0 {
+		_, err := ck.SendCoins(ctx, EscrowAddress(packet.SrcChain), payload.DestAddr, unwrapVouchers(returning, packet.DestChain))
+		if err != nil {
+			return err
+		}
+	}
+	if len(foreign) > 0 {
+		_, _, err := ck.AddCoins(ctx, payload.DestAddr, wrapVouchers(foreign, packet.SrcChain))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Gives the coins of a failed or timed out outgoing packet back to the sender,
+// undoing sendPacketCoins.
+func refundPacketCoins(ctx sdk.Context, ck bank.Keeper, packet IBCPacket, payload TransferPayload) sdk.Error {
+	native, vouchers := splitVouchers(payload.Coins, packet.DestChain)
+	if len(vouchers) > 0 {
+		_, _, err := ck.AddCoins(ctx, payload.SrcAddr, vouchers)
+		if err != nil {
+			return err
+		}
+	}
+	if len(native) > 0 {
+		_, err := ck.SendCoins(ctx, EscrowAddress(packet.DestChain), payload.SrcAddr, native)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
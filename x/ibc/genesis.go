@@ -0,0 +1,37 @@
+package ibc
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// GenesisState - ibc state that must be provided at genesis
+type GenesisState struct {
+	TrustedChains []TrustedChain `json:"trusted_chains"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		TrustedChains: []TrustedChain{},
+	}
+}
+
+// InitGenesis - store the counterparty chains which can be registered
+func InitGenesis(ctx sdk.Context, ibcm Mapper, data GenesisState) {
+	err := validateTrustedChains(ctx, data.TrustedChains)
+	if err != nil {
+		panic(err)
+	}
+	ibcm.SetTrustedChains(ctx, data.TrustedChains)
+}
+
+// WriteGenesis - output the counterparty chains which can be registered
+func WriteGenesis(ctx sdk.Context, ibcm Mapper) GenesisState {
+	chains := ibcm.GetTrustedChains(ctx)
+	if chains == nil {
+		chains = []TrustedChain{}
+	}
+	return GenesisState{
+		TrustedChains: chains,
+	}
+}
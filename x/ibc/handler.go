@@ -1,19 +1,31 @@
 package ibc
 
 import (
+	"bytes"
 	"reflect"
 
+	"github.com/tepleton/tepleton-sdk/store"
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	"github.com/tepleton/tepleton-sdk/x/bank"
 )
 
-func NewHandler(ibcm Mapper, ck bank.Keeper) sdk.Handler {
+// NewHandler returns the handler of IBC messages. Packets are handed to the
+// packet handler registered in the router for the type of their payload.
+func NewHandler(ibcm Mapper, ck bank.Keeper, rtr Router) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 		switch msg := msg.(type) {
 		case IBCTransferMsg:
 			return handleIBCTransferMsg(ctx, ibcm, ck, msg)
 		case IBCReceiveMsg:
-			return handleIBCReceiveMsg(ctx, ibcm, ck, msg)
+			return handleIBCReceiveMsg(ctx, ibcm, rtr, msg)
+		case IBCAcknowledgementMsg:
+			return handleIBCAcknowledgementMsg(ctx, ibcm, rtr, msg)
+		case IBCTimeoutMsg:
+			return handleIBCTimeoutMsg(ctx, ibcm, rtr, msg)
+		case IBCRegisterChainMsg:
+			return handleIBCRegisterChainMsg(ctx, ibcm, msg)
+		case IBCUpdateChainMsg:
+			return handleIBCUpdateChainMsg(ctx, ibcm, msg)
 		default:
 			errMsg := "Unrecognized IBC Msg type: " + reflect.TypeOf(msg).Name()
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -21,11 +33,20 @@ func NewHandler(ibcm Mapper, ck bank.Keeper) sdk.Handler {
 	}
 }
 
-// IBCTransferMsg deducts coins from the account and creates an egress IBC packet.
+// IBCTransferMsg escrows or burns coins of the account and creates an egress IBC packet.
 func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCTransferMsg) sdk.Result {
 	packet := msg.IBCPacket
 
-	_, _, err := ck.SubtractCoins(ctx, packet.SrcAddr, packet.Coins)
+	if packet.SrcChain != ctx.ChainID() {
+		return ErrInvalidChain(ibcm.codespace, packet.SrcChain).Result()
+	}
+
+	payload, ok := packet.Payload.(TransferPayload)
+	if !ok {
+		return ErrInvalidPayload(ibcm.codespace, "transfer requires a transfer payload").Result()
+	}
+
+	err := sendPacketCoins(ctx, ck, packet, payload)
 	if err != nil {
 		return err.Result()
 	}
@@ -38,21 +59,225 @@ func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCT
 	return sdk.Result{}
 }
 
-// IBCReceiveMsg adds coins to the destination address and creates an ingress IBC packet.
-func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCReceiveMsg) sdk.Result {
+// IBCReceiveMsg hands the packet to the handler of its payload and creates an ingress IBC packet.
+// The packet is only accepted if it is proven to be in the egress queue of the
+// source chain, against the app hash of a trusted commit of that chain. The
+// result of the payload handler is written as the acknowledgement of the
+// packet; state changes of a failed payload handler are discarded. A timed out
+// packet is skipped without being handled, so it can be refunded on the source
+// chain.
+func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, rtr Router, msg IBCReceiveMsg) sdk.Result {
 	packet := msg.IBCPacket
 
+	if packet.DestChain != ctx.ChainID() {
+		return ErrInvalidChain(ibcm.codespace, packet.DestChain).Result()
+	}
+
 	seq := ibcm.GetIngressSequence(ctx, packet.SrcChain)
 	if msg.Sequence != seq {
 		return ErrInvalidSequence(ibcm.codespace).Result()
 	}
 
-	_, _, err := ck.AddCoins(ctx, packet.DestAddr, packet.Coins)
+	fc, found := ibcm.GetCommit(ctx, packet.SrcChain, msg.Height)
+	if !found {
+		return ErrCommitNotFound(ibcm.codespace, packet.SrcChain, msg.Height).Result()
+	}
+
+	bz, err := ibcm.cdc.MarshalBinary(packet)
 	if err != nil {
-		return err.Result()
+		panic(err)
+	}
+	key := EgressKey(packet.DestChain, msg.Sequence)
+	err = store.VerifyProof(msg.Proof, ibcm.key.Name(), key, bz, fc.AppHash())
+	if err != nil {
+		return ErrInvalidProof(ibcm.codespace, err.Error()).Result()
 	}
 
 	ibcm.SetIngressSequence(ctx, packet.SrcChain, seq+1)
 
+	if packet.TimedOut(ctx.BlockHeight()) {
+		return sdk.Result{Log: "packet timed out"}
+	}
+
+	var res sdk.Result
+	h := rtr.Route(packet.Payload.Type())
+	if h == nil {
+		errMsg := "Unrecognized IBC payload type: " + packet.Payload.Type()
+		res = sdk.ErrUnknownRequest(errMsg).Result()
+	} else {
+		cacheCtx, write := ctx.CacheContext()
+		res = h.ReceivePacket(cacheCtx, packet)
+		if res.IsOK() {
+			write()
+		}
+	}
+
+	ack := NewAcknowledgement(res)
+	ibcm.setAcknowledgement(ctx, packet.SrcChain, seq, ack)
+
+	return sdk.Result{
+		Data: res.Data,
+		Log:  res.Log,
+		Tags: res.Tags,
+	}
+}
+
+// checks that the packet is the egress IBC packet at the sequence, and that
+// it was neither acknowledged nor refunded yet
+func checkEgressPacket(ctx sdk.Context, ibcm Mapper, packet IBCPacket, sequence int64) sdk.Error {
+	if packet.SrcChain != ctx.ChainID() {
+		return ErrInvalidChain(ibcm.codespace, packet.SrcChain)
+	}
+
+	bz, err := ibcm.cdc.MarshalBinary(packet)
+	if err != nil {
+		panic(err)
+	}
+	if !bytes.Equal(bz, ibcm.getEgressPacket(ctx, packet.DestChain, sequence)) {
+		return ErrUnknownPacket(ibcm.codespace)
+	}
+	if ibcm.isSettled(ctx, packet.DestChain, sequence) {
+		return ErrPacketSettled(ibcm.codespace)
+	}
+	return nil
+}
+
+// IBCAcknowledgementMsg hands the acknowledgement of an egress IBC packet to
+// the handler of its payload, if it is proven that the destination chain wrote
+// it.
+func handleIBCAcknowledgementMsg(ctx sdk.Context, ibcm Mapper, rtr Router, msg IBCAcknowledgementMsg) sdk.Result {
+	packet := msg.IBCPacket
+
+	sdkErr := checkEgressPacket(ctx, ibcm, packet, msg.Sequence)
+	if sdkErr != nil {
+		return sdkErr.Result()
+	}
+
+	fc, found := ibcm.GetCommit(ctx, packet.DestChain, msg.Height)
+	if !found {
+		return ErrCommitNotFound(ibcm.codespace, packet.DestChain, msg.Height).Result()
+	}
+
+	ack, err := ibcm.cdc.MarshalBinary(msg.Acknowledgement)
+	if err != nil {
+		panic(err)
+	}
+	key := AcknowledgementKey(packet.SrcChain, msg.Sequence)
+	err = store.VerifyProof(msg.Proof, ibcm.key.Name(), key, ack, fc.AppHash())
+	if err != nil {
+		return ErrInvalidProof(ibcm.codespace, err.Error()).Result()
+	}
+
+	h := rtr.Route(packet.Payload.Type())
+	if h == nil {
+		errMsg := "Unrecognized IBC payload type: " + packet.Payload.Type()
+		return sdk.ErrUnknownRequest(errMsg).Result()
+	}
+	res := h.AcknowledgePacket(ctx, packet, msg.Acknowledgement)
+	if !res.IsOK() {
+		return res
+	}
+
+	ibcm.setSettled(ctx, packet.DestChain, msg.Sequence)
+
+	return res
+}
+
+// IBCTimeoutMsg hands an egress IBC packet back to the handler of its payload
+// to be refunded, if it is proven that the destination chain had not received
+// it when it timed out.
+func handleIBCTimeoutMsg(ctx sdk.Context, ibcm Mapper, rtr Router, msg IBCTimeoutMsg) sdk.Result {
+	packet := msg.IBCPacket
+
+	sdkErr := checkEgressPacket(ctx, ibcm, packet, msg.Sequence)
+	if sdkErr != nil {
+		return sdkErr.Result()
+	}
+
+	// the destination chain skips the packet from its timeout height on
+	if !packet.TimedOut(msg.Height) {
+		return ErrInvalidTimeout(ibcm.codespace, "").Result()
+	}
+	if msg.ReceivedSequence > msg.Sequence {
+		return ErrInvalidTimeout(ibcm.codespace, "packet was received").Result()
+	}
+
+	fc, found := ibcm.GetCommit(ctx, packet.DestChain, msg.Height)
+	if !found {
+		return ErrCommitNotFound(ibcm.codespace, packet.DestChain, msg.Height).Result()
+	}
+
+	received, err := ibcm.cdc.MarshalBinary(msg.ReceivedSequence)
+	if err != nil {
+		panic(err)
+	}
+	key := IngressSequenceKey(packet.SrcChain)
+	err = store.VerifyProof(msg.Proof, ibcm.key.Name(), key, received, fc.AppHash())
+	if err != nil {
+		return ErrInvalidProof(ibcm.codespace, err.Error()).Result()
+	}
+
+	h := rtr.Route(packet.Payload.Type())
+	if h == nil {
+		errMsg := "Unrecognized IBC payload type: " + packet.Payload.Type()
+		return sdk.ErrUnknownRequest(errMsg).Result()
+	}
+	res := h.TimeoutPacket(ctx, packet)
+	if !res.IsOK() {
+		return res
+	}
+
+	ibcm.setSettled(ctx, packet.DestChain, msg.Sequence)
+
+	return res
+}
+
+// IBCRegisterChainMsg stores the first trusted commit of a counterparty chain.
+// Only the chains listed in the trusted chains param can be registered, with a
+// commit of the validator set listed there.
+func handleIBCRegisterChainMsg(ctx sdk.Context, ibcm Mapper, msg IBCRegisterChainMsg) sdk.Result {
+	fc := msg.Commit
+	chainID := fc.ChainID()
+
+	if chainID == ctx.ChainID() {
+		return ErrIdenticalChains(ibcm.codespace).Result()
+	}
+	if _, found := ibcm.GetLatestCommit(ctx, chainID); found {
+		return ErrChainRegistered(ibcm.codespace, chainID).Result()
+	}
+	if !ibcm.isTrustedChain(ctx, chainID, fc.Header.ValidatorsHash) {
+		return ErrUntrustedChain(ibcm.codespace, chainID).Result()
+	}
+
+	err := fc.Verify()
+	if err != nil {
+		return ErrInvalidCommit(ibcm.codespace, err.Error()).Result()
+	}
+
+	ibcm.SetCommit(ctx, fc)
+	// the ingress sequence must exist for packet timeouts to be provable
+	ibcm.SetIngressSequence(ctx, chainID, 0)
+
+	return sdk.Result{}
+}
+
+// IBCUpdateChainMsg stores a newer commit of a counterparty chain, if it is
+// signed by the validators of the latest trusted commit.
+func handleIBCUpdateChainMsg(ctx sdk.Context, ibcm Mapper, msg IBCUpdateChainMsg) sdk.Result {
+	fc := msg.Commit
+	chainID := fc.ChainID()
+
+	trusted, found := ibcm.GetLatestCommit(ctx, chainID)
+	if !found {
+		return ErrChainNotFound(ibcm.codespace, chainID).Result()
+	}
+
+	err := fc.VerifyUpdate(trusted)
+	if err != nil {
+		return ErrInvalidCommit(ibcm.codespace, err.Error()).Result()
+	}
+
+	ibcm.SetCommit(ctx, fc)
+
 	return sdk.Result{}
 }
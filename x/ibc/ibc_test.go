@@ -2,6 +2,7 @@ package ibc
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -9,23 +10,157 @@ import (
 	"github.com/tepleton/tepleton/crypto"
 	dbm "github.com/tepleton/tepleton/libs/db"
 	"github.com/tepleton/tepleton/libs/log"
+	tmtypes "github.com/tepleton/tepleton/types"
 
 	"github.com/tepleton/tepleton-sdk/store"
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	"github.com/tepleton/tepleton-sdk/wire"
 	"github.com/tepleton/tepleton-sdk/x/auth"
 	"github.com/tepleton/tepleton-sdk/x/bank"
+	"github.com/tepleton/tepleton-sdk/x/params"
 )
 
 // AccountMapper(/Keeper) and IBCMapper should use different StoreKey later
 
-func defaultContext(key sdk.StoreKey) sdk.Context {
+func defaultContext(chainID string, keys ...sdk.StoreKey) (sdk.CommitMultiStore, sdk.Context) {
 	db := dbm.NewMemDB()
 	cms := store.NewCommitMultiStore(db)
-	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	for _, key := range keys {
+		cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
 	cms.LoadLatestVersion()
-	ctx := sdk.NewContext(cms, wrsp.Header{}, false, log.NewNopLogger())
-	return ctx
+	ctx := sdk.NewContext(cms, wrsp.Header{ChainID: chainID}, false, log.NewNopLogger())
+	return cms, ctx
+}
+
+// a chain with its own store, validators and ibc handler
+type testChain struct {
+	chainID string
+	key     *sdk.KVStoreKey
+	cms     sdk.CommitMultiStore
+	ctx     sdk.Context
+	ck      bank.Keeper
+	ibcm    Mapper
+	ps      params.Setter
+	h       sdk.Handler
+	privs   []crypto.PrivKey
+}
+
+func newTestChain(cdc *wire.Codec, chainID string) *testChain {
+	key := sdk.NewKVStoreKey("ibc")
+	keyParams := sdk.NewKVStoreKey("params")
+	cms, ctx := defaultContext(chainID, key, keyParams)
+	ck := bank.NewKeeper(auth.NewAccountMapper(cdc, key, &auth.BaseAccount{}))
+	pk := params.NewKeeper(cdc, keyParams)
+	ibcm := NewMapper(cdc, key, DefaultCodespace).WithParams(pk.Setter())
+	return &testChain{
+		chainID: chainID,
+		key:     key,
+		cms:     cms,
+		ctx:     ctx,
+		ck:      ck,
+		ibcm:    ibcm,
+		ps:      pk.Setter(),
+		h:       NewHandler(ibcm, ck, NewRouter().AddRoute(TransferRoute, NewTransferHandler(ck))),
+		privs:   newPrivKeys(4),
+	}
+}
+
+// commit the chain and return the signed header of the next block, which
+// holds the app hash
+func (c *testChain) commit(t *testing.T) FullCommit {
+	cid := c.cms.Commit()
+	c.ctx = c.ctx.WithBlockHeight(cid.Version + 1)
+	return makeFullCommit(t, c.privs, c.chainID, cid.Version+1, cid.Hash)
+}
+
+// return the proof of the key at the last committed version
+func (c *testChain) prove(t *testing.T, key []byte) []byte {
+	query := wrsp.RequestQuery{
+		Path:   "/" + c.key.Name() + "/key",
+		Data:   key,
+		Height: c.cms.LastCommitID().Version,
+		Prove:  true,
+	}
+	res := c.cms.(store.Queryable).Query(query)
+	require.Equal(t, uint32(0), res.Code, res.Log)
+	return res.Proof
+}
+
+// commit the chain and prove the key at the committed version
+func (c *testChain) commitAndProve(t *testing.T, key []byte) (FullCommit, []byte) {
+	fc := c.commit(t)
+	return fc, c.prove(t, key)
+}
+
+// post the commit of the counterparty chain, registering it if needed
+func (c *testChain) postCommit(t *testing.T, fc FullCommit) {
+	var res sdk.Result
+	if _, found := c.ibcm.GetLatestCommit(c.ctx, fc.ChainID()); found {
+		res = c.h(c.ctx, IBCUpdateChainMsg{fc, newAddress()})
+	} else {
+		trustChain(t, c.ctx, c.ibcm, c.ps, fc)
+		res = c.h(c.ctx, IBCRegisterChainMsg{fc, newAddress()})
+	}
+	require.True(t, res.IsOK(), res.Log)
+}
+
+// add the chain and validators of the commit to the trusted chains
+func trustChain(t *testing.T, ctx sdk.Context, ibcm Mapper, ps params.Setter, fc FullCommit) {
+	chains := append(ibcm.GetTrustedChains(ctx), TrustedChain{fc.ChainID(), fc.Header.ValidatorsHash})
+	bz, err := makeCodec().MarshalJSON(chains)
+	require.Nil(t, err)
+	require.Nil(t, ps.Change(ctx, ParamStoreKeyTrustedChains, bz))
+}
+
+// make a commit of a chain at the given height signed by all the validators
+func makeFullCommit(t *testing.T, privs []crypto.PrivKey, chainID string, height int64, appHash []byte) FullCommit {
+	vals := make([]*tmtypes.Validator, len(privs))
+	for i, priv := range privs {
+		vals[i] = tmtypes.NewValidator(priv.PubKey(), 10)
+	}
+	valset := tmtypes.NewValidatorSet(vals)
+
+	header := &tmtypes.Header{
+		ChainID:        chainID,
+		Height:         height,
+		Time:           time.Now(),
+		ValidatorsHash: valset.Hash(),
+		AppHash:        appHash,
+	}
+	blockID := tmtypes.BlockID{Hash: header.Hash()}
+
+	precommits := make([]*tmtypes.Vote, len(privs))
+	for _, priv := range privs {
+		idx, val := valset.GetByAddress(priv.PubKey().Address())
+		vote := &tmtypes.Vote{
+			ValidatorAddress: val.Address,
+			ValidatorIndex:   idx,
+			Height:           height,
+			Round:            0,
+			Timestamp:        time.Now(),
+			Type:             tmtypes.VoteTypePrecommit,
+			BlockID:          blockID,
+		}
+		sig, err := priv.Sign(vote.SignBytes(chainID))
+		require.Nil(t, err)
+		vote.Signature = sig
+		precommits[idx] = vote
+	}
+
+	return FullCommit{
+		Header:     header,
+		Commit:     &tmtypes.Commit{BlockID: blockID, Precommits: precommits},
+		Validators: valset,
+	}
+}
+
+func newPrivKeys(n int) []crypto.PrivKey {
+	privs := make([]crypto.PrivKey, n)
+	for i := 0; i < n; i++ {
+		privs[i] = crypto.GenPrivKeyEd25519()
+	}
+	return privs
 }
 
 func newAddress() crypto.Address {
@@ -47,6 +182,14 @@ func makeCodec() *wire.Codec {
 	cdc.RegisterConcrete(bank.MsgIssue{}, "test/ibc/Issue", nil)
 	cdc.RegisterConcrete(IBCTransferMsg{}, "test/ibc/IBCTransferMsg", nil)
 	cdc.RegisterConcrete(IBCReceiveMsg{}, "test/ibc/IBCReceiveMsg", nil)
+	cdc.RegisterConcrete(IBCAcknowledgementMsg{}, "test/ibc/IBCAcknowledgementMsg", nil)
+	cdc.RegisterConcrete(IBCTimeoutMsg{}, "test/ibc/IBCTimeoutMsg", nil)
+	cdc.RegisterConcrete(IBCRegisterChainMsg{}, "test/ibc/IBCRegisterChainMsg", nil)
+	cdc.RegisterConcrete(IBCUpdateChainMsg{}, "test/ibc/IBCUpdateChainMsg", nil)
+
+	// Register Payloads
+	cdc.RegisterInterface((*Payload)(nil), nil)
+	cdc.RegisterConcrete(TransferPayload{}, "test/ibc/TransferPayload", nil)
 
 	// Register AppAccount
 	cdc.RegisterInterface((*auth.Account)(nil), nil)
@@ -59,76 +202,351 @@ func makeCodec() *wire.Codec {
 func TestIBC(t *testing.T) {
 	cdc := makeCodec()
 
-	key := sdk.NewKVStoreKey("ibc")
-	ctx := defaultContext(key)
-
-	am := auth.NewAccountMapper(cdc, key, &auth.BaseAccount{})
-	ck := bank.NewKeeper(am)
+	srcChain := newTestChain(cdc, "src-chain")
+	destChain := newTestChain(cdc, "dest-chain")
 
 	src := newAddress()
 	dest := newAddress()
-	chainid := "ibcchain"
 	zero := sdk.Coins(nil)
 	mycoins := sdk.Coins{sdk.NewCoin("mycoin", 10)}
+	vouchers := sdk.Coins{sdk.NewCoin("src-chain/mycoin", 10)}
 
-	coins, _, err := ck.AddCoins(ctx, src, mycoins)
+	coins, _, err := srcChain.ck.AddCoins(srcChain.ctx, src, mycoins)
 	require.Nil(t, err)
 	require.Equal(t, mycoins, coins)
 
-	ibcm := NewMapper(cdc, key, DefaultCodespace)
-	h := NewHandler(ibcm, ck)
 	packet := IBCPacket{
-		SrcAddr:   src,
-		DestAddr:  dest,
-		Coins:     mycoins,
-		SrcChain:  chainid,
-		DestChain: chainid,
+		Payload:   TransferPayload{src, dest, mycoins},
+		SrcChain:  srcChain.chainID,
+		DestChain: destChain.chainID,
 	}
 
-	store := ctx.KVStore(key)
+	srcStore := srcChain.ctx.KVStore(srcChain.key)
 
 	var msg sdk.Msg
 	var res sdk.Result
 	var egl int64
 	var igs int64
 
-	egl = ibcm.getEgressLength(store, chainid)
+	egl = srcChain.ibcm.getEgressLength(srcStore, destChain.chainID)
 	require.Equal(t, egl, int64(0))
 
 	msg = IBCTransferMsg{
 		IBCPacket: packet,
 	}
-	res = h(ctx, msg)
+	res = srcChain.h(srcChain.ctx, msg)
 	require.True(t, res.IsOK())
 
-	coins, err = getCoins(ck, ctx, src)
+	coins, err = getCoins(srcChain.ck, srcChain.ctx, src)
 	require.Nil(t, err)
 	require.Equal(t, zero, coins)
+	coins, err = getCoins(srcChain.ck, srcChain.ctx, EscrowAddress(destChain.chainID))
+	require.Nil(t, err)
+	require.Equal(t, mycoins, coins)
 
-	egl = ibcm.getEgressLength(store, chainid)
+	egl = srcChain.ibcm.getEgressLength(srcStore, destChain.chainID)
 	require.Equal(t, egl, int64(1))
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
+	fc, proof := srcChain.commitAndProve(t, EgressKey(destChain.chainID, 0))
+
+	igs = destChain.ibcm.GetIngressSequence(destChain.ctx, srcChain.chainID)
 	require.Equal(t, igs, int64(0))
 
-	msg = IBCReceiveMsg{
+	// the source chain is not registered yet
+	receive := IBCReceiveMsg{
 		IBCPacket: packet,
 		Relayer:   src,
 		Sequence:  0,
+		Proof:     proof,
+		Height:    fc.Height(),
 	}
-	res = h(ctx, msg)
+	res = destChain.h(destChain.ctx, receive)
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeCommitNotFound), res.Code)
+
+	destChain.postCommit(t, fc)
+
+	// a relayer cannot mint more than was sent
+	forged := receive
+	forged.Payload = TransferPayload{src, dest, sdk.Coins{sdk.NewCoin("mycoin", 1000)}}
+	res = destChain.h(destChain.ctx, forged)
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidProof), res.Code)
+
+	// nor can it post a proof against another height
+	forged = receive
+	forged.Height = fc.Height() + 1
+	res = destChain.h(destChain.ctx, forged)
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeCommitNotFound), res.Code)
+
+	// nor send the packet to another chain
+	res = srcChain.h(srcChain.ctx, receive)
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidChain), res.Code)
+
+	coins, err = getCoins(destChain.ck, destChain.ctx, dest)
+	require.Nil(t, err)
+	require.Equal(t, zero, coins)
+
+	res = destChain.h(destChain.ctx, receive)
 	require.True(t, res.IsOK())
 
-	coins, err = getCoins(ck, ctx, dest)
+	coins, err = getCoins(destChain.ck, destChain.ctx, dest)
 	require.Nil(t, err)
-	require.Equal(t, mycoins, coins)
+	require.Equal(t, vouchers, coins)
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
+	igs = destChain.ibcm.GetIngressSequence(destChain.ctx, srcChain.chainID)
 	require.Equal(t, igs, int64(1))
 
-	res = h(ctx, msg)
+	res = destChain.h(destChain.ctx, receive)
 	require.False(t, res.IsOK())
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
+	igs = destChain.ibcm.GetIngressSequence(destChain.ctx, srcChain.chainID)
 	require.Equal(t, igs, int64(1))
+
+	// the vouchers go back home, where the escrowed coins are released
+	packet = IBCPacket{
+		Payload:   TransferPayload{dest, src, vouchers},
+		SrcChain:  destChain.chainID,
+		DestChain: srcChain.chainID,
+	}
+	res = destChain.h(destChain.ctx, IBCTransferMsg{packet})
+	require.True(t, res.IsOK())
+
+	coins, err = getCoins(destChain.ck, destChain.ctx, dest)
+	require.Nil(t, err)
+	require.Equal(t, zero, coins)
+	coins, err = getCoins(destChain.ck, destChain.ctx, EscrowAddress(srcChain.chainID))
+	require.Nil(t, err)
+	require.Equal(t, zero, coins)
+
+	fc, proof = destChain.commitAndProve(t, EgressKey(srcChain.chainID, 0))
+	srcChain.postCommit(t, fc)
+
+	res = srcChain.h(srcChain.ctx, IBCReceiveMsg{packet, dest, 0, proof, fc.Height()})
+	require.True(t, res.IsOK(), res.Log)
+
+	coins, err = getCoins(srcChain.ck, srcChain.ctx, src)
+	require.Nil(t, err)
+	require.Equal(t, mycoins, coins)
+	coins, err = getCoins(srcChain.ck, srcChain.ctx, EscrowAddress(destChain.chainID))
+	require.Nil(t, err)
+	require.Equal(t, zero, coins)
+}
+
+func TestIBCTimeout(t *testing.T) {
+	cdc := makeCodec()
+
+	srcChain := newTestChain(cdc, "src-chain")
+	destChain := newTestChain(cdc, "dest-chain")
+
+	src := newAddress()
+	dest := newAddress()
+	zero := sdk.Coins(nil)
+	mycoins := sdk.Coins{sdk.NewCoin("mycoin", 10)}
+
+	_, _, err := srcChain.ck.AddCoins(srcChain.ctx, src, mycoins)
+	require.Nil(t, err)
+
+	// both chains know each other at height 2
+	fc, _ := destChain.commitAndProve(t, IngressSequenceKey(srcChain.chainID))
+	srcChain.postCommit(t, fc)
+	fc, _ = srcChain.commitAndProve(t, EgressKey(destChain.chainID, 0))
+	destChain.postCommit(t, fc)
+
+	packet := NewIBCPacket(TransferPayload{src, dest, mycoins}, srcChain.chainID, destChain.chainID, 4)
+	res := srcChain.h(srcChain.ctx, IBCTransferMsg{packet})
+	require.True(t, res.IsOK())
+
+	// the packet cannot be refunded before it timed out
+	fc, proof := destChain.commitAndProve(t, IngressSequenceKey(srcChain.chainID))
+	require.Equal(t, int64(3), fc.Height())
+	srcChain.postCommit(t, fc)
+	res = srcChain.h(srcChain.ctx, IBCTimeoutMsg{packet, src, 0, 0, proof, fc.Height()})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidTimeout), res.Code)
+
+	fc, egressProof := srcChain.commitAndProve(t, EgressKey(destChain.chainID, 0))
+	destChain.postCommit(t, fc)
+	receive := IBCReceiveMsg{packet, dest, 0, egressProof, fc.Height()}
+
+	// at height 4 the packet has timed out and the destination chain skips it
+	timeoutCommit, timeoutProof := destChain.commitAndProve(t, IngressSequenceKey(srcChain.chainID))
+	require.Equal(t, int64(4), destChain.ctx.BlockHeight())
+	res = destChain.h(destChain.ctx, receive)
+	require.True(t, res.IsOK())
+	coins, err := getCoins(destChain.ck, destChain.ctx, dest)
+	require.Nil(t, err)
+	require.Equal(t, zero, coins)
+	igs := destChain.ibcm.GetIngressSequence(destChain.ctx, srcChain.chainID)
+	require.Equal(t, int64(1), igs)
+
+	skippedCommit, skippedProof := destChain.commitAndProve(t, IngressSequenceKey(srcChain.chainID))
+	srcChain.postCommit(t, timeoutCommit)
+	srcChain.postCommit(t, skippedCommit)
+
+	// the proof must show the packet was not received
+	res = srcChain.h(srcChain.ctx, IBCTimeoutMsg{packet, src, 0, 0, skippedProof, skippedCommit.Height()})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidProof), res.Code)
+	res = srcChain.h(srcChain.ctx, IBCTimeoutMsg{packet, src, 0, 1, skippedProof, skippedCommit.Height()})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidTimeout), res.Code)
+
+	// the packet must match the one that was sent
+	forged := IBCTimeoutMsg{packet, src, 0, 0, timeoutProof, timeoutCommit.Height()}
+	forged.Payload = TransferPayload{src, dest, sdk.Coins{sdk.NewCoin("mycoin", 1000)}}
+	res = srcChain.h(srcChain.ctx, forged)
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeUnknownPacket), res.Code)
+
+	timeout := IBCTimeoutMsg{packet, src, 0, 0, timeoutProof, timeoutCommit.Height()}
+	res = srcChain.h(srcChain.ctx, timeout)
+	require.True(t, res.IsOK(), res.Log)
+	coins, err = getCoins(srcChain.ck, srcChain.ctx, src)
+	require.Nil(t, err)
+	require.Equal(t, mycoins, coins)
+	coins, err = getCoins(srcChain.ck, srcChain.ctx, EscrowAddress(destChain.chainID))
+	require.Nil(t, err)
+	require.Equal(t, zero, coins)
+
+	// and only once
+	res = srcChain.h(srcChain.ctx, timeout)
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodePacketSettled), res.Code)
+}
+
+func TestIBCAcknowledgement(t *testing.T) {
+	cdc := makeCodec()
+
+	srcChain := newTestChain(cdc, "src-chain")
+	destChain := newTestChain(cdc, "dest-chain")
+
+	src := newAddress()
+	dest := newAddress()
+	zero := sdk.Coins(nil)
+	mycoins := sdk.Coins{sdk.NewCoin("mycoin", 10)}
+	// vouchers of coins the destination chain never escrowed
+	badVouchers := sdk.Coins{sdk.NewCoin("dest-chain/foo", 10)}
+
+	_, _, err := srcChain.ck.AddCoins(srcChain.ctx, src, mycoins.Plus(badVouchers))
+	require.Nil(t, err)
+
+	// both chains know each other
+	srcChain.postCommit(t, destChain.commit(t))
+	destChain.postCommit(t, srcChain.commit(t))
+
+	good := NewIBCPacket(TransferPayload{src, dest, mycoins}, srcChain.chainID, destChain.chainID, 0)
+	bad := NewIBCPacket(TransferPayload{src, dest, badVouchers}, srcChain.chainID, destChain.chainID, 0)
+	res := srcChain.h(srcChain.ctx, IBCTransferMsg{good})
+	require.True(t, res.IsOK())
+	res = srcChain.h(srcChain.ctx, IBCTransferMsg{bad})
+	require.True(t, res.IsOK())
+	coins, err := getCoins(srcChain.ck, srcChain.ctx, src)
+	require.Nil(t, err)
+	require.Equal(t, zero, coins)
+
+	fc := srcChain.commit(t)
+	goodProof := srcChain.prove(t, EgressKey(destChain.chainID, 0))
+	badProof := srcChain.prove(t, EgressKey(destChain.chainID, 1))
+	destChain.postCommit(t, fc)
+
+	// both packets are received, but only the first payload succeeds
+	res = destChain.h(destChain.ctx, IBCReceiveMsg{good, src, 0, goodProof, fc.Height()})
+	require.True(t, res.IsOK())
+	res = destChain.h(destChain.ctx, IBCReceiveMsg{bad, src, 1, badProof, fc.Height()})
+	require.True(t, res.IsOK())
+	coins, err = getCoins(destChain.ck, destChain.ctx, dest)
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("src-chain/mycoin", 10)}, coins)
+
+	goodAck, found := destChain.ibcm.GetAcknowledgement(destChain.ctx, srcChain.chainID, 0)
+	require.True(t, found)
+	require.True(t, goodAck.IsOK())
+	badAck, found := destChain.ibcm.GetAcknowledgement(destChain.ctx, srcChain.chainID, 1)
+	require.True(t, found)
+	require.False(t, badAck.IsOK())
+
+	fc = destChain.commit(t)
+	goodProof = destChain.prove(t, AcknowledgementKey(srcChain.chainID, 0))
+	badProof = destChain.prove(t, AcknowledgementKey(srcChain.chainID, 1))
+	srcChain.postCommit(t, fc)
+
+	// a successful acknowledgement keeps the coins in escrow
+	ack := IBCAcknowledgementMsg{good, goodAck, src, 0, goodProof, fc.Height()}
+	res = srcChain.h(srcChain.ctx, ack)
+	require.True(t, res.IsOK(), res.Log)
+	coins, err = getCoins(srcChain.ck, srcChain.ctx, EscrowAddress(destChain.chainID))
+	require.Nil(t, err)
+	require.Equal(t, mycoins, coins)
+	res = srcChain.h(srcChain.ctx, ack)
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodePacketSettled), res.Code)
+
+	// the acknowledgement must be the one written by the destination chain
+	res = srcChain.h(srcChain.ctx, IBCAcknowledgementMsg{bad, goodAck, src, 1, badProof, fc.Height()})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidProof), res.Code)
+
+	// a failed acknowledgement refunds the sender
+	ack = IBCAcknowledgementMsg{bad, badAck, src, 1, badProof, fc.Height()}
+	res = srcChain.h(srcChain.ctx, ack)
+	require.True(t, res.IsOK(), res.Log)
+	coins, err = getCoins(srcChain.ck, srcChain.ctx, src)
+	require.Nil(t, err)
+	require.Equal(t, badVouchers, coins)
+	res = srcChain.h(srcChain.ctx, ack)
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodePacketSettled), res.Code)
+}
+
+func TestIBCChainCommits(t *testing.T) {
+	cdc := makeCodec()
+
+	chainID := "counterparty"
+	key := sdk.NewKVStoreKey("ibc")
+	keyParams := sdk.NewKVStoreKey("params")
+	_, ctx := defaultContext("ibcchain", key, keyParams)
+
+	pk := params.NewKeeper(cdc, keyParams)
+	ibcm := NewMapper(cdc, key, DefaultCodespace).WithParams(pk.Setter())
+	ck := bank.NewKeeper(auth.NewAccountMapper(cdc, key, &auth.BaseAccount{}))
+	h := NewHandler(ibcm, ck, NewRouter().AddRoute(TransferRoute, NewTransferHandler(ck)))
+	relayer := newAddress()
+
+	privs := newPrivKeys(4)
+	otherPrivs := newPrivKeys(4)
+
+	// updates need a registered chain
+	res := h(ctx, IBCUpdateChainMsg{makeFullCommit(t, privs, chainID, 1, nil), relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeChainNotFound), res.Code)
+
+	// only trusted chains can be registered, with the trusted validators
+	res = h(ctx, IBCRegisterChainMsg{makeFullCommit(t, privs, chainID, 1, nil), relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeUntrustedChain), res.Code)
+	trustChain(t, ctx, ibcm, pk.Setter(), makeFullCommit(t, privs, chainID, 1, nil))
+	res = h(ctx, IBCRegisterChainMsg{makeFullCommit(t, otherPrivs, chainID, 1, nil), relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeUntrustedChain), res.Code)
+
+	// a commit missing signatures is rejected
+	fc := makeFullCommit(t, privs, chainID, 1, nil)
+	fc.Commit.Precommits[0], fc.Commit.Precommits[1] = nil, nil
+	res = h(ctx, IBCRegisterChainMsg{fc, relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidCommit), res.Code)
+
+	res = h(ctx, IBCRegisterChainMsg{makeFullCommit(t, privs, chainID, 1, nil), relayer})
+	require.True(t, res.IsOK())
+	res = h(ctx, IBCRegisterChainMsg{makeFullCommit(t, otherPrivs, chainID, 2, nil), relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeChainRegistered), res.Code)
+
+	// commits signed by the trusted validators are accepted
+	res = h(ctx, IBCUpdateChainMsg{makeFullCommit(t, privs, chainID, 5, []byte("apphash")), relayer})
+	require.True(t, res.IsOK())
+	fc, found := ibcm.GetLatestCommit(ctx, chainID)
+	require.True(t, found)
+	require.Equal(t, int64(5), fc.Height())
+	require.Equal(t, []byte("apphash"), fc.AppHash())
+	_, found = ibcm.GetCommit(ctx, chainID, 1)
+	require.True(t, found)
+
+	// older commits and commits of unknown validators are rejected
+	res = h(ctx, IBCUpdateChainMsg{makeFullCommit(t, privs, chainID, 4, nil), relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidCommit), res.Code)
+	res = h(ctx, IBCUpdateChainMsg{makeFullCommit(t, otherPrivs, chainID, 6, nil), relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidCommit), res.Code)
+	_, found = ibcm.GetCommit(ctx, chainID, 6)
+	require.False(t, found)
+
+	// the validator set can change if the trusted validators signed it
+	res = h(ctx, IBCUpdateChainMsg{makeFullCommit(t, append(privs, otherPrivs[0]), chainID, 7, nil), relayer})
+	require.True(t, res.IsOK())
 }
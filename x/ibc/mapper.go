@@ -5,6 +5,7 @@ import (
 
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	wire "github.com/tepleton/tepleton-sdk/wire"
+	"github.com/tepleton/tepleton-sdk/x/params"
 )
 
 // IBC Mapper
@@ -12,6 +13,7 @@ type Mapper struct {
 	key       sdk.StoreKey
 	cdc       *wire.Codec
 	codespace sdk.CodespaceType
+	params    *params.Setter // trusted counterparty chains, nil if none can be registered
 }
 
 // XXX: The Mapper should not take a CoinKeeper. Rather have the CoinKeeper
@@ -25,9 +27,20 @@ func NewMapper(cdc *wire.Codec, key sdk.StoreKey, codespace sdk.CodespaceType) M
 	}
 }
 
-// XXX: This is not the public API. This will change in MVP2 and will henceforth
-// only be invoked from another module directly and not through a user
-// transaction.
+// Set the param store holding the counterparty chains which can be
+// registered. Without it, no chain can be registered.
+func (ibcm Mapper) WithParams(setter params.Setter) Mapper {
+	if ibcm.params != nil {
+		panic("cannot set ibc params twice")
+	}
+	registerParams(setter.Getter)
+	ibcm.params = &setter
+	return ibcm
+}
+
+// PostIBCPacket writes the packet to the egress queue of its destination
+// chain, for relayers to pick up. Modules send their payloads through it and
+// register a PacketHandler in the router to handle them on the other chain.
 // TODO: Handle invalid IBC packets and return errors.
 func (ibcm Mapper) PostIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
 	// write everything into the state
@@ -48,15 +61,6 @@ func (ibcm Mapper) PostIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
 	return nil
 }
 
-// XXX: In the future every module is able to register it's own handler for
-// handling it's own IBC packets. The "ibc" handler will only route the packets
-// to the appropriate callbacks.
-// XXX: For now this handles all interactions with the CoinKeeper.
-// XXX: This needs to do some authentication checking.
-func (ibcm Mapper) ReceiveIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
-	return nil
-}
-
 // --------------------------
 // Functions for accessing the underlying KVStore.
 
@@ -114,6 +118,75 @@ func (ibcm Mapper) getEgressLength(store sdk.KVStore, destChain string) int64 {
 	return res
 }
 
+// Retrieves the bytes of an outgoing IBC packet.
+func (ibcm Mapper) getEgressPacket(ctx sdk.Context, destChain string, index int64) []byte {
+	store := ctx.KVStore(ibcm.key)
+	return store.Get(EgressKey(destChain, index))
+}
+
+// Whether the outgoing IBC packet was acknowledged or refunded after it timed out.
+func (ibcm Mapper) isSettled(ctx sdk.Context, destChain string, index int64) bool {
+	store := ctx.KVStore(ibcm.key)
+	return store.Has(SettledKey(destChain, index))
+}
+
+// Marks the outgoing IBC packet as acknowledged or refunded.
+func (ibcm Mapper) setSettled(ctx sdk.Context, destChain string, index int64) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(SettledKey(destChain, index), []byte{0x01})
+}
+
+// Retrieves the acknowledgement written for an incoming IBC packet.
+func (ibcm Mapper) GetAcknowledgement(ctx sdk.Context, srcChain string, sequence int64) (ack Acknowledgement, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(AcknowledgementKey(srcChain, sequence))
+	if bz == nil {
+		return ack, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &ack)
+	return ack, true
+}
+
+// Writes the acknowledgement of an incoming IBC packet.
+func (ibcm Mapper) setAcknowledgement(ctx sdk.Context, srcChain string, sequence int64, ack Acknowledgement) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(AcknowledgementKey(srcChain, sequence), marshalBinaryPanic(ibcm.cdc, ack))
+}
+
+// Stores the latest trusted commit of a counterparty chain and keeps the
+// commits by height, so packets can be proven against their app hashes.
+func (ibcm Mapper) SetCommit(ctx sdk.Context, fc FullCommit) {
+	store := ctx.KVStore(ibcm.key)
+	chainID, height := fc.ChainID(), fc.Height()
+
+	store.Set(CommitKey(chainID, height), marshalBinaryPanic(ibcm.cdc, fc))
+	store.Set(LatestCommitHeightKey(chainID), marshalBinaryPanic(ibcm.cdc, height))
+}
+
+// Retrieves the trusted commit of a counterparty chain at the given height.
+func (ibcm Mapper) GetCommit(ctx sdk.Context, chainID string, height int64) (fc FullCommit, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(CommitKey(chainID, height))
+	if bz == nil {
+		return fc, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &fc)
+	return fc, true
+}
+
+// Retrieves the latest trusted commit of a counterparty chain, if the chain
+// has been registered.
+func (ibcm Mapper) GetLatestCommit(ctx sdk.Context, chainID string) (fc FullCommit, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(LatestCommitHeightKey(chainID))
+	if bz == nil {
+		return fc, false
+	}
+	var height int64
+	unmarshalBinaryPanic(ibcm.cdc, bz, &height)
+	return ibcm.GetCommit(ctx, chainID, height)
+}
+
 // Stores an outgoing IBC packet under "egress/chain_id/index".
 func EgressKey(destChain string, index int64) []byte {
 	return []byte(fmt.Sprintf("egress/%s/%d", destChain, index))
@@ -128,3 +201,23 @@ func EgressLengthKey(destChain string) []byte {
 func IngressSequenceKey(srcChain string) []byte {
 	return []byte(fmt.Sprintf("ingress/%s", srcChain))
 }
+
+// Marks a settled outgoing IBC packet under "settled/chain_id/index".
+func SettledKey(destChain string, index int64) []byte {
+	return []byte(fmt.Sprintf("settled/%s/%d", destChain, index))
+}
+
+// Stores the acknowledgement of an incoming IBC packet under "ack/chain_id/sequence".
+func AcknowledgementKey(srcChain string, sequence int64) []byte {
+	return []byte(fmt.Sprintf("ack/%s/%d", srcChain, sequence))
+}
+
+// Stores a trusted commit of a counterparty chain under "commit/chain_id/height".
+func CommitKey(chainID string, height int64) []byte {
+	return []byte(fmt.Sprintf("commit/%s/%d", chainID, height))
+}
+
+// Stores the height of the latest trusted commit under "commit/chain_id".
+func LatestCommitHeightKey(chainID string) []byte {
+	return []byte(fmt.Sprintf("commit/%s", chainID))
+}
@@ -0,0 +1,78 @@
+package ibc
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/x/params"
+)
+
+// ParamStoreKeyTrustedChains is the key of the counterparty chains which can
+// be registered, changed through governance
+var ParamStoreKeyTrustedChains = params.ComposeKey("ibc", "trustedchains")
+
+// TrustedChain allows a counterparty chain to be registered with a first
+// commit signed by the validator set of the given hash. Later commits are
+// trusted through the validators of the registered commit.
+type TrustedChain struct {
+	ChainID        string `json:"chain_id"`
+	ValidatorsHash []byte `json:"validators_hash"`
+}
+
+// declare the ibc params to the param store
+func registerParams(getter params.Getter) {
+	getter.Register(ParamStoreKeyTrustedChains, []TrustedChain{}, validateTrustedChains)
+}
+
+func validateTrustedChains(_ sdk.Context, value interface{}) error {
+	chains := value.([]TrustedChain)
+	seen := make(map[string]bool, len(chains))
+	for _, chain := range chains {
+		if chain.ChainID == "" {
+			return fmt.Errorf("trusted chain without chain id")
+		}
+		if len(chain.ValidatorsHash) == 0 {
+			return fmt.Errorf("trusted chain %s without validators hash", chain.ChainID)
+		}
+		if seen[chain.ChainID] {
+			return fmt.Errorf("chain %s is trusted twice", chain.ChainID)
+		}
+		seen[chain.ChainID] = true
+	}
+	return nil
+}
+
+// GetTrustedChains returns the counterparty chains which can be registered
+func (ibcm Mapper) GetTrustedChains(ctx sdk.Context) []TrustedChain {
+	if ibcm.params == nil {
+		return nil
+	}
+	var chains []TrustedChain
+	err := ibcm.params.Get(ctx, ParamStoreKeyTrustedChains, &chains)
+	if err != nil {
+		return nil
+	}
+	return chains
+}
+
+// SetTrustedChains replaces the counterparty chains which can be registered
+func (ibcm Mapper) SetTrustedChains(ctx sdk.Context, chains []TrustedChain) {
+	if ibcm.params == nil {
+		panic("ibc params are not set")
+	}
+	err := ibcm.params.Set(ctx, ParamStoreKeyTrustedChains, chains)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// whether a chain can be registered with a commit of the given validators
+func (ibcm Mapper) isTrustedChain(ctx sdk.Context, chainID string, validatorsHash []byte) bool {
+	for _, chain := range ibcm.GetTrustedChains(ctx) {
+		if chain.ChainID == chainID {
+			return bytes.Equal(chain.ValidatorsHash, validatorsHash)
+		}
+	}
+	return false
+}
@@ -65,7 +65,12 @@ func TransferRequestHandler(cdc *wire.Codec, kb keys.Keybase) func(http.Response
 		to := sdk.Address(bz)
 
 		// build message
-		packet := ibc.NewIBCPacket(info.PubKey.Address(), to, m.Amount, m.SrcChainID, destChainID)
+		payload := ibc.TransferPayload{
+			SrcAddr:  info.PubKey.Address(),
+			DestAddr: to,
+			Coins:    m.Amount,
+		}
+		packet := ibc.NewIBCPacket(payload, m.SrcChainID, destChainID, 0)
 		msg := ibc.IBCTransferMsg{packet}
 
 		// sign
@@ -0,0 +1,65 @@
+package ibc
+
+import (
+	"regexp"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// PacketHandler handles the payloads of one module. ReceivePacket is called
+// on the destination chain and its result is written back as the
+// acknowledgement of the packet. AcknowledgePacket is called on the source
+// chain once the acknowledgement was relayed back, and TimeoutPacket when the
+// packet timed out before it was received.
+type PacketHandler interface {
+	ReceivePacket(ctx sdk.Context, packet IBCPacket) sdk.Result
+	AcknowledgePacket(ctx sdk.Context, packet IBCPacket, ack Acknowledgement) sdk.Result
+	TimeoutPacket(ctx sdk.Context, packet IBCPacket) sdk.Result
+}
+
+// Router provides packet handlers for each payload type.
+type Router interface {
+	AddRoute(r string, h PacketHandler) (rtr Router)
+	Route(path string) (h PacketHandler)
+}
+
+// map a payload type to a packet handler
+type route struct {
+	r string
+	h PacketHandler
+}
+
+type router struct {
+	routes []route
+}
+
+// nolint
+// NewRouter - create new router
+// TODO either make Function unexported or make return type (router) Exported
+func NewRouter() *router {
+	return &router{
+		routes: make([]route, 0),
+	}
+}
+
+var isAlpha = regexp.MustCompile(`^[a-zA-Z]+$`).MatchString
+
+// AddRoute - register the packet handler of a payload type
+func (rtr *router) AddRoute(r string, h PacketHandler) Router {
+	if !isAlpha(r) {
+		panic("route expressions can only contain alphanumeric characters")
+	}
+	rtr.routes = append(rtr.routes, route{r, h})
+
+	return rtr
+}
+
+// Route - get the packet handler of a payload type
+func (rtr *router) Route(path string) (h PacketHandler) {
+	for _, route := range rtr.routes {
+		if route.r == path {
+			return route.h
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package ibc
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/x/bank"
+)
+
+// TransferRoute is the route of TransferPayloads.
+const TransferRoute = "transfer"
+
+// handles the TransferPayloads of IBC packets with the coin keeper
+type transferHandler struct {
+	ck bank.Keeper
+}
+
+var _ PacketHandler = transferHandler{}
+
+// NewTransferHandler returns the packet handler of TransferPayloads.
+func NewTransferHandler(ck bank.Keeper) PacketHandler {
+	return transferHandler{ck}
+}
+
+// release or mint the coins to the receiver
+func (h transferHandler) ReceivePacket(ctx sdk.Context, packet IBCPacket) sdk.Result {
+	payload, ok := packet.Payload.(TransferPayload)
+	if !ok {
+		return ErrInvalidPayload(DefaultCodespace, "not a transfer payload").Result()
+	}
+
+	err := receivePacketCoins(ctx, h.ck, packet, payload)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
+// refund the sender if the destination chain failed to give out the coins
+func (h transferHandler) AcknowledgePacket(ctx sdk.Context, packet IBCPacket, ack Acknowledgement) sdk.Result {
+	if ack.IsOK() {
+		return sdk.Result{}
+	}
+	return h.TimeoutPacket(ctx, packet)
+}
+
+// refund the sender
+func (h transferHandler) TimeoutPacket(ctx sdk.Context, packet IBCPacket) sdk.Result {
+	payload, ok := packet.Payload.(TransferPayload)
+	if !ok {
+		return ErrInvalidPayload(DefaultCodespace, "not a transfer payload").Result()
+	}
+
+	err := refundPacketCoins(ctx, h.ck, packet, payload)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
@@ -13,6 +13,19 @@ var (
 
 func init() {
 	msgCdc = wire.NewCodec()
+	wire.RegisterCrypto(msgCdc)
+}
+
+// ------------------------------
+// Payload
+
+// Payload is the module specific content of an IBCPacket. Concrete payloads
+// must be registered on the codec, and Type names the route of the module
+// handling them, like for sdk.Msg.
+type Payload interface {
+	Type() string
+	GetSignBytes() []byte
+	ValidateBasic() sdk.Error
 }
 
 // ------------------------------
@@ -20,41 +33,39 @@ func init() {
 
 // nolint - TODO rename to Packet as IBCPacket stutters (golint)
 // IBCPacket defines a piece of data that can be send between two separate
-// blockchains.
+// blockchains. A packet with a non-zero TimeoutHeight is no longer accepted
+// by the destination chain from that height on, and can then be refunded on
+// the source chain.
 type IBCPacket struct {
-	SrcAddr   sdk.Address
-	DestAddr  sdk.Address
-	Coins     sdk.Coins
-	SrcChain  string
-	DestChain string
+	Payload       Payload
+	SrcChain      string
+	DestChain     string
+	TimeoutHeight int64
 }
 
-func NewIBCPacket(srcAddr sdk.Address, destAddr sdk.Address, coins sdk.Coins,
-	srcChain string, destChain string) IBCPacket {
+func NewIBCPacket(payload Payload, srcChain string, destChain string,
+	timeoutHeight int64) IBCPacket {
 
 	return IBCPacket{
-		SrcAddr:   srcAddr,
-		DestAddr:  destAddr,
-		Coins:     coins,
-		SrcChain:  srcChain,
-		DestChain: destChain,
+		Payload:       payload,
+		SrcChain:      srcChain,
+		DestChain:     destChain,
+		TimeoutHeight: timeoutHeight,
 	}
 }
 
 //nolint
 func (p IBCPacket) GetSignBytes() []byte {
 	b, err := msgCdc.MarshalJSON(struct {
-		SrcAddr   string
-		DestAddr  string
-		Coins     sdk.Coins
-		SrcChain  string
-		DestChain string
+		Payload       json.RawMessage
+		SrcChain      string
+		DestChain     string
+		TimeoutHeight int64
 	}{
-		SrcAddr:   sdk.MustBech32ifyAcc(p.SrcAddr),
-		DestAddr:  sdk.MustBech32ifyAcc(p.DestAddr),
-		Coins:     p.Coins,
-		SrcChain:  p.SrcChain,
-		DestChain: p.DestChain,
+		Payload:       json.RawMessage(p.Payload.GetSignBytes()),
+		SrcChain:      p.SrcChain,
+		DestChain:     p.DestChain,
+		TimeoutHeight: p.TimeoutHeight,
 	})
 	if err != nil {
 		panic(err)
@@ -67,6 +78,78 @@ func (p IBCPacket) ValidateBasic() sdk.Error {
 	if p.SrcChain == p.DestChain {
 		return ErrIdenticalChains(DefaultCodespace).TraceSDK("")
 	}
+	if p.TimeoutHeight < 0 {
+		return ErrInvalidTimeout(DefaultCodespace, "timeout height cannot be negative")
+	}
+	if p.Payload == nil {
+		return ErrInvalidPayload(DefaultCodespace, "missing payload")
+	}
+	return p.Payload.ValidateBasic()
+}
+
+// whether the packet can no longer be received at the given height
+func (p IBCPacket) TimedOut(height int64) bool {
+	return p.TimeoutHeight > 0 && height >= p.TimeoutHeight
+}
+
+// ------------------------------
+// Acknowledgement
+
+// Acknowledgement is written back by the destination chain for every packet
+// it handled, so the source chain can learn whether the payload succeeded.
+type Acknowledgement struct {
+	Code sdk.WRSPCodeType
+	Data []byte
+}
+
+// NewAcknowledgement creates the acknowledgement of a payload handler result.
+func NewAcknowledgement(res sdk.Result) Acknowledgement {
+	return Acknowledgement{
+		Code: res.Code,
+		Data: res.Data,
+	}
+}
+
+// whether the payload was handled successfully
+func (ack Acknowledgement) IsOK() bool {
+	return ack.Code.IsOK()
+}
+
+// ------------------------------
+// TransferPayload
+
+// TransferPayload moves coins to an address on another chain. Coins native to
+// the source chain are held in escrow there and minted as vouchers on the
+// destination chain, vouchers are burned and released from escrow when they
+// go back home.
+type TransferPayload struct {
+	SrcAddr  sdk.Address
+	DestAddr sdk.Address
+	Coins    sdk.Coins
+}
+
+// nolint
+func (p TransferPayload) Type() string { return TransferRoute }
+
+//nolint
+func (p TransferPayload) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		SrcAddr  string
+		DestAddr string
+		Coins    sdk.Coins
+	}{
+		SrcAddr:  sdk.MustBech32ifyAcc(p.SrcAddr),
+		DestAddr: sdk.MustBech32ifyAcc(p.DestAddr),
+		Coins:    p.Coins,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// validate the transfer payload
+func (p TransferPayload) ValidateBasic() sdk.Error {
 	if !p.Coins.IsValid() {
 		return sdk.ErrInvalidCoins("")
 	}
@@ -77,7 +160,8 @@ func (p IBCPacket) ValidateBasic() sdk.Error {
 // IBCTransferMsg
 
 // nolint - TODO rename to TransferMsg as folks will reference with ibc.TransferMsg
-// IBCTransferMsg defines how another module can send an IBCPacket.
+// IBCTransferMsg defines how another module can send an IBCPacket with a
+// TransferPayload.
 type IBCTransferMsg struct {
 	IBCPacket
 }
@@ -86,7 +170,10 @@ type IBCTransferMsg struct {
 func (msg IBCTransferMsg) Type() string { return "ibc" }
 
 // x/bank/tx.go MsgSend.GetSigners()
-func (msg IBCTransferMsg) GetSigners() []sdk.Address { return []sdk.Address{msg.SrcAddr} }
+func (msg IBCTransferMsg) GetSigners() []sdk.Address {
+	payload, _ := msg.Payload.(TransferPayload)
+	return []sdk.Address{payload.SrcAddr}
+}
 
 // get the sign bytes for ibc transfer message
 func (msg IBCTransferMsg) GetSignBytes() []byte {
@@ -95,6 +182,9 @@ func (msg IBCTransferMsg) GetSignBytes() []byte {
 
 // validate ibc transfer message
 func (msg IBCTransferMsg) ValidateBasic() sdk.Error {
+	if _, ok := msg.Payload.(TransferPayload); !ok {
+		return ErrInvalidPayload(DefaultCodespace, "transfer requires a transfer payload")
+	}
 	return msg.IBCPacket.ValidateBasic()
 }
 
@@ -103,16 +193,19 @@ func (msg IBCTransferMsg) ValidateBasic() sdk.Error {
 
 // nolint - TODO rename to ReceiveMsg as folks will reference with ibc.ReceiveMsg
 // IBCReceiveMsg defines the message that a relayer uses to post an IBCPacket
-// to the destination chain.
+// to the destination chain. Proof is the proof of the egress queue entry of
+// the packet on the source chain, and Height is the height of the stored
+// source chain commit whose app hash it is proven against.
 type IBCReceiveMsg struct {
 	IBCPacket
 	Relayer  sdk.Address
 	Sequence int64
+	Proof    []byte
+	Height   int64
 }
 
 // nolint
-func (msg IBCReceiveMsg) Type() string             { return "ibc" }
-func (msg IBCReceiveMsg) ValidateBasic() sdk.Error { return msg.IBCPacket.ValidateBasic() }
+func (msg IBCReceiveMsg) Type() string { return "ibc" }
 
 // x/bank/tx.go MsgSend.GetSigners()
 func (msg IBCReceiveMsg) GetSigners() []sdk.Address { return []sdk.Address{msg.Relayer} }
@@ -123,13 +216,220 @@ func (msg IBCReceiveMsg) GetSignBytes() []byte {
 		IBCPacket json.RawMessage
 		Relayer   string
 		Sequence  int64
+		Proof     []byte
+		Height    int64
 	}{
 		IBCPacket: json.RawMessage(msg.IBCPacket.GetSignBytes()),
 		Relayer:   sdk.MustBech32ifyAcc(msg.Relayer),
 		Sequence:  msg.Sequence,
+		Proof:     msg.Proof,
+		Height:    msg.Height,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// validate ibc receive message
+func (msg IBCReceiveMsg) ValidateBasic() sdk.Error {
+	if len(msg.Proof) == 0 {
+		return ErrInvalidProof(DefaultCodespace, "missing proof")
+	}
+	if msg.Height <= 0 {
+		return ErrInvalidProof(DefaultCodespace, "proof height must be positive")
+	}
+	return msg.IBCPacket.ValidateBasic()
+}
+
+// ----------------------------------
+// IBCTimeoutMsg
+
+// IBCTimeoutMsg defines the message that a relayer uses to refund a packet
+// that timed out on the destination chain. Proof is the proof of the ingress
+// sequence of the destination chain for packets from this chain, which must
+// show that the packet had not been received, against the stored commit of
+// the destination chain at Height, which must be past the packet timeout.
+type IBCTimeoutMsg struct {
+	IBCPacket
+	Relayer          sdk.Address
+	Sequence         int64
+	ReceivedSequence int64
+	Proof            []byte
+	Height           int64
+}
+
+// nolint
+func (msg IBCTimeoutMsg) Type() string { return "ibc" }
+
+// x/bank/tx.go MsgSend.GetSigners()
+func (msg IBCTimeoutMsg) GetSigners() []sdk.Address { return []sdk.Address{msg.Relayer} }
+
+// get the sign bytes for ibc timeout message
+func (msg IBCTimeoutMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		IBCPacket        json.RawMessage
+		Relayer          string
+		Sequence         int64
+		ReceivedSequence int64
+		Proof            []byte
+		Height           int64
+	}{
+		IBCPacket:        json.RawMessage(msg.IBCPacket.GetSignBytes()),
+		Relayer:          sdk.MustBech32ifyAcc(msg.Relayer),
+		Sequence:         msg.Sequence,
+		ReceivedSequence: msg.ReceivedSequence,
+		Proof:            msg.Proof,
+		Height:           msg.Height,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// validate ibc timeout message
+func (msg IBCTimeoutMsg) ValidateBasic() sdk.Error {
+	if len(msg.Proof) == 0 {
+		return ErrInvalidProof(DefaultCodespace, "missing proof")
+	}
+	if msg.Height <= 0 {
+		return ErrInvalidProof(DefaultCodespace, "proof height must be positive")
+	}
+	if msg.TimeoutHeight == 0 {
+		return ErrInvalidTimeout(DefaultCodespace, "packet has no timeout")
+	}
+	if msg.ReceivedSequence > msg.Sequence {
+		return ErrInvalidTimeout(DefaultCodespace, "packet was received")
+	}
+	return msg.IBCPacket.ValidateBasic()
+}
+
+// ----------------------------------
+// IBCAcknowledgementMsg
+
+// IBCAcknowledgementMsg defines the message that a relayer uses to post the
+// acknowledgement of a packet back to its source chain. Proof is the proof of
+// the acknowledgement written by the destination chain, against the stored
+// commit of the destination chain at Height.
+type IBCAcknowledgementMsg struct {
+	IBCPacket
+	Acknowledgement Acknowledgement
+	Relayer         sdk.Address
+	Sequence        int64
+	Proof           []byte
+	Height          int64
+}
+
+// nolint
+func (msg IBCAcknowledgementMsg) Type() string { return "ibc" }
+
+// x/bank/tx.go MsgSend.GetSigners()
+func (msg IBCAcknowledgementMsg) GetSigners() []sdk.Address { return []sdk.Address{msg.Relayer} }
+
+// get the sign bytes for ibc acknowledgement message
+func (msg IBCAcknowledgementMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		IBCPacket       json.RawMessage
+		Acknowledgement Acknowledgement
+		Relayer         string
+		Sequence        int64
+		Proof           []byte
+		Height          int64
+	}{
+		IBCPacket:       json.RawMessage(msg.IBCPacket.GetSignBytes()),
+		Acknowledgement: msg.Acknowledgement,
+		Relayer:         sdk.MustBech32ifyAcc(msg.Relayer),
+		Sequence:        msg.Sequence,
+		Proof:           msg.Proof,
+		Height:          msg.Height,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// validate ibc acknowledgement message
+func (msg IBCAcknowledgementMsg) ValidateBasic() sdk.Error {
+	if len(msg.Proof) == 0 {
+		return ErrInvalidProof(DefaultCodespace, "missing proof")
+	}
+	if msg.Height <= 0 {
+		return ErrInvalidProof(DefaultCodespace, "proof height must be positive")
+	}
+	return msg.IBCPacket.ValidateBasic()
+}
+
+// ----------------------------------
+// IBCRegisterChainMsg
+
+// IBCRegisterChainMsg registers a counterparty chain with its first trusted
+// commit. Later commits must be signed by the validators of this one.
+type IBCRegisterChainMsg struct {
+	Commit     FullCommit
+	Registrant sdk.Address
+}
+
+// nolint
+func (msg IBCRegisterChainMsg) Type() string              { return "ibc" }
+func (msg IBCRegisterChainMsg) GetSigners() []sdk.Address { return []sdk.Address{msg.Registrant} }
+
+// get the sign bytes for ibc register chain message
+func (msg IBCRegisterChainMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Commit     FullCommit
+		Registrant string
+	}{
+		Commit:     msg.Commit,
+		Registrant: sdk.MustBech32ifyAcc(msg.Registrant),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// validate ibc register chain message
+func (msg IBCRegisterChainMsg) ValidateBasic() sdk.Error {
+	if err := msg.Commit.ValidateBasic(); err != nil {
+		return ErrInvalidCommit(DefaultCodespace, err.Error())
+	}
+	return nil
+}
+
+// ----------------------------------
+// IBCUpdateChainMsg
+
+// IBCUpdateChainMsg posts a newer commit of a registered counterparty chain.
+type IBCUpdateChainMsg struct {
+	Commit  FullCommit
+	Relayer sdk.Address
+}
+
+// nolint
+func (msg IBCUpdateChainMsg) Type() string              { return "ibc" }
+func (msg IBCUpdateChainMsg) GetSigners() []sdk.Address { return []sdk.Address{msg.Relayer} }
+
+// get the sign bytes for ibc update chain message
+func (msg IBCUpdateChainMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Commit  FullCommit
+		Relayer string
+	}{
+		Commit:  msg.Commit,
+		Relayer: sdk.MustBech32ifyAcc(msg.Relayer),
 	})
 	if err != nil {
 		panic(err)
 	}
 	return b
 }
+
+// validate ibc update chain message
+func (msg IBCUpdateChainMsg) ValidateBasic() sdk.Error {
+	if err := msg.Commit.ValidateBasic(); err != nil {
+		return ErrInvalidCommit(DefaultCodespace, err.Error())
+	}
+	return nil
+}
@@ -18,6 +18,9 @@ func TestIBCPacketValidation(t *testing.T) {
 	}{
 		{true, constructIBCPacket(true)},
 		{false, constructIBCPacket(false)},
+		{false, NewIBCPacket(constructTransferPayload(), "source-chain", "dest-chain", -1)},
+		{false, NewIBCPacket(nil, "source-chain", "dest-chain", 0)},
+		{false, NewIBCPacket(TransferPayload{Coins: sdk.Coins{sdk.NewCoin("atom", 0)}}, "source-chain", "dest-chain", 0)},
 	}
 
 	for i, tc := range cases {
@@ -67,7 +70,7 @@ func TestIBCTransferMsgValidation(t *testing.T) {
 
 func TestIBCReceiveMsg(t *testing.T) {
 	packet := constructIBCPacket(true)
-	msg := IBCReceiveMsg{packet, sdk.Address([]byte("relayer")), 0}
+	msg := IBCReceiveMsg{packet, sdk.Address([]byte("relayer")), 0, []byte("proof"), 1}
 
 	require.Equal(t, msg.Type(), "ibc")
 }
@@ -80,8 +83,73 @@ func TestIBCReceiveMsgValidation(t *testing.T) {
 		valid bool
 		msg   IBCReceiveMsg
 	}{
-		{true, IBCReceiveMsg{validPacket, sdk.Address([]byte("relayer")), 0}},
-		{false, IBCReceiveMsg{invalidPacket, sdk.Address([]byte("relayer")), 0}},
+		{true, IBCReceiveMsg{validPacket, sdk.Address([]byte("relayer")), 0, []byte("proof"), 1}},
+		{false, IBCReceiveMsg{invalidPacket, sdk.Address([]byte("relayer")), 0, []byte("proof"), 1}},
+		{false, IBCReceiveMsg{validPacket, sdk.Address([]byte("relayer")), 0, nil, 1}},
+		{false, IBCReceiveMsg{validPacket, sdk.Address([]byte("relayer")), 0, []byte("proof"), 0}},
+	}
+
+	for i, tc := range cases {
+		err := tc.msg.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err, "%d: %+v", i, err)
+		} else {
+			require.NotNil(t, err, "%d", i)
+		}
+	}
+}
+
+// -------------------------------
+// IBCTimeoutMsg Tests
+
+func TestIBCTimeoutMsgValidation(t *testing.T) {
+	validPacket := constructIBCPacket(true)
+	validPacket.TimeoutHeight = 10
+	invalidPacket := constructIBCPacket(false)
+	invalidPacket.TimeoutHeight = 10
+	noTimeoutPacket := constructIBCPacket(true)
+	relayer := sdk.Address([]byte("relayer"))
+
+	cases := []struct {
+		valid bool
+		msg   IBCTimeoutMsg
+	}{
+		{true, IBCTimeoutMsg{validPacket, relayer, 1, 1, []byte("proof"), 10}},
+		{true, IBCTimeoutMsg{validPacket, relayer, 1, 0, []byte("proof"), 10}},
+		{false, IBCTimeoutMsg{invalidPacket, relayer, 1, 1, []byte("proof"), 10}},
+		{false, IBCTimeoutMsg{noTimeoutPacket, relayer, 1, 1, []byte("proof"), 10}},
+		{false, IBCTimeoutMsg{validPacket, relayer, 1, 2, []byte("proof"), 10}},
+		{false, IBCTimeoutMsg{validPacket, relayer, 1, 1, nil, 10}},
+		{false, IBCTimeoutMsg{validPacket, relayer, 1, 1, []byte("proof"), 0}},
+	}
+
+	for i, tc := range cases {
+		err := tc.msg.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err, "%d: %+v", i, err)
+		} else {
+			require.NotNil(t, err, "%d", i)
+		}
+	}
+}
+
+// -------------------------------
+// IBCAcknowledgementMsg Tests
+
+func TestIBCAcknowledgementMsgValidation(t *testing.T) {
+	validPacket := constructIBCPacket(true)
+	invalidPacket := constructIBCPacket(false)
+	ack := Acknowledgement{Code: sdk.WRSPCodeOK}
+	relayer := sdk.Address([]byte("relayer"))
+
+	cases := []struct {
+		valid bool
+		msg   IBCAcknowledgementMsg
+	}{
+		{true, IBCAcknowledgementMsg{validPacket, ack, relayer, 0, []byte("proof"), 1}},
+		{false, IBCAcknowledgementMsg{invalidPacket, ack, relayer, 0, []byte("proof"), 1}},
+		{false, IBCAcknowledgementMsg{validPacket, ack, relayer, 0, nil, 1}},
+		{false, IBCAcknowledgementMsg{validPacket, ack, relayer, 0, []byte("proof"), 0}},
 	}
 
 	for i, tc := range cases {
@@ -97,15 +165,21 @@ func TestIBCReceiveMsgValidation(t *testing.T) {
 // -------------------------------
 // Helpers
 
+func constructTransferPayload() TransferPayload {
+	return TransferPayload{
+		SrcAddr:  sdk.Address([]byte("source")),
+		DestAddr: sdk.Address([]byte("destination")),
+		Coins:    sdk.Coins{sdk.NewCoin("atom", 10)},
+	}
+}
+
 func constructIBCPacket(valid bool) IBCPacket {
-	srcAddr := sdk.Address([]byte("source"))
-	destAddr := sdk.Address([]byte("destination"))
-	coins := sdk.Coins{sdk.NewCoin("atom", 10)}
+	payload := constructTransferPayload()
 	srcChain := "source-chain"
 	destChain := "dest-chain"
 
 	if valid {
-		return NewIBCPacket(srcAddr, destAddr, coins, srcChain, destChain)
+		return NewIBCPacket(payload, srcChain, destChain, 0)
 	}
-	return NewIBCPacket(srcAddr, destAddr, coins, srcChain, srcChain)
+	return NewIBCPacket(payload, srcChain, srcChain, 0)
 }
@@ -6,6 +6,13 @@ import (
 
 // Register concrete types on wire codec
 func RegisterWire(cdc *wire.Codec) {
+	cdc.RegisterInterface((*Payload)(nil), nil)
+	cdc.RegisterConcrete(TransferPayload{}, "tepleton-sdk/TransferPayload", nil)
+
 	cdc.RegisterConcrete(IBCTransferMsg{}, "tepleton-sdk/IBCTransferMsg", nil)
 	cdc.RegisterConcrete(IBCReceiveMsg{}, "tepleton-sdk/IBCReceiveMsg", nil)
+	cdc.RegisterConcrete(IBCAcknowledgementMsg{}, "tepleton-sdk/IBCAcknowledgementMsg", nil)
+	cdc.RegisterConcrete(IBCTimeoutMsg{}, "tepleton-sdk/IBCTimeoutMsg", nil)
+	cdc.RegisterConcrete(IBCRegisterChainMsg{}, "tepleton-sdk/IBCRegisterChainMsg", nil)
+	cdc.RegisterConcrete(IBCUpdateChainMsg{}, "tepleton-sdk/IBCUpdateChainMsg", nil)
 }
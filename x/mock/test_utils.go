@@ -4,12 +4,28 @@ import (
 	"testing"
 
 	"github.com/tepleton/tepleton-sdk/baseapp"
+	"github.com/tepleton/tepleton-sdk/store"
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	"github.com/stretchr/testify/require"
 	wrsp "github.com/tepleton/tepleton/wrsp/types"
 	"github.com/tepleton/tepleton/crypto"
+	dbm "github.com/tepleton/tepleton/libs/db"
+	"github.com/tepleton/tepleton/libs/log"
 )
 
+// NewTestContext returns a context at the given block time over an IAVL store
+// in memory for each of the keys, to test a keeper without an app.
+func NewTestContext(t *testing.T, blockTime int64, keys ...*sdk.KVStoreKey) sdk.Context {
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	for _, key := range keys {
+		ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+	return sdk.NewContext(ms, wrsp.Header{Time: blockTime}, false, log.NewNopLogger())
+}
+
 // CheckBalance checks the balance of an account.
 func CheckBalance(t *testing.T, app *App, addr sdk.Address, exp sdk.Coins) {
 	ctxCheck := app.BaseApp.NewContext(true, wrsp.Header{})
@@ -0,0 +1,281 @@
+package params
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+)
+
+// Keeper manages the global parameter store. Parameters are stored under
+// "<subspace>/<key>" as amino JSON so that governance proposals can carry new
+// values without knowing the concrete go type. The modules register the go
+// type and the validator of each of their params, and only registered params
+// can be changed.
+type Keeper struct {
+	cdc      *wire.Codec
+	key      sdk.StoreKey
+	registry map[string]paramSpec // shared by the copies of the keeper
+}
+
+// ParamValidator checks a new value of a param, decoded into its registered
+// go type, against the current state of the param store
+type ParamValidator func(ctx sdk.Context, value interface{}) error
+
+// registered go type and validator of a param
+type paramSpec struct {
+	typ      reflect.Type
+	validate ParamValidator
+}
+
+// NewKeeper constructs a params keeper
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey) Keeper {
+	return Keeper{
+		cdc:      cdc,
+		key:      key,
+		registry: make(map[string]paramSpec),
+	}
+}
+
+// Getter returns a read-only view of the param store
+func (k Keeper) Getter() Getter {
+	return Getter{k}
+}
+
+// Setter returns a read/write view of the param store
+func (k Keeper) Setter() Setter {
+	return Setter{Getter{k}}
+}
+
+// ComposeKey joins a module subspace and a param key into a store key
+func ComposeKey(subspace, key string) string {
+	return fmt.Sprintf("%s/%s", subspace, key)
+}
+
+//_______________________________________________________________________
+
+// Getter exposes methods related with only getting params
+type Getter struct {
+	k Keeper
+}
+
+// Register declares the param stored under key, with a value of its go type
+// and the validator of its new values, so that it can be changed. Modules
+// register their params when their keeper is created.
+func (k Getter) Register(key string, value interface{}, validate ParamValidator) {
+	k.k.registry[key] = paramSpec{
+		typ:      reflect.TypeOf(value),
+		validate: validate,
+	}
+}
+
+// GetRaw returns the raw json encoded param, nil if not set
+func (k Getter) GetRaw(ctx sdk.Context, key string) []byte {
+	store := ctx.KVStore(k.k.key)
+	return store.Get([]byte(key))
+}
+
+// Get decodes the param stored under key into ptr
+func (k Getter) Get(ctx sdk.Context, key string, ptr interface{}) error {
+	bz := k.GetRaw(ctx, key)
+	if bz == nil {
+		return ErrParamNotFound(key)
+	}
+	return k.k.cdc.UnmarshalJSON(bz, ptr)
+}
+
+// Has returns whether a param has been set under key
+func (k Getter) Has(ctx sdk.Context, key string) bool {
+	return k.GetRaw(ctx, key) != nil
+}
+
+// GetInt64 returns an int64 param
+func (k Getter) GetInt64(ctx sdk.Context, key string) (res int64, err error) {
+	err = k.Get(ctx, key, &res)
+	return
+}
+
+// GetRat returns a sdk.Rat param
+func (k Getter) GetRat(ctx sdk.Context, key string) (res sdk.Rat, err error) {
+	err = k.Get(ctx, key, &res)
+	return
+}
+
+// GetInt64WithDefault returns an int64 param, or def if the param is not set
+func (k Getter) GetInt64WithDefault(ctx sdk.Context, key string, def int64) int64 {
+	if !k.Has(ctx, key) {
+		return def
+	}
+	res, err := k.GetInt64(ctx, key)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// GetRatWithDefault returns a sdk.Rat param, or def if the param is not set
+func (k Getter) GetRatWithDefault(ctx sdk.Context, key string, def sdk.Rat) sdk.Rat {
+	if !k.Has(ctx, key) {
+		return def
+	}
+	res, err := k.GetRat(ctx, key)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// ValidateChange checks that value can replace the param stored under key.
+// The param must be registered, and the new value must decode into its go
+// type with exactly its json structure, and pass its validator.
+func (k Getter) ValidateChange(ctx sdk.Context, key string, value []byte) error {
+	spec, ok := k.k.registry[key]
+	if !ok {
+		return fmt.Errorf("unknown param %s", key)
+	}
+	var newValue interface{}
+	if err := json.Unmarshal(value, &newValue); err != nil {
+		return fmt.Errorf("invalid json value for param %s: %v", key, err)
+	}
+	ptr := reflect.New(spec.typ)
+	if err := k.k.cdc.UnmarshalJSON(value, ptr.Interface()); err != nil {
+		return fmt.Errorf("invalid value for param %s: %v", key, err)
+	}
+
+	// fields missing from the value or unknown to the type are rejected
+	bz, err := k.k.cdc.MarshalJSON(ptr.Elem().Interface())
+	if err != nil {
+		return fmt.Errorf("invalid value for param %s: %v", key, err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(bz, &decoded); err != nil {
+		panic(err)
+	}
+	if !sameShape(decoded, newValue) {
+		return fmt.Errorf("value for param %s does not match the structure %s of its type", key, bz)
+	}
+
+	if spec.validate != nil {
+		if err := spec.validate(ctx, ptr.Elem().Interface()); err != nil {
+			return fmt.Errorf("invalid value for param %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+//_______________________________________________________________________
+
+// Setter exposes all methods including Set
+type Setter struct {
+	Getter
+}
+
+// Set encodes and stores param under key
+func (k Setter) Set(ctx sdk.Context, key string, param interface{}) error {
+	bz, err := k.k.cdc.MarshalJSON(param)
+	if err != nil {
+		return err
+	}
+	k.SetRaw(ctx, key, bz)
+	return nil
+}
+
+// SetRaw stores already encoded json under key
+func (k Setter) SetRaw(ctx sdk.Context, key string, bz []byte) {
+	store := ctx.KVStore(k.k.key)
+	store.Set([]byte(key), bz)
+}
+
+// Change validates and stores a new raw json value under key
+func (k Setter) Change(ctx sdk.Context, key string, value []byte) error {
+	if err := k.ValidateChange(ctx, key, value); err != nil {
+		return err
+	}
+	k.SetRaw(ctx, key, value)
+	return nil
+}
+
+//_______________________________________________________________________
+
+// PositiveInt64 validates int64 params greater than zero
+func PositiveInt64(ctx sdk.Context, value interface{}) error {
+	if value.(int64) <= 0 {
+		return fmt.Errorf("%d is not positive", value)
+	}
+	return nil
+}
+
+// NonNegativeInt64 validates int64 params greater than or equal to zero
+func NonNegativeInt64(ctx sdk.Context, value interface{}) error {
+	if value.(int64) < 0 {
+		return fmt.Errorf("%d is negative", value)
+	}
+	return nil
+}
+
+// RatBetween returns a validator of sdk.Rat params within [min, max]
+func RatBetween(min, max sdk.Rat) ParamValidator {
+	return func(ctx sdk.Context, value interface{}) error {
+		return CheckRatBetween(value.(sdk.Rat), min, max)
+	}
+}
+
+// CheckRatBetween checks that a rational is within [min, max]
+func CheckRatBetween(r, min, max sdk.Rat) error {
+	if r.LT(min) || r.GT(max) {
+		return fmt.Errorf("%v is not between %v and %v", r, min, max)
+	}
+	return nil
+}
+
+//_______________________________________________________________________
+
+// ErrParamNotFound is returned when reading a param which was never set
+func ErrParamNotFound(key string) error {
+	return fmt.Errorf("param %s not found", key)
+}
+
+// sameShape reports whether two decoded json values have the same kind and,
+// for objects and arrays, the same fields or elements with the same shapes
+func sameShape(a, b interface{}) bool {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		bm, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(bm) {
+			return false
+		}
+		for field, av := range a {
+			bv, ok := bm[field]
+			if !ok || !sameShape(av, bv) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bs, ok := b.([]interface{})
+		if !ok || len(a) != len(bs) {
+			return false
+		}
+		for i := range a {
+			if !sameShape(a[i], bs[i]) {
+				return false
+			}
+		}
+		return true
+	case string:
+		_, ok := b.(string)
+		return ok
+	case float64:
+		_, ok := b.(float64)
+		return ok
+	case bool:
+		_, ok := b.(bool)
+		return ok
+	case nil:
+		return true
+	default:
+		return false
+	}
+}
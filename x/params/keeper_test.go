@@ -0,0 +1,94 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+	"github.com/tepleton/tepleton-sdk/x/mock"
+)
+
+type testParams struct {
+	Rate   sdk.Rat `json:"rate"`
+	Period int64   `json:"period"`
+}
+
+func TestKeeper(t *testing.T) {
+	key := sdk.NewKVStoreKey("params")
+	ctx := mock.NewTestContext(t, 0, key)
+	setter := NewKeeper(wire.NewCodec(), key).Setter()
+
+	// unset params fall back to the default
+	require.False(t, setter.Has(ctx, "test/int"))
+	require.Equal(t, int64(5), setter.GetInt64WithDefault(ctx, "test/int", 5))
+	_, err := setter.GetInt64(ctx, "test/int")
+	require.NotNil(t, err)
+
+	require.Nil(t, setter.Set(ctx, "test/int", int64(10)))
+	require.Equal(t, int64(10), setter.GetInt64WithDefault(ctx, "test/int", 5))
+
+	require.Nil(t, setter.Set(ctx, "test/rat", sdk.NewRat(1, 3)))
+	rat, err := setter.GetRat(ctx, "test/rat")
+	require.Nil(t, err)
+	require.True(t, sdk.NewRat(1, 3).Equal(rat))
+
+	params := testParams{sdk.NewRat(1, 2), 100}
+	require.Nil(t, setter.Set(ctx, ComposeKey("test", "params"), params))
+	var got testParams
+	require.Nil(t, setter.Get(ctx, "test/params", &got))
+	require.True(t, params.Rate.Equal(got.Rate))
+	require.Equal(t, params.Period, got.Period)
+}
+
+func TestChange(t *testing.T) {
+	key := sdk.NewKVStoreKey("params")
+	ctx := mock.NewTestContext(t, 0, key)
+	setter := NewKeeper(wire.NewCodec(), key).Setter()
+
+	params := testParams{sdk.NewRat(1, 2), 100}
+	require.Nil(t, setter.Set(ctx, "test/params", params))
+	setter.Register("test/params", testParams{}, func(ctx sdk.Context, value interface{}) error {
+		if err := CheckRatBetween(value.(testParams).Rate, sdk.ZeroRat(), sdk.OneRat()); err != nil {
+			return err
+		}
+		return PositiveInt64(ctx, value.(testParams).Period)
+	})
+	setter.Register("test/int", int64(0), NonNegativeInt64)
+
+	cases := []struct {
+		value string
+		valid bool
+	}{
+		{`{"rate":"1/3","period":"200"}`, true},
+		{`{"rate":"1/3"}`, false},
+		{`{"rate":"1/3","period":"200","extra":"1"}`, false},
+		{`{"rate":{},"period":"200"}`, false},
+		{`"1/3"`, false},
+		{`not json`, false},
+		{`{"rate":"3/2","period":"200"}`, false},
+		{`{"rate":"-1/3","period":"200"}`, false},
+		{`{"rate":"1/3","period":"0"}`, false},
+		{`{"rate":"1/0","period":"200"}`, false},
+		{`{"rate":null,"period":"200"}`, false},
+	}
+	for i, tc := range cases {
+		err := setter.Change(ctx, "test/params", []byte(tc.value))
+		require.Equal(t, tc.valid, err == nil, "case %d: %v", i, err)
+	}
+
+	var got testParams
+	require.Nil(t, setter.Get(ctx, "test/params", &got))
+	require.True(t, sdk.NewRat(1, 3).Equal(got.Rate))
+	require.Equal(t, int64(200), got.Period)
+
+	// registered params which have never been set can be changed
+	require.Nil(t, setter.Change(ctx, "test/int", []byte(`"10"`)))
+	require.Equal(t, int64(10), setter.GetInt64WithDefault(ctx, "test/int", 0))
+	require.NotNil(t, setter.Change(ctx, "test/int", []byte(`"-1"`)))
+
+	// unregistered params are rejected
+	require.NotNil(t, setter.Change(ctx, "test/new", []byte(`"10"`)))
+	require.False(t, setter.Has(ctx, "test/new"))
+}
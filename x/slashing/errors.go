@@ -15,6 +15,8 @@ const (
 	CodeInvalidValidator    CodeType = 101
 	CodeValidatorJailed     CodeType = 102
 	CodeValidatorNotRevoked CodeType = 103
+	CodeSelfDelegationLow   CodeType = 104
+	CodeValidatorTombstoned CodeType = 105
 )
 
 func ErrNoValidatorForAddress(codespace sdk.CodespaceType) sdk.Error {
@@ -29,3 +31,9 @@ func ErrValidatorJailed(codespace sdk.CodespaceType) sdk.Error {
 func ErrValidatorNotRevoked(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeValidatorNotRevoked, "validator not revoked, cannot be unrevoked")
 }
+func ErrSelfDelegationTooLow(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeSelfDelegationLow, "validator's self delegation is below its minimum, cannot be unrevoked")
+}
+func ErrValidatorTombstoned(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeValidatorTombstoned, "validator tombstoned for double signing, cannot be unrevoked")
+}
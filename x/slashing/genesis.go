@@ -0,0 +1,82 @@
+package slashing
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// GenesisState - all slashing state that must be provided at genesis. The
+// start heights of the signing infos are relative to the height of the
+// export, so that a chain restarted from an export keeps the signed blocks
+// window of the validators.
+type GenesisState struct {
+	SigningInfos    []SigningInfo     `json:"signing_infos"`
+	SigningBitArray []SigningBitEntry `json:"signing_bit_array"`
+}
+
+// Signing info of a validator, keyed by *validator* address (not owner address)
+type SigningInfo struct {
+	Address sdk.Address          `json:"address"`
+	Info    ValidatorSigningInfo `json:"info"`
+}
+
+// Single stored entry of the signed block bit array of a validator
+type SigningBitEntry struct {
+	Address sdk.Address `json:"address"`
+	Index   int64       `json:"index"`
+	Signed  bool        `json:"signed"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		SigningInfos:    []SigningInfo{},
+		SigningBitArray: []SigningBitEntry{},
+	}
+}
+
+// InitGenesis - store genesis signing infos and signed block bit arrays
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	for _, signingInfo := range data.SigningInfos {
+		info := signingInfo.Info
+		info.StartHeight += ctx.BlockHeight()
+		k.setValidatorSigningInfo(ctx, signingInfo.Address, info)
+	}
+	for _, entry := range data.SigningBitArray {
+		k.setValidatorSigningBitArray(ctx, entry.Address, entry.Index, entry.Signed)
+	}
+}
+
+// WriteGenesis - output all signing infos and signed block bit arrays
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	store := ctx.KVStore(k.storeKey)
+
+	signingInfos := []SigningInfo{}
+	iterator := sdk.KVStorePrefixIterator(store, ValidatorSigningInfoKey)
+	for ; iterator.Valid(); iterator.Next() {
+		var info ValidatorSigningInfo
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &info)
+		info.StartHeight -= ctx.BlockHeight()
+		address := sdk.Address(iterator.Key()[len(ValidatorSigningInfoKey):])
+		signingInfos = append(signingInfos, SigningInfo{address, info})
+	}
+	iterator.Close()
+
+	signingBitArray := []SigningBitEntry{}
+	iterator = sdk.KVStorePrefixIterator(store, ValidatorSigningBitArrayKey)
+	for ; iterator.Valid(); iterator.Next() {
+		var signed bool
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &signed)
+		key := iterator.Key()
+		address := sdk.Address(key[len(ValidatorSigningBitArrayKey) : len(key)-8])
+		index := int64(binary.LittleEndian.Uint64(key[len(key)-8:]))
+		signingBitArray = append(signingBitArray, SigningBitEntry{address, index, signed})
+	}
+	iterator.Close()
+
+	return GenesisState{
+		SigningInfos:    signingInfos,
+		SigningBitArray: signingBitArray,
+	}
+}
@@ -0,0 +1,53 @@
+package slashing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenesisRoundTrip(t *testing.T) {
+	ctx, _, _, keeper := createTestInput(t)
+
+	// record some signatures for two validators, one of which is jailed
+	for height := int64(1); height <= 5; height++ {
+		ctx = ctx.WithBlockHeight(height)
+		keeper.handleValidatorSignature(ctx, pks[0], 100, height%2 == 0)
+		keeper.handleValidatorSignature(ctx, pks[1], 100, true)
+	}
+	jailed, found := keeper.getValidatorSigningInfo(ctx, pks[1].Address())
+	require.True(t, found)
+	jailed.JailedUntil = 1000
+	keeper.setValidatorSigningInfo(ctx, pks[1].Address(), jailed)
+
+	// the start heights are exported relative to the export height
+	genesis := WriteGenesis(ctx, keeper)
+	require.Equal(t, 2, len(genesis.SigningInfos))
+	require.Equal(t, 2+5, len(genesis.SigningBitArray))
+	for _, signingInfo := range genesis.SigningInfos {
+		require.Equal(t, int64(1-5), signingInfo.Info.StartHeight)
+	}
+
+	// import into a fresh store at a different height
+	ctx2, _, _, keeper2 := createTestInput(t)
+	ctx2 = ctx2.WithBlockHeight(10)
+	InitGenesis(ctx2, keeper2, genesis)
+	require.Equal(t, genesis, WriteGenesis(ctx2, keeper2))
+
+	for _, pk := range pks[:2] {
+		info, found := keeper.getValidatorSigningInfo(ctx, pk.Address())
+		require.True(t, found)
+		info2, found := keeper2.getValidatorSigningInfo(ctx2, pk.Address())
+		require.True(t, found)
+		require.Equal(t, info.StartHeight+10-5, info2.StartHeight)
+		info2.StartHeight = info.StartHeight
+		require.Equal(t, info, info2)
+		for index := int64(0); index < 5; index++ {
+			require.Equal(t,
+				keeper.getValidatorSigningBitArray(ctx, pk.Address(), index),
+				keeper2.getValidatorSigningBitArray(ctx2, pk.Address(), index))
+		}
+	}
+	info, _ := keeper2.getValidatorSigningInfo(ctx2, pks[1].Address())
+	require.Equal(t, int64(1000), info.JailedUntil)
+}
@@ -38,6 +38,11 @@ func handleMsgUnrevoke(ctx sdk.Context, msg MsgUnrevoke, k Keeper) sdk.Result {
 		return ErrNoValidatorForAddress(k.codespace).Result()
 	}
 
+	// Cannot be unrevoked once tombstoned for double signing
+	if info.Tombstoned {
+		return ErrValidatorTombstoned(k.codespace).Result()
+	}
+
 	// Cannot be unrevoked until out of jail
 	if ctx.BlockHeader().Time < info.JailedUntil {
 		return ErrValidatorJailed(k.codespace).Result()
@@ -51,8 +56,11 @@ func handleMsgUnrevoke(ctx sdk.Context, msg MsgUnrevoke, k Keeper) sdk.Result {
 	info.StartHeight = ctx.BlockHeight()
 	k.setValidatorSigningInfo(ctx, addr, info)
 
-	// Unrevoke the validator
+	// Unrevoke the validator, which stays revoked without enough self delegation
 	k.validatorSet.Unrevoke(ctx, validator.GetPubKey())
+	if k.validatorSet.Validator(ctx, msg.ValidatorAddr).GetRevoked() {
+		return ErrSelfDelegationTooLow(k.codespace).Result()
+	}
 
 	tags := sdk.NewTags("action", []byte("unrevoke"), "validator", []byte(msg.ValidatorAddr.String()))
 
@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	"github.com/tepleton/tepleton-sdk/x/stake"
 )
@@ -26,3 +28,49 @@ func TestCannotUnrevokeUnlessRevoked(t *testing.T) {
 	require.False(t, got.IsOK(), "allowed unrevoke of non-revoked validator")
 	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeValidatorNotRevoked), got.Code)
 }
+
+func TestCannotUnrevokeBelowMinSelfDelegation(t *testing.T) {
+	// initial setup
+	ctx, _, sk, keeper := createTestInput(t)
+	slh := NewHandler(keeper)
+	addr, val, amt := addrs[0], pks[0], sdk.NewInt(100)
+	msgCreateValidator := newTestMsgCreateValidator(addr, val, amt)
+	msgCreateValidator.MinSelfDelegation = 100
+	got := stake.NewHandler(sk)(ctx, msgCreateValidator)
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+	keeper.setValidatorSigningInfo(ctx, val.Address(), NewValidatorSigningInfo(0, 0, 0, 0, false))
+
+	// unbonding below the minimum self delegation revokes the validator
+	got = stake.NewHandler(sk)(ctx, stake.NewMsgBeginUnbonding(addr, addr, sdk.NewRat(1)))
+	require.True(t, got.IsOK())
+	require.True(t, sk.Validator(ctx, addr).GetRevoked())
+
+	// which cannot be unrevoked until the self delegation is restored
+	got = slh(ctx, NewMsgUnrevoke(addr))
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeSelfDelegationLow), got.Code)
+	require.True(t, sk.Validator(ctx, addr).GetRevoked())
+}
+
+func TestCannotUnrevokeTombstoned(t *testing.T) {
+	// initial setup
+	ctx, _, sk, keeper := createTestInput(t)
+	slh := NewHandler(keeper)
+	amtInt := int64(100)
+	addr, val, amt := addrs[0], pks[0], sdk.NewInt(amtInt)
+	got := stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addr, val, amt))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+	keeper.handleValidatorSignature(ctx, val, amtInt, true)
+
+	// double sign tombstones the validator
+	keeper.handleDoubleSign(ctx, val, 0, 0, amtInt)
+	require.True(t, sk.Validator(ctx, addr).GetRevoked())
+
+	// even after the jail period, the validator cannot be unrevoked
+	ctx = ctx.WithBlockHeader(wrsp.Header{Time: 1 + keeper.DoubleSignUnbondDuration(ctx)})
+	got = slh(ctx, NewMsgUnrevoke(addr))
+	require.False(t, got.IsOK(), "allowed unrevoke of tombstoned validator")
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeValidatorTombstoned), got.Code)
+	require.True(t, sk.Validator(ctx, addr).GetRevoked())
+}
@@ -2,41 +2,90 @@ package slashing
 
 import (
 	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/x/params"
 )
 
+// nolint - keys of the slashing params within the global param store
 var (
-	// MaxEvidenceAge - Max age for evidence - 21 days (3 weeks)
-	// TODO Should this be a governance parameter or just modifiable with SoftwareUpgradeProposals?
-	// MaxEvidenceAge = 60 * 60 * 24 * 7 * 3
+	MaxEvidenceAgeKey           = params.ComposeKey("slashing", "maxevidenceage")
+	SignedBlocksWindowKey       = params.ComposeKey("slashing", "signedblockswindow")
+	MinSignedPerWindowKey       = params.ComposeKey("slashing", "minsignedperwindow")
+	DowntimeUnbondDurationKey   = params.ComposeKey("slashing", "downtimeunbondduration")
+	DoubleSignUnbondDurationKey = params.ComposeKey("slashing", "doublesignunbondduration")
+	SlashFractionDoubleSignKey  = params.ComposeKey("slashing", "slashfractiondoublesign")
+	SlashFractionDowntimeKey    = params.ComposeKey("slashing", "slashfractiondowntime")
+)
+
+// declare the slashing params to the param store, with their valid ranges
+func registerParams(getter params.Getter) {
+	zero, one := sdk.ZeroRat(), sdk.OneRat()
+	getter.Register(MaxEvidenceAgeKey, int64(0), params.PositiveInt64)
+	getter.Register(SignedBlocksWindowKey, int64(0), params.PositiveInt64)
+	getter.Register(MinSignedPerWindowKey, sdk.Rat{}, params.RatBetween(zero, one))
+	getter.Register(DowntimeUnbondDurationKey, int64(0), params.NonNegativeInt64)
+	getter.Register(DoubleSignUnbondDurationKey, int64(0), params.NonNegativeInt64)
+	getter.Register(SlashFractionDoubleSignKey, sdk.Rat{}, params.RatBetween(zero, one))
+	getter.Register(SlashFractionDowntimeKey, sdk.Rat{}, params.RatBetween(zero, one))
+}
+
+// MaxEvidenceAge - Max age for evidence - 21 days (3 weeks)
+func (k Keeper) MaxEvidenceAge(ctx sdk.Context) int64 {
+	return k.params.GetInt64WithDefault(ctx, MaxEvidenceAgeKey, defaultMaxEvidenceAge)
+}
+
+// SignedBlocksWindow - sliding window for downtime slashing
+func (k Keeper) SignedBlocksWindow(ctx sdk.Context) int64 {
+	return k.params.GetInt64WithDefault(ctx, SignedBlocksWindowKey, defaultSignedBlocksWindow)
+}
+
+// Downtime slashing threshold - the minimum number of blocks
+// signed within the window, from the param store ratio
+func (k Keeper) MinSignedPerWindow(ctx sdk.Context) int64 {
+	minSignedPerWindow := k.params.GetRatWithDefault(ctx, MinSignedPerWindowKey, defaultMinSignedPerWindow)
+	signedBlocksWindow := k.SignedBlocksWindow(ctx)
+	return sdk.NewRat(signedBlocksWindow).Mul(minSignedPerWindow).RoundInt64()
+}
+
+// Downtime unbond duration
+func (k Keeper) DowntimeUnbondDuration(ctx sdk.Context) int64 {
+	return k.params.GetInt64WithDefault(ctx, DowntimeUnbondDurationKey, defaultDowntimeUnbondDuration)
+}
+
+// Double-sign unbond duration
+func (k Keeper) DoubleSignUnbondDuration(ctx sdk.Context) int64 {
+	return k.params.GetInt64WithDefault(ctx, DoubleSignUnbondDurationKey, defaultDoubleSignUnbondDuration)
+}
+
+// SlashFractionDoubleSign - currently default 5%
+func (k Keeper) SlashFractionDoubleSign(ctx sdk.Context) sdk.Rat {
+	return k.params.GetRatWithDefault(ctx, SlashFractionDoubleSignKey, defaultSlashFractionDoubleSign)
+}
+
+// SlashFractionDowntime - currently default 1%
+func (k Keeper) SlashFractionDowntime(ctx sdk.Context) sdk.Rat {
+	return k.params.GetRatWithDefault(ctx, SlashFractionDowntimeKey, defaultSlashFractionDowntime)
+}
+
+// defaults used while a param has not been set in the param store
+var (
+	// defaultMaxEvidenceAge = 60 * 60 * 24 * 7 * 3
 	// TODO Temporarily set to 2 minutes for testnets.
-	MaxEvidenceAge int64 = 60 * 2
+	defaultMaxEvidenceAge int64 = 60 * 2
 
-	// SignedBlocksWindow - sliding window for downtime slashing
-	// TODO Governance parameter?
 	// TODO Temporarily set to 40000 blocks for testnets
-	SignedBlocksWindow int64 = 40000
-
-	// Downtime slashing threshold - 50%
-	// TODO Governance parameter?
-	MinSignedPerWindow = SignedBlocksWindow / 2
+	defaultSignedBlocksWindow int64 = 40000
 
-	// Downtime unbond duration
-	// TODO Governance parameter?
 	// TODO Temporarily set to five minutes for testnets
-	DowntimeUnbondDuration int64 = 60 * 5
+	defaultDowntimeUnbondDuration int64 = 60 * 5
 
-	// Double-sign unbond duration
-	// TODO Governance parameter?
 	// TODO Temporarily set to five minutes for testnets
-	DoubleSignUnbondDuration int64 = 60 * 5
+	defaultDoubleSignUnbondDuration int64 = 60 * 5
 )
 
 var (
-	// SlashFractionDoubleSign - currently 5%
-	// TODO Governance parameter?
-	SlashFractionDoubleSign = sdk.NewRat(1).Quo(sdk.NewRat(20))
+	defaultMinSignedPerWindow = sdk.NewRat(1, 2)
+
+	defaultSlashFractionDoubleSign = sdk.NewRat(1).Quo(sdk.NewRat(20))
 
-	// SlashFractionDowntime - currently 1%
-	// TODO Governance parameter?
-	SlashFractionDowntime = sdk.NewRat(1).Quo(sdk.NewRat(100))
+	defaultSlashFractionDowntime = sdk.NewRat(1).Quo(sdk.NewRat(100))
 )
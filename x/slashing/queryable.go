@@ -0,0 +1,46 @@
+package slashing
+
+import (
+	"fmt"
+
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// query endpoints supported by the slashing querier
+const (
+	QuerySigningInfo = "signingInfo"
+)
+
+// QuerySigningInfoParams - params of the signing info query, as JSON data
+type QuerySigningInfoParams struct {
+	ValidatorAddr sdk.Address `json:"validator_addr"` // validator address, not owner address
+}
+
+// NewQuerier answers the custom slashing queries, with JSON responses
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req wrsp.RequestQuery) (res []byte, err sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no slashing query endpoint")
+		}
+		switch path[0] {
+		case QuerySigningInfo:
+			return querySigningInfo(ctx, k, req)
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown slashing query endpoint %s", path[0]))
+		}
+	}
+}
+
+func querySigningInfo(ctx sdk.Context, k Keeper, req wrsp.RequestQuery) ([]byte, sdk.Error) {
+	var params QuerySigningInfoParams
+	if err := sdk.UnmarshalQueryParams(k.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+	info, found := k.getValidatorSigningInfo(ctx, params.ValidatorAddr)
+	if !found {
+		return nil, ErrNoValidatorForAddress(k.codespace)
+	}
+	return sdk.MarshalQueryResult(k.cdc, info)
+}
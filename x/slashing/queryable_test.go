@@ -0,0 +1,34 @@
+package slashing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+)
+
+func TestQuerySigningInfo(t *testing.T) {
+	ctx, _, _, keeper := createTestInput(t)
+	querier := NewQuerier(keeper)
+	info := ValidatorSigningInfo{
+		StartHeight:         int64(4),
+		IndexOffset:         int64(3),
+		JailedUntil:         int64(2),
+		SignedBlocksCounter: int64(10),
+	}
+	keeper.setValidatorSigningInfo(ctx, addrs[0], info)
+
+	data, err := keeper.cdc.MarshalJSON(QuerySigningInfoParams{addrs[0]})
+	require.Nil(t, err)
+	res, queryErr := querier(ctx, []string{QuerySigningInfo}, wrsp.RequestQuery{Data: data})
+	require.Nil(t, queryErr)
+	var queried ValidatorSigningInfo
+	require.Nil(t, keeper.cdc.UnmarshalJSON(res, &queried))
+	require.Equal(t, info, queried)
+
+	// no signing info for the address
+	data, err = keeper.cdc.MarshalJSON(QuerySigningInfoParams{addrs[1]})
+	require.Nil(t, err)
+	_, queryErr = querier(ctx, []string{QuerySigningInfo}, wrsp.RequestQuery{Data: data})
+	require.Equal(t, CodeInvalidValidator, queryErr.Code())
+}
@@ -48,12 +48,13 @@ func (k Keeper) setValidatorSigningBitArray(ctx sdk.Context, address sdk.Address
 }
 
 // Construct a new `ValidatorSigningInfo` struct
-func NewValidatorSigningInfo(startHeight int64, indexOffset int64, jailedUntil int64, signedBlocksCounter int64) ValidatorSigningInfo {
+func NewValidatorSigningInfo(startHeight int64, indexOffset int64, jailedUntil int64, signedBlocksCounter int64, tombstoned bool) ValidatorSigningInfo {
 	return ValidatorSigningInfo{
 		StartHeight:         startHeight,
 		IndexOffset:         indexOffset,
 		JailedUntil:         jailedUntil,
 		SignedBlocksCounter: signedBlocksCounter,
+		Tombstoned:          tombstoned,
 	}
 }
 
@@ -63,22 +64,29 @@ type ValidatorSigningInfo struct {
 	IndexOffset         int64 `json:"index_offset"`          // index offset into signed block bit array
 	JailedUntil         int64 `json:"jailed_until"`          // timestamp validator cannot be unrevoked until
 	SignedBlocksCounter int64 `json:"signed_blocks_counter"` // signed blocks counter (to avoid scanning the array every time)
+	Tombstoned          bool  `json:"tombstoned"`            // whether the validator double signed, in which case it can never be unrevoked or slashed again
 }
 
 // Return human readable signing info
 func (i ValidatorSigningInfo) HumanReadableString() string {
-	return fmt.Sprintf("Start height: %d, index offset: %d, jailed until: %d, signed blocks counter: %d",
-		i.StartHeight, i.IndexOffset, i.JailedUntil, i.SignedBlocksCounter)
+	return fmt.Sprintf("Start height: %d, index offset: %d, jailed until: %d, signed blocks counter: %d, tombstoned: %t",
+		i.StartHeight, i.IndexOffset, i.JailedUntil, i.SignedBlocksCounter, i.Tombstoned)
 }
 
+// nolint - prefixes of the signing info and signed block bit array keys
+var (
+	ValidatorSigningInfoKey     = []byte{0x01}
+	ValidatorSigningBitArrayKey = []byte{0x02}
+)
+
 // Stored by *validator* address (not owner address)
 func GetValidatorSigningInfoKey(v sdk.Address) []byte {
-	return append([]byte{0x01}, v.Bytes()...)
+	return append(ValidatorSigningInfoKey, v.Bytes()...)
 }
 
 // Stored by *validator* address (not owner address)
 func GetValidatorSigningBitArrayKey(v sdk.Address, i int64) []byte {
 	b := make([]byte, 8)
 	binary.LittleEndian.PutUint64(b, uint64(i))
-	return append([]byte{0x02}, append(v.Bytes(), b...)...)
+	return append(ValidatorSigningBitArrayKey, append(v.Bytes(), b...)...)
 }
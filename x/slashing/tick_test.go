@@ -45,9 +45,11 @@ func TestBeginBlocker(t *testing.T) {
 	require.Equal(t, int64(1), info.SignedBlocksCounter)
 
 	height := int64(0)
+	signedBlocksWindow := keeper.SignedBlocksWindow(ctx)
+	minSignedPerWindow := keeper.MinSignedPerWindow(ctx)
 
 	// for 1000 blocks, mark the validator as having signed
-	for ; height < SignedBlocksWindow; height++ {
+	for ; height < signedBlocksWindow; height++ {
 		ctx = ctx.WithBlockHeight(height)
 		req = wrsp.RequestBeginBlock{
 			Validators: []wrsp.SigningValidator{{
@@ -59,7 +61,7 @@ func TestBeginBlocker(t *testing.T) {
 	}
 
 	// for 500 blocks, mark the validator as having not signed
-	for ; height < ((SignedBlocksWindow * 2) - MinSignedPerWindow + 1); height++ {
+	for ; height < ((signedBlocksWindow * 2) - minSignedPerWindow + 1); height++ {
 		ctx = ctx.WithBlockHeight(height)
 		req = wrsp.RequestBeginBlock{
 			Validators: []wrsp.SigningValidator{{
@@ -289,11 +289,11 @@ func GetCmdQueryUnbondingDelegations(storeName string, cdc *wire.Codec) *cobra.C
 	return cmd
 }
 
-// get the command to query a single unbonding-delegation record
+// get the command to query a single redelegation record
 func GetCmdQueryRedelegation(storeName string, cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "unbonding-delegation",
-		Short: "Query an unbonding-delegation record based on delegator and validator address",
+		Use:   "redelegation",
+		Short: "Query a redelegation record based on delegator and source and destination validator addresses",
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			valSrcAddr, err := sdk.GetAccAddressBech32(viper.GetString(FlagAddressValidatorSrc))
@@ -344,11 +344,11 @@ func GetCmdQueryRedelegation(storeName string, cdc *wire.Codec) *cobra.Command {
 	return cmd
 }
 
-// get the command to query all the unbonding-delegation records for a delegator
+// get the command to query all the redelegation records for a delegator
 func GetCmdQueryRedelegations(storeName string, cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "unbonding-delegations [delegator-addr]",
-		Short: "Query all unbonding-delegations records for one delegator",
+		Use:   "redelegations [delegator-addr]",
+		Short: "Query all redelegations records for one delegator",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
@@ -383,3 +383,168 @@ func GetCmdQueryRedelegations(storeName string, cdc *wire.Codec) *cobra.Command
 	}
 	return cmd
 }
+
+// get the command to query all the delegations made to one validator
+func GetCmdQueryValidatorDelegations(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validator-delegations [owner-addr]",
+		Short: "Query all delegations made to one validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			validatorAddr, err := sdk.GetValAddressBech32(args[0])
+			if err != nil {
+				return err
+			}
+			ctx := context.NewCoreContextFromViper()
+
+			resValues, err := queryIndexed(ctx, cdc, stake.GetDelegationsByValIndexKey(validatorAddr, cdc), storeName)
+			if err != nil {
+				return err
+			}
+
+			// parse out the delegations
+			var delegations []stake.Delegation
+			for _, value := range resValues {
+				var delegation stake.Delegation
+				cdc.MustUnmarshalBinary(value, &delegation)
+				delegations = append(delegations, delegation)
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				for _, delegation := range delegations {
+					resp, err := delegation.HumanReadableString()
+					if err != nil {
+						return err
+					}
+					fmt.Println(resp)
+				}
+			case "json":
+				output, err := wire.MarshalJSONIndent(cdc, delegations)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(output))
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// get the command to query all the unbonding-delegation records from one validator
+func GetCmdQueryValidatorUnbondingDelegations(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validator-unbonding-delegations [owner-addr]",
+		Short: "Query all unbonding-delegations records from one validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			validatorAddr, err := sdk.GetValAddressBech32(args[0])
+			if err != nil {
+				return err
+			}
+			ctx := context.NewCoreContextFromViper()
+			resValues, err := queryIndexed(ctx, cdc, stake.GetUBDsByValIndexKey(validatorAddr, cdc), storeName)
+			if err != nil {
+				return err
+			}
+
+			// parse out the unbonding delegations
+			var ubds []stake.UnbondingDelegation
+			for _, value := range resValues {
+				var ubd stake.UnbondingDelegation
+				cdc.MustUnmarshalBinary(value, &ubd)
+				ubds = append(ubds, ubd)
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				for _, ubd := range ubds {
+					resp, err := ubd.HumanReadableString()
+					if err != nil {
+						return err
+					}
+					fmt.Println(resp)
+				}
+			case "json":
+				output, err := wire.MarshalJSONIndent(cdc, ubds)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(output))
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// get the command to query all the redelegation records away from or towards one validator
+func GetCmdQueryValidatorRedelegations(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validator-redelegations [owner-addr]",
+		Short: "Query all redelegations records away from or towards one validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			validatorAddr, err := sdk.GetValAddressBech32(args[0])
+			if err != nil {
+				return err
+			}
+			ctx := context.NewCoreContextFromViper()
+			srcValues, err := queryIndexed(ctx, cdc, stake.GetREDsFromValSrcIndexKey(validatorAddr, cdc), storeName)
+			if err != nil {
+				return err
+			}
+			dstValues, err := queryIndexed(ctx, cdc, stake.GetREDsToValDstIndexKey(validatorAddr, cdc), storeName)
+			if err != nil {
+				return err
+			}
+
+			// parse out the redelegations
+			var reds []stake.Redelegation
+			for _, value := range append(srcValues, dstValues...) {
+				var red stake.Redelegation
+				cdc.MustUnmarshalBinary(value, &red)
+				reds = append(reds, red)
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				for _, red := range reds {
+					resp, err := red.HumanReadableString()
+					if err != nil {
+						return err
+					}
+					fmt.Println(resp)
+				}
+			case "json":
+				output, err := wire.MarshalJSONIndent(cdc, reds)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(output))
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// query the records whose keys are stored as the values of an index
+func queryIndexed(ctx context.CoreContext, cdc *wire.Codec, indexKey []byte, storeName string) (values [][]byte, err error) {
+	resKVs, err := ctx.QuerySubspace(cdc, indexKey, storeName)
+	if err != nil {
+		return nil, err
+	}
+	for _, KV := range resKVs {
+		res, err := ctx.QueryStore(KV.Value, storeName)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, res)
+	}
+	return values, nil
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -50,7 +51,16 @@ func GetCmdCreateValidator(cdc *wire.Codec) *cobra.Command {
 				Website:  viper.GetString(FlagWebsite),
 				Details:  viper.GetString(FlagDetails),
 			}
-			msg := stake.NewMsgCreateValidator(validatorAddr, pk, amount, description)
+			commission, err := getCommission(
+				viper.GetString(FlagCommissionRate),
+				viper.GetString(FlagCommissionMaxRate),
+				viper.GetString(FlagCommissionMaxChangeRate),
+			)
+			if err != nil {
+				return err
+			}
+			minSelfDelegation := viper.GetInt64(FlagMinSelfDelegation)
+			msg := stake.NewMsgCreateValidator(validatorAddr, pk, amount, description, commission, minSelfDelegation)
 
 			// build and sign the transaction, then broadcast to Tendermint
 			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
@@ -66,6 +76,8 @@ func GetCmdCreateValidator(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(fsPk)
 	cmd.Flags().AddFlagSet(fsAmount)
 	cmd.Flags().AddFlagSet(fsDescription)
+	cmd.Flags().AddFlagSet(fsCommission)
+	cmd.Flags().Int64(FlagMinSelfDelegation, 0, "minimum amount of tokens the owner must keep delegated to the validator")
 	cmd.Flags().AddFlagSet(fsValidator)
 	return cmd
 }
@@ -87,7 +99,27 @@ func GetCmdEditValidator(cdc *wire.Codec) *cobra.Command {
 				Website:  viper.GetString(FlagWebsite),
 				Details:  viper.GetString(FlagDetails),
 			}
-			msg := stake.NewMsgEditValidator(validatorAddr, description)
+
+			// the commission is only modified if a new rate is provided
+			var commissionRate *sdk.Rat
+			if rateStr := viper.GetString(FlagCommissionRate); rateStr != "" {
+				rate, err := sdk.NewRatFromDecimal(rateStr, types.MaxBondDenominatorPrecision)
+				if err != nil {
+					return err
+				}
+				commissionRate = &rate
+			}
+
+			// the minimum self delegation is only modified if a new value is provided
+			var minSelfDelegation *int64
+			if minStr := viper.GetString(FlagMinSelfDelegation); minStr != "" {
+				min, err := strconv.ParseInt(minStr, 10, 64)
+				if err != nil {
+					return err
+				}
+				minSelfDelegation = &min
+			}
+			msg := stake.NewMsgEditValidator(validatorAddr, description, commissionRate, minSelfDelegation)
 
 			// build and sign the transaction, then broadcast to Tendermint
 			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
@@ -103,6 +135,8 @@ func GetCmdEditValidator(cdc *wire.Codec) *cobra.Command {
 	}
 
 	cmd.Flags().AddFlagSet(fsDescription)
+	cmd.Flags().String(FlagCommissionRate, "", "new commission rate charged to delegators, as a decimal")
+	cmd.Flags().String(FlagMinSelfDelegation, "", "new minimum self delegation, which can only increase")
 	cmd.Flags().AddFlagSet(fsValidator)
 	return cmd
 }
@@ -390,3 +424,20 @@ func GetCmdCompleteUnbonding(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(fsValidator)
 	return cmd
 }
+
+// parse the commission rates of a new validator
+func getCommission(rateStr, maxRateStr, maxChangeRateStr string) (commission stake.CommissionMsg, err error) {
+	rate, err := sdk.NewRatFromDecimal(rateStr, types.MaxBondDenominatorPrecision)
+	if err != nil {
+		return commission, err
+	}
+	maxRate, err := sdk.NewRatFromDecimal(maxRateStr, types.MaxBondDenominatorPrecision)
+	if err != nil {
+		return commission, err
+	}
+	maxChangeRate, err := sdk.NewRatFromDecimal(maxChangeRateStr, types.MaxBondDenominatorPrecision)
+	if err != nil {
+		return commission, err
+	}
+	return stake.NewCommissionMsg(rate, maxRate, maxChangeRate), nil
+}
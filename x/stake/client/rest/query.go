@@ -3,6 +3,7 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
@@ -35,6 +36,49 @@ func registerQueryRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec
 		"/stake/validators",
 		validatorsHandlerFn(ctx, cdc),
 	).Methods("GET")
+
+	r.HandleFunc(
+		"/stake/delegators/{delegator}/delegations",
+		delegatorHandlerFn(ctx, cdc, queryDelegatorDelegations),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/stake/delegators/{delegator}/unbonding_delegations",
+		delegatorHandlerFn(ctx, cdc, queryDelegatorUBDs),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/stake/delegators/{delegator}/redelegations",
+		delegatorHandlerFn(ctx, cdc, queryDelegatorREDs),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/stake/validators/{validator}/delegations",
+		validatorHandlerFn(ctx, cdc, queryValidatorDelegations),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/stake/validators/{validator}/unbonding_delegations",
+		validatorHandlerFn(ctx, cdc, queryValidatorUBDs),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/stake/validators/{validator}/redelegations",
+		validatorHandlerFn(ctx, cdc, queryValidatorREDs),
+	).Methods("GET")
+}
+
+// read the optional height parameter of a request, to query the state as of a past block
+func withHeight(ctx context.CoreContext, r *http.Request) (context.CoreContext, error) {
+	heightStr := r.URL.Query().Get("height")
+	if heightStr == "" {
+		return ctx, nil
+	}
+	height, err := strconv.ParseInt(heightStr, 10, 64)
+	if err != nil || height < 0 {
+		return ctx, fmt.Errorf("invalid height %q", heightStr)
+	}
+	return ctx.WithHeight(height), nil
 }
 
 // http request handler to query a delegation
@@ -62,7 +106,14 @@ func delegationHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerF
 
 		key := stake.GetDelegationKey(delegatorAddr, validatorAddr, cdc)
 
-		res, err := ctx.QueryStore(key, storeName)
+		queryCtx, err := withHeight(ctx, r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		res, err := queryCtx.QueryStore(key, storeName)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			w.Write([]byte(fmt.Sprintf("couldn't query delegation. Error: %s", err.Error())))
@@ -119,7 +170,14 @@ func ubdHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
 
 		key := stake.GetUBDKey(delegatorAddr, validatorAddr, cdc)
 
-		res, err := ctx.QueryStore(key, storeName)
+		queryCtx, err := withHeight(ctx, r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		res, err := queryCtx.QueryStore(key, storeName)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			w.Write([]byte(fmt.Sprintf("couldn't query unbonding-delegation. Error: %s", err.Error())))
@@ -184,7 +242,14 @@ func redHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
 
 		key := stake.GetREDKey(delegatorAddr, validatorSrcAddr, validatorDstAddr, cdc)
 
-		res, err := ctx.QueryStore(key, storeName)
+		queryCtx, err := withHeight(ctx, r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		res, err := queryCtx.QueryStore(key, storeName)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			w.Write([]byte(fmt.Sprintf("couldn't query redelegation. Error: %s", err.Error())))
@@ -216,6 +281,297 @@ func redHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
 	}
 }
 
+// query of all the records of one delegator or validator, returning their bech32 outputs and their count
+type addrQuery func(ctx context.CoreContext, cdc *wire.Codec, addr sdk.Address) (output interface{}, count int, err error)
+
+// http request handler to query all the records of one delegator
+func delegatorHandlerFn(ctx context.CoreContext, cdc *wire.Codec, query addrQuery) http.HandlerFunc {
+	return addrHandlerFn(ctx, cdc, "delegator", sdk.GetAccAddressBech32, query)
+}
+
+// http request handler to query all the records one validator is involved in
+func validatorHandlerFn(ctx context.CoreContext, cdc *wire.Codec, query addrQuery) http.HandlerFunc {
+	return addrHandlerFn(ctx, cdc, "validator", sdk.GetValAddressBech32, query)
+}
+
+func addrHandlerFn(ctx context.CoreContext, cdc *wire.Codec, addrVar string,
+	parseAddr func(string) (sdk.Address, error), query addrQuery) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// read parameters
+		addr, err := parseAddr(mux.Vars(r)[addrVar])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		queryCtx, err := withHeight(ctx, r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		records, count, err := query(queryCtx, cdc, addr)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query the records of the %s. Error: %s", addrVar, err.Error())))
+			return
+		}
+
+		// the query will return empty if there are no records
+		if count == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		output, err := cdc.MarshalJSON(records)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
+// query all the delegations of a delegator
+func queryDelegatorDelegations(ctx context.CoreContext, cdc *wire.Codec, delegatorAddr sdk.Address) (interface{}, int, error) {
+	kvs, err := ctx.QuerySubspace(cdc, stake.GetDelegationsKey(delegatorAddr, cdc), storeName)
+	if err != nil {
+		return nil, 0, err
+	}
+	var outputs []DelegationOutput
+	for _, kv := range kvs {
+		output, err := decodeDelegation(cdc, kv.Value)
+		if err != nil {
+			return nil, 0, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, len(outputs), nil
+}
+
+// query all the delegations to a validator through their validator index
+func queryValidatorDelegations(ctx context.CoreContext, cdc *wire.Codec, validatorAddr sdk.Address) (interface{}, int, error) {
+	values, err := queryIndexed(ctx, cdc, stake.GetDelegationsByValIndexKey(validatorAddr, cdc))
+	if err != nil {
+		return nil, 0, err
+	}
+	var outputs []DelegationOutput
+	for _, value := range values {
+		output, err := decodeDelegation(cdc, value)
+		if err != nil {
+			return nil, 0, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, len(outputs), nil
+}
+
+// query all the unbonding-delegations of a delegator
+func queryDelegatorUBDs(ctx context.CoreContext, cdc *wire.Codec, delegatorAddr sdk.Address) (interface{}, int, error) {
+	kvs, err := ctx.QuerySubspace(cdc, stake.GetUBDsKey(delegatorAddr, cdc), storeName)
+	if err != nil {
+		return nil, 0, err
+	}
+	var outputs []UnbondingDelegationOutput
+	for _, kv := range kvs {
+		output, err := decodeUBD(cdc, kv.Value)
+		if err != nil {
+			return nil, 0, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, len(outputs), nil
+}
+
+// query all the unbonding-delegations from a validator through their validator index
+func queryValidatorUBDs(ctx context.CoreContext, cdc *wire.Codec, validatorAddr sdk.Address) (interface{}, int, error) {
+	values, err := queryIndexed(ctx, cdc, stake.GetUBDsByValIndexKey(validatorAddr, cdc))
+	if err != nil {
+		return nil, 0, err
+	}
+	var outputs []UnbondingDelegationOutput
+	for _, value := range values {
+		output, err := decodeUBD(cdc, value)
+		if err != nil {
+			return nil, 0, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, len(outputs), nil
+}
+
+// query all the redelegations of a delegator
+func queryDelegatorREDs(ctx context.CoreContext, cdc *wire.Codec, delegatorAddr sdk.Address) (interface{}, int, error) {
+	kvs, err := ctx.QuerySubspace(cdc, stake.GetREDsKey(delegatorAddr, cdc), storeName)
+	if err != nil {
+		return nil, 0, err
+	}
+	var outputs []RedelegationOutput
+	for _, kv := range kvs {
+		output, err := decodeRED(cdc, kv.Value)
+		if err != nil {
+			return nil, 0, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, len(outputs), nil
+}
+
+// query all the redelegations away from or towards a validator through their validator indexes
+func queryValidatorREDs(ctx context.CoreContext, cdc *wire.Codec, validatorAddr sdk.Address) (interface{}, int, error) {
+	srcValues, err := queryIndexed(ctx, cdc, stake.GetREDsFromValSrcIndexKey(validatorAddr, cdc))
+	if err != nil {
+		return nil, 0, err
+	}
+	dstValues, err := queryIndexed(ctx, cdc, stake.GetREDsToValDstIndexKey(validatorAddr, cdc))
+	if err != nil {
+		return nil, 0, err
+	}
+	var outputs []RedelegationOutput
+	for _, value := range append(srcValues, dstValues...) {
+		output, err := decodeRED(cdc, value)
+		if err != nil {
+			return nil, 0, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, len(outputs), nil
+}
+
+// query the records whose keys are stored as the values of an index
+func queryIndexed(ctx context.CoreContext, cdc *wire.Codec, indexKey []byte) (values [][]byte, err error) {
+	kvs, err := ctx.QuerySubspace(cdc, indexKey, storeName)
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range kvs {
+		res, err := ctx.QueryStore(kv.Value, storeName)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, res)
+	}
+	return values, nil
+}
+
+// DelegationOutput - a delegation with bech32 addresses
+type DelegationOutput struct {
+	DelegatorAddr string  `json:"delegator_addr"` // in bech32
+	ValidatorAddr string  `json:"validator_addr"` // in bech32
+	Shares        sdk.Rat `json:"shares"`
+	Height        int64   `json:"height"` // last height bond updated
+}
+
+func bech32DelegationOutput(delegation stake.Delegation) (DelegationOutput, error) {
+	bechDelegator, err := sdk.Bech32ifyAcc(delegation.DelegatorAddr)
+	if err != nil {
+		return DelegationOutput{}, err
+	}
+	bechValidator, err := sdk.Bech32ifyVal(delegation.ValidatorAddr)
+	if err != nil {
+		return DelegationOutput{}, err
+	}
+	return DelegationOutput{
+		DelegatorAddr: bechDelegator,
+		ValidatorAddr: bechValidator,
+		Shares:        delegation.Shares,
+		Height:        delegation.Height,
+	}, nil
+}
+
+func decodeDelegation(cdc *wire.Codec, bz []byte) (DelegationOutput, error) {
+	var delegation stake.Delegation
+	err := cdc.UnmarshalBinary(bz, &delegation)
+	if err != nil {
+		return DelegationOutput{}, err
+	}
+	return bech32DelegationOutput(delegation)
+}
+
+// UnbondingDelegationOutput - an unbonding-delegation with bech32 addresses
+type UnbondingDelegationOutput struct {
+	DelegatorAddr  string   `json:"delegator_addr"`  // in bech32
+	ValidatorAddr  string   `json:"validator_addr"`  // in bech32
+	CreationHeight int64    `json:"creation_height"` // height which the unbonding took place
+	MinTime        int64    `json:"min_time"`        // unix time for unbonding completion
+	InitialBalance sdk.Coin `json:"initial_balance"` // atoms initially scheduled to receive at completion
+	Balance        sdk.Coin `json:"balance"`         // atoms to receive at completion
+}
+
+func decodeUBD(cdc *wire.Codec, bz []byte) (UnbondingDelegationOutput, error) {
+	var ubd stake.UnbondingDelegation
+	err := cdc.UnmarshalBinary(bz, &ubd)
+	if err != nil {
+		return UnbondingDelegationOutput{}, err
+	}
+	bechDelegator, err := sdk.Bech32ifyAcc(ubd.DelegatorAddr)
+	if err != nil {
+		return UnbondingDelegationOutput{}, err
+	}
+	bechValidator, err := sdk.Bech32ifyVal(ubd.ValidatorAddr)
+	if err != nil {
+		return UnbondingDelegationOutput{}, err
+	}
+	return UnbondingDelegationOutput{
+		DelegatorAddr:  bechDelegator,
+		ValidatorAddr:  bechValidator,
+		CreationHeight: ubd.CreationHeight,
+		MinTime:        ubd.MinTime,
+		InitialBalance: ubd.InitialBalance,
+		Balance:        ubd.Balance,
+	}, nil
+}
+
+// RedelegationOutput - a redelegation with bech32 addresses
+type RedelegationOutput struct {
+	DelegatorAddr    string   `json:"delegator_addr"`     // in bech32
+	ValidatorSrcAddr string   `json:"validator_src_addr"` // in bech32
+	ValidatorDstAddr string   `json:"validator_dst_addr"` // in bech32
+	CreationHeight   int64    `json:"creation_height"`    // height which the redelegation took place
+	MinTime          int64    `json:"min_time"`           // unix time for redelegation completion
+	InitialBalance   sdk.Coin `json:"initial_balance"`    // initial balance when redelegation started
+	Balance          sdk.Coin `json:"balance"`            // current balance
+	SharesSrc        sdk.Rat  `json:"shares_src"`         // amount of source shares redelegating
+	SharesDst        sdk.Rat  `json:"shares_dst"`         // amount of destination shares redelegating
+}
+
+func decodeRED(cdc *wire.Codec, bz []byte) (RedelegationOutput, error) {
+	var red stake.Redelegation
+	err := cdc.UnmarshalBinary(bz, &red)
+	if err != nil {
+		return RedelegationOutput{}, err
+	}
+	bechDelegator, err := sdk.Bech32ifyAcc(red.DelegatorAddr)
+	if err != nil {
+		return RedelegationOutput{}, err
+	}
+	bechValidatorSrc, err := sdk.Bech32ifyVal(red.ValidatorSrcAddr)
+	if err != nil {
+		return RedelegationOutput{}, err
+	}
+	bechValidatorDst, err := sdk.Bech32ifyVal(red.ValidatorDstAddr)
+	if err != nil {
+		return RedelegationOutput{}, err
+	}
+	return RedelegationOutput{
+		DelegatorAddr:    bechDelegator,
+		ValidatorSrcAddr: bechValidatorSrc,
+		ValidatorDstAddr: bechValidatorDst,
+		CreationHeight:   red.CreationHeight,
+		MinTime:          red.MinTime,
+		InitialBalance:   red.InitialBalance,
+		Balance:          red.Balance,
+		SharesSrc:        red.SharesSrc,
+		SharesDst:        red.SharesDst,
+	}, nil
+}
+
 // TODO move exist next to validator struct for maintainability
 type StakeValidatorOutput struct {
 	Owner   string `json:"owner"`   // in bech32
@@ -228,15 +584,12 @@ type StakeValidatorOutput struct {
 	Description        stake.Description `json:"description"`           // description terms for the validator
 	BondHeight         int64             `json:"bond_height"`           // earliest height as a bonded validator
 	BondIntraTxCounter int16             `json:"bond_intra_tx_counter"` // block-local tx index of validator change
-	ProposerRewardPool sdk.Coins         `json:"proposer_reward_pool"`  // XXX reward pool collected from being the proposer
+	MinSelfDelegation  int64             `json:"min_self_delegation"`   // minimum tokens the owner must keep delegated to its validator
 
-	Commission            sdk.Rat `json:"commission"`              // XXX the commission rate of fees charged to any delegators
-	CommissionMax         sdk.Rat `json:"commission_max"`          // XXX maximum commission rate which this validator can ever charge
-	CommissionChangeRate  sdk.Rat `json:"commission_change_rate"`  // XXX maximum daily increase of the validator commission
-	CommissionChangeToday sdk.Rat `json:"commission_change_today"` // XXX commission rate change today, reset each day (UTC time)
-
-	// fee related
-	PrevBondedShares sdk.Rat `json:"prev_bonded_shares"` // total shares of a global hold pools
+	Commission             sdk.Rat `json:"commission"`               // the commission rate of rewards charged to any delegators
+	CommissionMax          sdk.Rat `json:"commission_max"`           // maximum commission rate which this validator can ever charge
+	CommissionChangeRate   sdk.Rat `json:"commission_change_rate"`   // maximum daily increase of the validator commission
+	CommissionIncreaseTime int64   `json:"commission_increase_time"` // block time (unix) of the last commission increase, or of the creation
 }
 
 func bech32StakeValidatorOutput(validator stake.Validator) (StakeValidatorOutput, error) {
@@ -260,14 +613,12 @@ func bech32StakeValidatorOutput(validator stake.Validator) (StakeValidatorOutput
 		Description:        validator.Description,
 		BondHeight:         validator.BondHeight,
 		BondIntraTxCounter: validator.BondIntraTxCounter,
-		ProposerRewardPool: validator.ProposerRewardPool,
-
-		Commission:            validator.Commission,
-		CommissionMax:         validator.CommissionMax,
-		CommissionChangeRate:  validator.CommissionChangeRate,
-		CommissionChangeToday: validator.CommissionChangeToday,
+		MinSelfDelegation:  validator.MinSelfDelegation,
 
-		PrevBondedShares: validator.PrevBondedShares,
+		Commission:             validator.Commission,
+		CommissionMax:          validator.CommissionMax,
+		CommissionChangeRate:   validator.CommissionChangeRate,
+		CommissionIncreaseTime: validator.CommissionIncreaseTime,
 	}, nil
 }
 
@@ -275,7 +626,14 @@ func bech32StakeValidatorOutput(validator stake.Validator) (StakeValidatorOutput
 // http request handler to query list of validators
 func validatorsHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		kvs, err := ctx.QuerySubspace(cdc, stake.ValidatorsKey, storeName)
+		queryCtx, err := withHeight(ctx, r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		kvs, err := queryCtx.QuerySubspace(cdc, stake.ValidatorsKey, storeName)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			w.Write([]byte(fmt.Sprintf("couldn't query validators. Error: %s", err.Error())))
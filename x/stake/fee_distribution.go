@@ -1,10 +0,0 @@
-package stake
-
-import (
-	sdk "github.com/tepleton/tepleton-sdk/types"
-)
-
-// Handle fee distribution to the validators and delegators
-func FeeHandler(ctx sdk.Context, tx sdk.Tx, fee sdk.Coins) {
-
-}
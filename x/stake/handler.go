@@ -1,6 +1,8 @@
 package stake
 
 import (
+	"bytes"
+
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	"github.com/tepleton/tepleton-sdk/x/stake/keeper"
 	"github.com/tepleton/tepleton-sdk/x/stake/tags"
@@ -32,8 +34,41 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 	}
 }
 
-// Called every block, process inflation, update validator set
-func EndBlocker(ctx sdk.Context, k keeper.Keeper) (ValidatorUpdates []wrsp.Validator) {
+// Called every block, process inflation, complete matured unbondings and
+// redelegations, update validator set
+func EndBlocker(ctx sdk.Context, k keeper.Keeper) (ValidatorUpdates []wrsp.Validator, resTags sdk.Tags) {
+	// apply any params changed through the param store during this block
+	k.ApplyParamChanges(ctx)
+
+	resTags = sdk.NewTags()
+
+	// complete the unbonding delegations which have reached their unbonding time
+	for _, ubd := range k.GetMatureUnbondingDelegations(ctx) {
+		err := k.CompleteUnbonding(ctx, ubd.DelegatorAddr, ubd.ValidatorAddr)
+		if err != nil {
+			panic(err)
+		}
+		resTags = resTags.AppendTags(sdk.NewTags(
+			tags.Action, tags.ActionCompleteUnbonding,
+			tags.Delegator, []byte(ubd.DelegatorAddr.String()),
+			tags.SrcValidator, []byte(ubd.ValidatorAddr.String()),
+		))
+	}
+
+	// complete the redelegations which have reached their unbonding time
+	for _, red := range k.GetMatureRedelegations(ctx) {
+		err := k.CompleteRedelegation(ctx, red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr)
+		if err != nil {
+			panic(err)
+		}
+		resTags = resTags.AppendTags(sdk.NewTags(
+			tags.Action, tags.ActionCompleteRedelegation,
+			tags.Delegator, []byte(red.DelegatorAddr.String()),
+			tags.SrcValidator, []byte(red.ValidatorSrcAddr.String()),
+			tags.DstValidator, []byte(red.ValidatorDstAddr.String()),
+		))
+	}
+
 	pool := k.GetPool(ctx)
 
 	// Process types.Validator Provisions
@@ -72,12 +107,17 @@ func handleMsgCreateValidator(ctx sdk.Context, msg types.MsgCreateValidator, k k
 	}
 
 	validator := NewValidator(msg.ValidatorAddr, msg.PubKey, msg.Description)
+	validator, err := validator.SetInitialCommission(msg.Commission, ctx.BlockHeader().Time)
+	if err != nil {
+		return err.Result()
+	}
+	validator.MinSelfDelegation = msg.MinSelfDelegation
 	k.SetValidator(ctx, validator)
 	k.SetValidatorByPubKeyIndex(ctx, validator)
 
 	// move coins from the msg.Address account to a (self-delegation) delegator account
 	// the validator account and global shares are updated within here
-	_, err := k.Delegate(ctx, msg.ValidatorAddr, msg.SelfDelegation, validator)
+	_, err = k.Delegate(ctx, msg.ValidatorAddr, msg.SelfDelegation, validator)
 	if err != nil {
 		return err.Result()
 	}
@@ -102,18 +142,41 @@ func handleMsgEditValidator(ctx sdk.Context, msg types.MsgEditValidator, k keepe
 	}
 
 	// replace all editable fields (clients should autofill existing values)
-	description, err := validator.Description.UpdateDescription(msg.Description)
-	if err != nil {
-		return err.Result()
+	if msg.Description != (Description{}) {
+		description, err := validator.Description.UpdateDescription(msg.Description)
+		if err != nil {
+			return err.Result()
+		}
+		validator.Description = description
+	}
+
+	// the commission can only change within the limits set at the validator creation
+	if msg.CommissionRate != nil {
+		var err sdk.Error
+		validator, err = validator.UpdateCommission(*msg.CommissionRate, ctx.BlockHeader().Time)
+		if err != nil {
+			return err.Result()
+		}
+	}
+
+	// the minimum self delegation can only increase, up to the current self delegation
+	if msg.MinSelfDelegation != nil {
+		if *msg.MinSelfDelegation <= validator.MinSelfDelegation {
+			return ErrMinSelfDelegationDecreased(k.Codespace()).Result()
+		}
+		validator.MinSelfDelegation = *msg.MinSelfDelegation
+		selfDelegation, found := k.GetDelegation(ctx, validator.Owner, validator.Owner)
+		if !found || validator.SelfDelegationBelowMin(k.GetPool(ctx), selfDelegation.Shares) {
+			return ErrSelfDelegationBelowMinimum(k.Codespace()).Result()
+		}
 	}
-	validator.Description = description
 
 	k.UpdateValidator(ctx, validator)
 	tags := sdk.NewTags(
 		tags.Action, tags.ActionEditValidator,
 		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
-		tags.Moniker, []byte(description.Moniker),
-		tags.Identity, []byte(description.Identity),
+		tags.Moniker, []byte(validator.Description.Moniker),
+		tags.Identity, []byte(validator.Description.Identity),
 	)
 	return sdk.Result{
 		Tags: tags,
@@ -129,7 +192,8 @@ func handleMsgDelegate(ctx sdk.Context, msg types.MsgDelegate, k keeper.Keeper)
 	if msg.Bond.Denom != k.GetParams(ctx).BondDenom {
 		return ErrBadDenom(k.Codespace()).Result()
 	}
-	if validator.Revoked == true {
+	// the owner may still delegate, to restore its minimum self delegation
+	if validator.Revoked == true && !bytes.Equal(msg.DelegatorAddr, validator.Owner) {
 		return ErrValidatorRevoked(k.Codespace()).Result()
 	}
 	_, err := k.Delegate(ctx, msg.DelegatorAddr, msg.Bond, validator)
@@ -161,6 +225,7 @@ func handleMsgBeginUnbonding(ctx sdk.Context, msg types.MsgBeginUnbonding, k kee
 	return sdk.Result{Tags: tags}
 }
 
+// NOTE matured unbonding delegations are also completed by the EndBlocker
 func handleMsgCompleteUnbonding(ctx sdk.Context, msg types.MsgCompleteUnbonding, k keeper.Keeper) sdk.Result {
 
 	err := k.CompleteUnbonding(ctx, msg.DelegatorAddr, msg.ValidatorAddr)
@@ -193,6 +258,7 @@ func handleMsgBeginRedelegate(ctx sdk.Context, msg types.MsgBeginRedelegate, k k
 	return sdk.Result{Tags: tags}
 }
 
+// NOTE matured redelegations are also completed by the EndBlocker
 func handleMsgCompleteRedelegate(ctx sdk.Context, msg types.MsgCompleteRedelegate, k keeper.Keeper) sdk.Result {
 	err := k.CompleteRedelegation(ctx, msg.DelegatorAddr, msg.ValidatorSrcAddr, msg.ValidatorDstAddr)
 	if err != nil {
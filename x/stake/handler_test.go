@@ -17,6 +17,7 @@ import (
 func newTestMsgCreateValidator(address sdk.Address, pubKey crypto.PubKey, amt int64) MsgCreateValidator {
 	return MsgCreateValidator{
 		Description:    Description{},
+		Commission:     NewCommissionMsg(sdk.ZeroRat(), sdk.ZeroRat(), sdk.ZeroRat()),
 		ValidatorAddr:  address,
 		PubKey:         pubKey,
 		SelfDelegation: sdk.Coin{"steak", sdk.NewInt(amt)},
@@ -139,6 +140,100 @@ func TestDuplicatesMsgCreateValidator(t *testing.T) {
 	require.False(t, got.IsOK(), "%v", got)
 }
 
+func TestEditValidatorCommission(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr := keep.Addrs[0]
+
+	msgCreateValidator := newTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10)
+	msgCreateValidator.Commission = NewCommissionMsg(sdk.NewRat(1, 10), sdk.NewRat(5, 10), sdk.NewRat(1, 10))
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "%v", got)
+
+	// the commission cannot increase within a day of the creation
+	rate := sdk.NewRat(2, 10)
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, Description{}, &rate, nil), keeper)
+	require.Equal(t, ErrCommissionChangeTooLarge(DefaultCodespace).Result().Code, got.Code)
+
+	// the description is kept when only the commission is edited
+	header := ctx.BlockHeader()
+	header.Time += 24 * 60 * 60
+	ctx = ctx.WithBlockHeader(header)
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, Description{}, &rate, nil), keeper)
+	require.True(t, got.IsOK(), "%v", got)
+	validator, found := keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, found)
+	require.Equal(t, msgCreateValidator.Description, validator.Description)
+	require.True(t, rate.Equal(validator.Commission))
+
+	// the commission cannot increase again within a day of the last increase
+	rate = sdk.NewRat(21, 100)
+	header.Time += 24*60*60 - 1
+	ctx = ctx.WithBlockHeader(header)
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, Description{}, &rate, nil), keeper)
+	require.Equal(t, ErrCommissionChangeTooLarge(DefaultCodespace).Result().Code, got.Code)
+
+	// but can increase again a day later, up to the max rate
+	header.Time++
+	ctx = ctx.WithBlockHeader(header)
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, Description{}, &rate, nil), keeper)
+	require.True(t, got.IsOK(), "%v", got)
+	rate = sdk.NewRat(6, 10)
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, Description{}, &rate, nil), keeper)
+	require.False(t, got.IsOK())
+}
+
+func TestMinSelfDelegation(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr, delegatorAddr := keep.Addrs[0], keep.Addrs[1]
+
+	msgCreateValidator := newTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10)
+	msgCreateValidator.MinSelfDelegation = 6
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "%v", got)
+	got = handleMsgDelegate(ctx, newTestMsgDelegate(delegatorAddr, validatorAddr, 10), keeper)
+	require.True(t, got.IsOK(), "%v", got)
+
+	// unbonding down to the minimum keeps the validator
+	got = handleMsgBeginUnbonding(ctx, NewMsgBeginUnbonding(validatorAddr, validatorAddr, sdk.NewRat(3)), keeper)
+	require.True(t, got.IsOK(), "%v", got)
+	validator, _ := keeper.GetValidator(ctx, validatorAddr)
+	require.False(t, validator.Revoked)
+
+	// the minimum can only increase, up to the current self delegation
+	min := int64(5)
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, Description{}, nil, &min), keeper)
+	require.Equal(t, ErrMinSelfDelegationDecreased(DefaultCodespace).Result().Code, got.Code)
+	min = 8
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, Description{}, nil, &min), keeper)
+	require.False(t, got.IsOK())
+	min = 7
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, Description{}, nil, &min), keeper)
+	require.True(t, got.IsOK(), "%v", got)
+	validator, _ = keeper.GetValidator(ctx, validatorAddr)
+	require.Equal(t, int64(7), validator.MinSelfDelegation)
+
+	// unbonding below the minimum revokes the validator, which cannot be unrevoked
+	got = handleMsgBeginUnbonding(ctx, NewMsgBeginUnbonding(validatorAddr, validatorAddr, sdk.NewRat(1)), keeper)
+	require.True(t, got.IsOK(), "%v", got)
+	validator, _ = keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, validator.Revoked)
+	keeper.Unrevoke(ctx, keep.PKs[0])
+	validator, _ = keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, validator.Revoked)
+
+	// once the self delegation is restored the validator can be unrevoked
+	got = handleMsgDelegate(ctx, newTestMsgDelegate(validatorAddr, validatorAddr, 1), keeper)
+	require.True(t, got.IsOK(), "%v", got)
+	keeper.Unrevoke(ctx, keep.PKs[0])
+	validator, _ = keeper.GetValidator(ctx, validatorAddr)
+	require.False(t, validator.Revoked)
+
+	// a slash taking the self delegation below the minimum revokes the validator
+	keeper.Slash(ctx, keep.PKs[0], 0, 17, sdk.NewRat(1, 10))
+	validator, _ = keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, validator.Revoked)
+}
+
 func TestIncrementsMsgDelegate(t *testing.T) {
 	initBond := int64(1000)
 	ctx, accMapper, keeper := keep.CreateTestInput(t, false, initBond)
@@ -518,6 +613,105 @@ func TestRedelegationPeriod(t *testing.T) {
 	require.True(t, got.IsOK(), "expected no error")
 }
 
+func TestEndBlockerCompletesUnbonding(t *testing.T) {
+	ctx, am, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr, delegatorAddr := keep.Addrs[0], keep.Addrs[1]
+
+	// set the unbonding time
+	params := keeper.GetParams(ctx)
+	params.UnbondingTime = 7
+	keeper.SetParams(ctx, params)
+
+	msgCreateValidator := newTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	msgDelegate := newTestMsgDelegate(delegatorAddr, validatorAddr, 10)
+	got = handleMsgDelegate(ctx, msgDelegate, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgDelegate")
+
+	msgBeginUnbonding := NewMsgBeginUnbonding(delegatorAddr, validatorAddr, sdk.NewRat(10))
+	got = handleMsgBeginUnbonding(ctx, msgBeginUnbonding, keeper)
+	require.True(t, got.IsOK(), "expected no error")
+
+	// the unbonding is not completed before the unbonding time
+	origHeader := ctx.BlockHeader()
+	headerTime6 := origHeader
+	headerTime6.Time += 6
+	ctx = ctx.WithBlockHeader(headerTime6)
+	_, tags := EndBlocker(ctx, keeper)
+	require.Equal(t, 0, len(tags))
+	_, found := keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr)
+	require.True(t, found)
+
+	// the unbonding is completed without a message once the unbonding time passed
+	headerTime7 := origHeader
+	headerTime7.Time += 7
+	ctx = ctx.WithBlockHeader(headerTime7)
+	_, tags = EndBlocker(ctx, keeper)
+	require.Equal(t, sdk.NewTags(
+		TagAction, ActionCompleteUnbonding,
+		TagDelegator, []byte(delegatorAddr.String()),
+		TagSrcValidator, []byte(validatorAddr.String()),
+	), tags)
+	_, found = keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr)
+	require.False(t, found)
+	require.Equal(t, int64(1000), am.GetAccount(ctx, delegatorAddr).GetCoins().AmountOf("steak").Int64())
+
+	// the manual completion has nothing left to complete
+	msgCompleteUnbonding := NewMsgCompleteUnbonding(delegatorAddr, validatorAddr)
+	got = handleMsgCompleteUnbonding(ctx, msgCompleteUnbonding, keeper)
+	require.False(t, got.IsOK(), "expected an error")
+}
+
+func TestEndBlockerCompletesRedelegation(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr, validatorAddr2 := keep.Addrs[0], keep.Addrs[1]
+
+	// set the unbonding time
+	params := keeper.GetParams(ctx)
+	params.UnbondingTime = 7
+	keeper.SetParams(ctx, params)
+
+	msgCreateValidator := newTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	msgCreateValidator = newTestMsgCreateValidator(validatorAddr2, keep.PKs[1], 10)
+	got = handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+
+	msgBeginRedelegate := NewMsgBeginRedelegate(validatorAddr, validatorAddr, validatorAddr2, sdk.NewRat(5))
+	got = handleMsgBeginRedelegate(ctx, msgBeginRedelegate, keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+
+	// the redelegation is not completed before the unbonding time
+	origHeader := ctx.BlockHeader()
+	headerTime6 := origHeader
+	headerTime6.Time += 6
+	ctx = ctx.WithBlockHeader(headerTime6)
+	EndBlocker(ctx, keeper)
+	_, found := keeper.GetRedelegation(ctx, validatorAddr, validatorAddr, validatorAddr2)
+	require.True(t, found)
+
+	// the redelegation is completed without a message once the unbonding time passed
+	headerTime7 := origHeader
+	headerTime7.Time += 7
+	ctx = ctx.WithBlockHeader(headerTime7)
+	_, tags := EndBlocker(ctx, keeper)
+	require.Equal(t, sdk.NewTags(
+		TagAction, ActionCompleteRedelegation,
+		TagDelegator, []byte(validatorAddr.String()),
+		TagSrcValidator, []byte(validatorAddr.String()),
+		TagDstValidator, []byte(validatorAddr2.String()),
+	), tags)
+	_, found = keeper.GetRedelegation(ctx, validatorAddr, validatorAddr, validatorAddr2)
+	require.False(t, found)
+
+	// further redelegations to the destination are no longer transitive
+	msgBeginRedelegate = NewMsgBeginRedelegate(validatorAddr, validatorAddr2, validatorAddr, sdk.NewRat(5))
+	got = handleMsgBeginRedelegate(ctx, msgBeginRedelegate, keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+}
+
 func TestTransitiveRedelegation(t *testing.T) {
 	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
 	validatorAddr, validatorAddr2, validatorAddr3 := keep.Addrs[0], keep.Addrs[1], keep.Addrs[2]
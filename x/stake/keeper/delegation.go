@@ -65,17 +65,33 @@ func (k Keeper) GetDelegations(ctx sdk.Context, delegator sdk.Address,
 	return delegations[:i] // trim
 }
 
+// load all delegations to a particular validator
+func (k Keeper) GetDelegationsToValidator(ctx sdk.Context, valAddr sdk.Address) (delegations []types.Delegation) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetDelegationsByValIndexKey(valAddr, k.cdc))
+	for ; iterator.Valid(); iterator.Next() {
+		var delegation types.Delegation
+		k.cdc.MustUnmarshalBinary(store.Get(iterator.Value()), &delegation)
+		delegations = append(delegations, delegation)
+	}
+	iterator.Close()
+	return delegations
+}
+
 // set the delegation
 func (k Keeper) SetDelegation(ctx sdk.Context, delegation types.Delegation) {
 	store := ctx.KVStore(k.storeKey)
 	b := k.cdc.MustMarshalBinary(delegation)
-	store.Set(GetDelegationKey(delegation.DelegatorAddr, delegation.ValidatorAddr, k.cdc), b)
+	delegationKey := GetDelegationKey(delegation.DelegatorAddr, delegation.ValidatorAddr, k.cdc)
+	store.Set(delegationKey, b)
+	store.Set(GetDelegationByValIndexKey(delegation.DelegatorAddr, delegation.ValidatorAddr, k.cdc), delegationKey)
 }
 
-// remove the delegation
+// remove the delegation and its validator index
 func (k Keeper) RemoveDelegation(ctx sdk.Context, delegation types.Delegation) {
 	store := ctx.KVStore(k.storeKey)
 	store.Delete(GetDelegationKey(delegation.DelegatorAddr, delegation.ValidatorAddr, k.cdc))
+	store.Delete(GetDelegationByValIndexKey(delegation.DelegatorAddr, delegation.ValidatorAddr, k.cdc))
 }
 
 //_____________________________________________________________________________________
@@ -115,21 +131,45 @@ func (k Keeper) GetUnbondingDelegationsFromValidator(ctx sdk.Context, valAddr sd
 	return unbondingDelegations
 }
 
-// set the unbonding delegation and associated index
+// load all unbonding delegations which have completed their unbonding time
+func (k Keeper) GetMatureUnbondingDelegations(ctx sdk.Context) (unbondingDelegations []types.UnbondingDelegation) {
+	store := ctx.KVStore(k.storeKey)
+	ctxTime := ctx.BlockHeader().Time
+	iterator := store.Iterator(UnbondingQueueKey, sdk.PrefixEndBytes(GetUBDQueueTimeKey(ctxTime)))
+	for ; iterator.Valid(); iterator.Next() {
+		unbondingBytes := store.Get(iterator.Value())
+		var unbondingDelegation types.UnbondingDelegation
+		k.cdc.MustUnmarshalBinary(unbondingBytes, &unbondingDelegation)
+		unbondingDelegations = append(unbondingDelegations, unbondingDelegation)
+	}
+	iterator.Close()
+	return unbondingDelegations
+}
+
+// set the unbonding delegation and associated indexes
 func (k Keeper) SetUnbondingDelegation(ctx sdk.Context, ubd types.UnbondingDelegation) {
 	store := ctx.KVStore(k.storeKey)
 	bz := k.cdc.MustMarshalBinary(ubd)
 	ubdKey := GetUBDKey(ubd.DelegatorAddr, ubd.ValidatorAddr, k.cdc)
+
+	// a replaced unbonding delegation must leave the queue at its former time
+	oldUbd, found := k.GetUnbondingDelegation(ctx, ubd.DelegatorAddr, ubd.ValidatorAddr)
+	if found && oldUbd.MinTime != ubd.MinTime {
+		store.Delete(GetUBDQueueKey(oldUbd.MinTime, ubd.DelegatorAddr, ubd.ValidatorAddr, k.cdc))
+	}
+
 	store.Set(ubdKey, bz)
 	store.Set(GetUBDByValIndexKey(ubd.DelegatorAddr, ubd.ValidatorAddr, k.cdc), ubdKey)
+	store.Set(GetUBDQueueKey(ubd.MinTime, ubd.DelegatorAddr, ubd.ValidatorAddr, k.cdc), ubdKey)
 }
 
-// remove the unbonding delegation object and associated index
+// remove the unbonding delegation object and associated indexes
 func (k Keeper) RemoveUnbondingDelegation(ctx sdk.Context, ubd types.UnbondingDelegation) {
 	store := ctx.KVStore(k.storeKey)
 	ubdKey := GetUBDKey(ubd.DelegatorAddr, ubd.ValidatorAddr, k.cdc)
 	store.Delete(ubdKey)
 	store.Delete(GetUBDByValIndexKey(ubd.DelegatorAddr, ubd.ValidatorAddr, k.cdc))
+	store.Delete(GetUBDQueueKey(ubd.MinTime, ubd.DelegatorAddr, ubd.ValidatorAddr, k.cdc))
 }
 
 //_____________________________________________________________________________________
@@ -186,23 +226,47 @@ func (k Keeper) HasReceivingRedelegation(ctx sdk.Context,
 	return found
 }
 
-// set a redelegation and associated index
+// load all redelegations which have completed their unbonding time
+func (k Keeper) GetMatureRedelegations(ctx sdk.Context) (redelegations []types.Redelegation) {
+	store := ctx.KVStore(k.storeKey)
+	ctxTime := ctx.BlockHeader().Time
+	iterator := store.Iterator(RedelegationQueueKey, sdk.PrefixEndBytes(GetREDQueueTimeKey(ctxTime)))
+	for ; iterator.Valid(); iterator.Next() {
+		redelegationBytes := store.Get(iterator.Value())
+		var redelegation types.Redelegation
+		k.cdc.MustUnmarshalBinary(redelegationBytes, &redelegation)
+		redelegations = append(redelegations, redelegation)
+	}
+	iterator.Close()
+	return redelegations
+}
+
+// set a redelegation and associated indexes
 func (k Keeper) SetRedelegation(ctx sdk.Context, red types.Redelegation) {
 	store := ctx.KVStore(k.storeKey)
 	bz := k.cdc.MustMarshalBinary(red)
 	redKey := GetREDKey(red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr, k.cdc)
+
+	// a replaced redelegation must leave the queue at its former time
+	oldRed, found := k.GetRedelegation(ctx, red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr)
+	if found && oldRed.MinTime != red.MinTime {
+		store.Delete(GetREDQueueKey(oldRed.MinTime, red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr, k.cdc))
+	}
+
 	store.Set(redKey, bz)
 	store.Set(GetREDByValSrcIndexKey(red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr, k.cdc), redKey)
 	store.Set(GetREDByValDstIndexKey(red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr, k.cdc), redKey)
+	store.Set(GetREDQueueKey(red.MinTime, red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr, k.cdc), redKey)
 }
 
-// remove a redelegation object and associated index
+// remove a redelegation object and associated indexes
 func (k Keeper) RemoveRedelegation(ctx sdk.Context, red types.Redelegation) {
 	store := ctx.KVStore(k.storeKey)
 	redKey := GetREDKey(red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr, k.cdc)
 	store.Delete(redKey)
 	store.Delete(GetREDByValSrcIndexKey(red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr, k.cdc))
 	store.Delete(GetREDByValDstIndexKey(red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr, k.cdc))
+	store.Delete(GetREDQueueKey(red.MinTime, red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr, k.cdc))
 }
 
 //_____________________________________________________________________________________
@@ -220,10 +284,13 @@ func (k Keeper) Delegate(ctx sdk.Context, delegatorAddr sdk.Address, bondAmt sdk
 			Shares:        sdk.ZeroRat(),
 		}
 	}
+	if k.hooks != nil {
+		k.hooks.BeforeDelegationSharesModified(ctx, delegatorAddr, validator.Owner)
+	}
 
 	// Account new shares, save
 	pool := k.GetPool(ctx)
-	_, _, err = k.coinKeeper.SubtractCoins(ctx, delegation.DelegatorAddr, sdk.Coins{bondAmt})
+	_, err = k.coinKeeper.DelegateCoins(ctx, delegation.DelegatorAddr, sdk.Coins{bondAmt})
 	if err != nil {
 		return
 	}
@@ -264,17 +331,22 @@ func (k Keeper) unbond(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address
 		return
 	}
 
+	if k.hooks != nil {
+		k.hooks.BeforeDelegationSharesModified(ctx, delegatorAddr, validatorAddr)
+	}
+
 	// subtract shares from delegator
 	delegation.Shares = delegation.Shares.Sub(shares)
 
+	// if the delegation is the owner of the validator and its remaining
+	// tokens are below the minimum self delegation then trigger a revoke validator
+	if bytes.Equal(delegation.DelegatorAddr, validator.Owner) && validator.Revoked == false &&
+		(delegation.Shares.IsZero() || validator.SelfDelegationBelowMin(k.GetPool(ctx), delegation.Shares)) {
+		validator.Revoked = true
+	}
+
 	// remove the delegation
 	if delegation.Shares.IsZero() {
-
-		// if the delegation is the owner of the validator then
-		// trigger a revoke validator
-		if bytes.Equal(delegation.DelegatorAddr, validator.Owner) && validator.Revoked == false {
-			validator.Revoked = true
-		}
 		k.RemoveDelegation(ctx, delegation)
 	} else {
 		// Update height
@@ -337,7 +409,7 @@ func (k Keeper) CompleteUnbonding(ctx sdk.Context, delegatorAddr, validatorAddr
 		return types.ErrNotMature(k.Codespace(), "unbonding", "unit-time", ubd.MinTime, ctxTime)
 	}
 
-	_, _, err := k.coinKeeper.AddCoins(ctx, ubd.DelegatorAddr, sdk.Coins{ubd.Balance})
+	_, err := k.coinKeeper.UndelegateCoins(ctx, ubd.DelegatorAddr, sdk.Coins{ubd.Balance})
 	if err != nil {
 		return err
 	}
@@ -86,11 +86,18 @@ func TestDelegation(t *testing.T) {
 	require.True(t, bond2to1.Equal(allBonds[3]))
 	require.True(t, bond2to2.Equal(allBonds[4]))
 	require.True(t, bond2to3.Equal(allBonds[5]))
+	resBonds = keeper.GetDelegationsToValidator(ctx, addrVals[2])
+	require.Equal(t, 2, len(resBonds))
+	require.True(t, bond1to3.Equal(resBonds[0]))
+	require.True(t, bond2to3.Equal(resBonds[1]))
 
 	// delete a record
 	keeper.RemoveDelegation(ctx, bond2to3)
 	_, found = keeper.GetDelegation(ctx, addrDels[1], addrVals[2])
 	require.False(t, found)
+	resBonds = keeper.GetDelegationsToValidator(ctx, addrVals[2])
+	require.Equal(t, 1, len(resBonds))
+	require.True(t, bond1to3.Equal(resBonds[0]))
 	resBonds = keeper.GetDelegations(ctx, addrDels[1], 5)
 	require.Equal(t, 2, len(resBonds))
 	require.True(t, bond2to1.Equal(resBonds[0]))
@@ -138,6 +145,50 @@ func TestUnbondingDelegation(t *testing.T) {
 	require.False(t, found)
 }
 
+func TestMatureUnbondingDelegations(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 0)
+
+	ubd1 := types.UnbondingDelegation{
+		DelegatorAddr: addrDels[0],
+		ValidatorAddr: addrVals[0],
+		MinTime:       10,
+		Balance:       sdk.NewCoin("steak", 5),
+	}
+	ubd2 := types.UnbondingDelegation{
+		DelegatorAddr: addrDels[1],
+		ValidatorAddr: addrVals[0],
+		MinTime:       5,
+		Balance:       sdk.NewCoin("steak", 5),
+	}
+	keeper.SetUnbondingDelegation(ctx, ubd1)
+	keeper.SetUnbondingDelegation(ctx, ubd2)
+
+	// nothing is mature before the earliest completion time
+	header := ctx.BlockHeader()
+	header.Time = 4
+	ctx = ctx.WithBlockHeader(header)
+	require.Equal(t, 0, len(keeper.GetMatureUnbondingDelegations(ctx)))
+
+	// records are mature from their completion time, earliest first
+	header.Time = 10
+	ctx = ctx.WithBlockHeader(header)
+	resUbds := keeper.GetMatureUnbondingDelegations(ctx)
+	require.Equal(t, 2, len(resUbds))
+	require.True(t, ubd2.Equal(resUbds[0]))
+	require.True(t, ubd1.Equal(resUbds[1]))
+
+	// a replaced record is only queued at its new time
+	ubd1.MinTime = 20
+	keeper.SetUnbondingDelegation(ctx, ubd1)
+	resUbds = keeper.GetMatureUnbondingDelegations(ctx)
+	require.Equal(t, 1, len(resUbds))
+	require.True(t, ubd2.Equal(resUbds[0]))
+
+	// a removed record leaves the queue
+	keeper.RemoveUnbondingDelegation(ctx, ubd2)
+	require.Equal(t, 0, len(keeper.GetMatureUnbondingDelegations(ctx)))
+}
+
 func TestUnbondDelegation(t *testing.T) {
 	ctx, _, keeper := CreateTestInput(t, false, 0)
 	pool := keeper.GetPool(ctx)
@@ -220,3 +271,31 @@ func TestRedelegation(t *testing.T) {
 	_, found = keeper.GetRedelegation(ctx, addrDels[0], addrVals[0], addrVals[1])
 	require.False(t, found)
 }
+
+func TestMatureRedelegations(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 0)
+
+	rd := types.Redelegation{
+		DelegatorAddr:    addrDels[0],
+		ValidatorSrcAddr: addrVals[0],
+		ValidatorDstAddr: addrVals[1],
+		MinTime:          10,
+		SharesSrc:        sdk.NewRat(5),
+		SharesDst:        sdk.NewRat(5),
+	}
+	keeper.SetRedelegation(ctx, rd)
+
+	header := ctx.BlockHeader()
+	header.Time = 9
+	ctx = ctx.WithBlockHeader(header)
+	require.Equal(t, 0, len(keeper.GetMatureRedelegations(ctx)))
+
+	header.Time = 10
+	ctx = ctx.WithBlockHeader(header)
+	resReds := keeper.GetMatureRedelegations(ctx)
+	require.Equal(t, 1, len(resReds))
+	require.True(t, rd.Equal(resReds[0]))
+
+	keeper.RemoveRedelegation(ctx, rd)
+	require.Equal(t, 0, len(keeper.GetMatureRedelegations(ctx)))
+}
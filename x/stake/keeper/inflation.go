@@ -20,8 +20,9 @@ func (k Keeper) ProcessProvisions(ctx sdk.Context) types.Pool {
 
 	provisions := pool.Inflation.Mul(sdk.NewRat(pool.TokenSupply())).Quo(hrsPerYrRat).RoundInt64()
 
-	// TODO add to the fees provisions
+	// the provisions are paid out as rewards by the distribution module
 	pool.LooseTokens += provisions
+	pool.PendingProvisions += provisions
 	return pool
 }
 
@@ -297,12 +297,16 @@ func updateProvisions(t *testing.T, keeper Keeper, pool types.Pool, ctx sdk.Cont
 	expInflation := keeper.NextInflation(ctx)
 	expProvisions := (expInflation.Mul(sdk.NewRat(pool.TokenSupply())).Quo(hrsPerYrRat)).RoundInt64()
 	startTotalSupply := pool.TokenSupply()
+	startPendingProvisions := pool.PendingProvisions
 	pool = keeper.ProcessProvisions(ctx)
 	keeper.SetPool(ctx, pool)
 
 	//check provisions were added to pool
 	require.Equal(t, startTotalSupply+expProvisions, pool.TokenSupply())
 
+	//check provisions are pending distribution
+	require.Equal(t, startPendingProvisions+expProvisions, pool.PendingProvisions)
+
 	return expInflation, expProvisions, pool
 }
 
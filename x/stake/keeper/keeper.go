@@ -1,33 +1,62 @@
 package keeper
 
 import (
+	"fmt"
+
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	"github.com/tepleton/tepleton-sdk/wire"
 
 	"github.com/tepleton/tepleton-sdk/x/bank"
+	"github.com/tepleton/tepleton-sdk/x/params"
 	"github.com/tepleton/tepleton-sdk/x/stake/types"
 )
 
 // keeper of the stake store
 type Keeper struct {
-	storeKey   sdk.StoreKey
-	cdc        *wire.Codec
-	coinKeeper bank.Keeper
+	storeKey    sdk.StoreKey
+	cdc         *wire.Codec
+	coinKeeper  bank.Keeper
+	paramSetter params.Setter
+	hooks       sdk.StakingHooks
 
 	// codespace
 	codespace sdk.CodespaceType
 }
 
-func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, codespace sdk.CodespaceType) Keeper {
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, ps params.Setter, codespace sdk.CodespaceType) Keeper {
 	keeper := Keeper{
-		storeKey:   key,
-		cdc:        cdc,
-		coinKeeper: ck,
-		codespace:  codespace,
+		storeKey:    key,
+		cdc:         cdc,
+		coinKeeper:  ck,
+		paramSetter: ps,
+		codespace:   codespace,
 	}
+	ps.Register(ParamStoreKeyParams, types.Params{}, keeper.validateParams)
 	return keeper
 }
 
+// validate a change of the params in the param store, which must keep the
+// bond denom of the existing tokens
+func (k Keeper) validateParams(ctx sdk.Context, value interface{}) error {
+	params := value.(types.Params)
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	if params.BondDenom != k.GetParams(ctx).BondDenom {
+		return fmt.Errorf("bond denom cannot be changed")
+	}
+	return nil
+}
+
+// Set the staking hooks, called by the modules which keep track of delegations
+func (k Keeper) WithHooks(sh sdk.StakingHooks) Keeper {
+	if k.hooks != nil {
+		panic("cannot set staking hooks twice")
+	}
+	k.hooks = sh
+	return k
+}
+
 //_________________________________________________________________________
 
 // return the codespace
@@ -38,16 +67,12 @@ func (k Keeper) Codespace() sdk.CodespaceType {
 //_________________________________________________________________________
 // some generic reads/writes that don't need their own files
 
-// load/save the global staking params
+// load the global staking params from the param store
 func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
-	store := ctx.KVStore(k.storeKey)
-
-	b := store.Get(ParamKey)
-	if b == nil {
-		panic("Stored params should not have been nil")
+	err := k.paramSetter.Get(ctx, ParamStoreKeyParams, &params)
+	if err != nil {
+		panic(fmt.Sprintf("Stored params should not have been nil: %v", err))
 	}
-
-	k.cdc.MustUnmarshalBinary(b, &params)
 	return
 }
 
@@ -56,20 +81,45 @@ func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
 // panic on retrieval if it doesn't exist - hence if we use setParams for the very
 // first params set it will panic.
 func (k Keeper) SetNewParams(ctx sdk.Context, params types.Params) {
-	store := ctx.KVStore(k.storeKey)
-	b := k.cdc.MustMarshalBinary(params)
-	store.Set(ParamKey, b)
+	err := k.paramSetter.Set(ctx, ParamStoreKeyParams, params)
+	if err != nil {
+		panic(err)
+	}
+	k.setAppliedParams(ctx, params)
 }
 
 // set the params
 func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	err := k.paramSetter.Set(ctx, ParamStoreKeyParams, params)
+	if err != nil {
+		panic(err)
+	}
+	k.ApplyParamChanges(ctx)
+}
+
+// The params may be changed in the param store without going through the
+// keeper (e.g. by a governance proposal), so the params the validator set was
+// last computed with are kept in the stake store. Apply the side-effects of
+// any change since then.
+func (k Keeper) ApplyParamChanges(ctx sdk.Context) {
 	store := ctx.KVStore(k.storeKey)
-	exParams := k.GetParams(ctx)
+	var applied types.Params
+	k.cdc.MustUnmarshalBinary(store.Get(ParamKey), &applied)
+
+	params := k.GetParams(ctx)
+	if applied.Equal(params) {
+		return
+	}
+	k.setAppliedParams(ctx, params)
 
 	// if max validator count changes, must recalculate validator set
-	if exParams.MaxValidators != params.MaxValidators {
+	if applied.MaxValidators != params.MaxValidators {
 		k.UpdateBondedValidatorsFull(ctx)
 	}
+}
+
+func (k Keeper) setAppliedParams(ctx sdk.Context, params types.Params) {
+	store := ctx.KVStore(k.storeKey)
 	b := k.cdc.MustMarshalBinary(params)
 	store.Set(ParamKey, b)
 }
@@ -37,3 +37,24 @@ func TestPool(t *testing.T) {
 	resPool = keeper.GetPool(ctx)
 	require.True(t, expPool.Equal(resPool))
 }
+
+func TestApplyParamChanges(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 0)
+	expParams := types.DefaultParams()
+
+	// change the params through the param store directly, as governance would
+	expParams.MaxValidators = 777
+	err := keeper.paramSetter.Set(ctx, ParamStoreKeyParams, expParams)
+	require.Nil(t, err)
+	require.True(t, expParams.Equal(keeper.GetParams(ctx)))
+
+	// the stake store still holds the previously applied params until the change is applied
+	store := ctx.KVStore(keeper.storeKey)
+	var applied types.Params
+	keeper.cdc.MustUnmarshalBinary(store.Get(ParamKey), &applied)
+	require.Equal(t, uint16(100), applied.MaxValidators)
+
+	keeper.ApplyParamChanges(ctx)
+	keeper.cdc.MustUnmarshalBinary(store.Get(ParamKey), &applied)
+	require.True(t, expParams.Equal(applied))
+}
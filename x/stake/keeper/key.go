@@ -7,15 +7,19 @@ import (
 
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	"github.com/tepleton/tepleton-sdk/wire"
+	"github.com/tepleton/tepleton-sdk/x/params"
 	"github.com/tepleton/tepleton-sdk/x/stake/types"
 )
 
+// key for the staking params within the global param store
+var ParamStoreKeyParams = params.ComposeKey("stake", "params")
+
 // TODO remove some of these prefixes once have working multistore
 
 //nolint
 var (
 	// Keys for store prefixes
-	ParamKey                         = []byte{0x00} // key for parameters relating to staking
+	ParamKey                         = []byte{0x00} // key for the parameters last applied to the validator set
 	PoolKey                          = []byte{0x01} // key for the staking pools
 	ValidatorsKey                    = []byte{0x02} // prefix for each key to a validator
 	ValidatorsByPubKeyIndexKey       = []byte{0x03} // prefix for each key to a validator index, by pubkey
@@ -31,6 +35,9 @@ var (
 	RedelegationKey                  = []byte{0x0D} // key for a redelegation
 	RedelegationByValSrcIndexKey     = []byte{0x0E} // prefix for each key for an redelegation, by validator owner
 	RedelegationByValDstIndexKey     = []byte{0x0F} // prefix for each key for an redelegation, by validator owner
+	UnbondingQueueKey                = []byte{0x10} // prefix for the unbonding-delegation queue, by completion time
+	RedelegationQueueKey             = []byte{0x11} // prefix for the redelegation queue, by completion time
+	DelegationByValIndexKey          = []byte{0x12} // prefix for each key for a delegation, by validator owner
 )
 
 const maxDigitsForAccount = 12 // ~220,000,000 atoms created at launch
@@ -96,12 +103,23 @@ func GetDelegationKey(delegatorAddr, validatorAddr sdk.Address, cdc *wire.Codec)
 	return append(GetDelegationsKey(delegatorAddr, cdc), validatorAddr.Bytes()...)
 }
 
+// get the index-key for a delegation, stored by validator-index
+func GetDelegationByValIndexKey(delegatorAddr, validatorAddr sdk.Address, cdc *wire.Codec) []byte {
+	return append(GetDelegationsByValIndexKey(validatorAddr, cdc), delegatorAddr.Bytes()...)
+}
+
 // get the prefix for a delegator for all validators
 func GetDelegationsKey(delegatorAddr sdk.Address, cdc *wire.Codec) []byte {
 	res := cdc.MustMarshalBinary(&delegatorAddr)
 	return append(DelegationKey, res...)
 }
 
+// get the prefix keyspace for the indexes of the delegations to a validator
+func GetDelegationsByValIndexKey(validatorAddr sdk.Address, cdc *wire.Codec) []byte {
+	res := cdc.MustMarshalBinary(&validatorAddr)
+	return append(DelegationByValIndexKey, res...)
+}
+
 //________________________________________________________________________________
 
 // get the key for an unbonding delegation
@@ -114,6 +132,11 @@ func GetUBDByValIndexKey(delegatorAddr, validatorAddr sdk.Address, cdc *wire.Cod
 	return append(GetUBDsByValIndexKey(validatorAddr, cdc), delegatorAddr.Bytes()...)
 }
 
+// get the queue-key for an unbonding delegation, ordered by completion time
+func GetUBDQueueKey(minTime int64, delegatorAddr, validatorAddr sdk.Address, cdc *wire.Codec) []byte {
+	return append(GetUBDQueueTimeKey(minTime), GetUBDKey(delegatorAddr, validatorAddr, cdc)...)
+}
+
 //______________
 
 // get the prefix for all unbonding delegations from a delegator
@@ -128,6 +151,11 @@ func GetUBDsByValIndexKey(validatorAddr sdk.Address, cdc *wire.Codec) []byte {
 	return append(UnbondingDelegationByValIndexKey, res...)
 }
 
+// get the prefix keyspace for the unbonding delegations completing at a time
+func GetUBDQueueTimeKey(minTime int64) []byte {
+	return append(UnbondingQueueKey, getTimeBytes(minTime)...)
+}
+
 //________________________________________________________________________________
 
 // get the key for a redelegation
@@ -166,6 +194,15 @@ func GetREDByValDstIndexKey(delegatorAddr, validatorSrcAddr,
 	)
 }
 
+// get the queue-key for a redelegation, ordered by completion time
+func GetREDQueueKey(minTime int64, delegatorAddr, validatorSrcAddr,
+	validatorDstAddr sdk.Address, cdc *wire.Codec) []byte {
+
+	return append(
+		GetREDQueueTimeKey(minTime),
+		GetREDKey(delegatorAddr, validatorSrcAddr, validatorDstAddr, cdc)...)
+}
+
 //______________
 
 // get the prefix keyspace for redelegations from a delegator
@@ -195,3 +232,17 @@ func GetREDsByDelToValDstIndexKey(delegatorAddr sdk.Address,
 		GetREDsToValDstIndexKey(validatorDstAddr, cdc),
 		delegatorAddr.Bytes()...)
 }
+
+// get the prefix keyspace for the redelegations completing at a time
+func GetREDQueueTimeKey(minTime int64) []byte {
+	return append(RedelegationQueueKey, getTimeBytes(minTime)...)
+}
+
+//________________________________________________________________________________
+
+// big-endian time, so the queues are iterated from the earliest completion time
+func getTimeBytes(t int64) []byte {
+	timeBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(timeBytes, uint64(t))
+	return timeBytes
+}
@@ -0,0 +1,105 @@
+package keeper
+
+import (
+	"fmt"
+
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/x/stake/types"
+)
+
+// query endpoints supported by the stake querier
+const (
+	QueryValidators           = "validators"
+	QueryValidator            = "validator"
+	QueryValidatorsByPower    = "validatorsByPower"
+	QueryDelegatorDelegations = "delegatorDelegations"
+	QueryDelegation           = "delegation"
+	QueryPool                 = "pool"
+	QueryParameters           = "parameters"
+)
+
+// QueryValidatorParams - params of the queries about a validator, as JSON data
+type QueryValidatorParams struct {
+	ValidatorAddr sdk.Address `json:"validator_addr"`
+}
+
+// QueryDelegatorParams - params of the queries about a delegator, as JSON data
+type QueryDelegatorParams struct {
+	DelegatorAddr sdk.Address `json:"delegator_addr"`
+}
+
+// QueryDelegationParams - params of the queries about a delegation, as JSON data
+type QueryDelegationParams struct {
+	DelegatorAddr sdk.Address `json:"delegator_addr"`
+	ValidatorAddr sdk.Address `json:"validator_addr"`
+}
+
+// NewQuerier answers the custom stake queries, with JSON responses
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req wrsp.RequestQuery) (res []byte, err sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no stake query endpoint")
+		}
+		switch path[0] {
+		case QueryValidators:
+			return sdk.MarshalQueryResult(k.cdc, k.GetAllValidators(ctx))
+		case QueryValidator:
+			return queryValidator(ctx, k, req)
+		case QueryValidatorsByPower:
+			return sdk.MarshalQueryResult(k.cdc, k.GetValidatorsByPower(ctx))
+		case QueryDelegatorDelegations:
+			return queryDelegatorDelegations(ctx, k, req)
+		case QueryDelegation:
+			return queryDelegation(ctx, k, req)
+		case QueryPool:
+			return sdk.MarshalQueryResult(k.cdc, k.GetPool(ctx))
+		case QueryParameters:
+			return sdk.MarshalQueryResult(k.cdc, k.GetParams(ctx))
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown stake query endpoint %s", path[0]))
+		}
+	}
+}
+
+func queryValidator(ctx sdk.Context, k Keeper, req wrsp.RequestQuery) ([]byte, sdk.Error) {
+	var params QueryValidatorParams
+	if err := sdk.UnmarshalQueryParams(k.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+	validator, found := k.GetValidator(ctx, params.ValidatorAddr)
+	if !found {
+		return nil, types.ErrNoValidatorFound(k.codespace)
+	}
+	return sdk.MarshalQueryResult(k.cdc, validator)
+}
+
+func queryDelegatorDelegations(ctx sdk.Context, k Keeper, req wrsp.RequestQuery) ([]byte, sdk.Error) {
+	var params QueryDelegatorParams
+	if err := sdk.UnmarshalQueryParams(k.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetDelegationsKey(params.DelegatorAddr, k.cdc))
+	delegations := []types.Delegation{}
+	for ; iterator.Valid(); iterator.Next() {
+		var delegation types.Delegation
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &delegation)
+		delegations = append(delegations, delegation)
+	}
+	iterator.Close()
+	return sdk.MarshalQueryResult(k.cdc, delegations)
+}
+
+func queryDelegation(ctx sdk.Context, k Keeper, req wrsp.RequestQuery) ([]byte, sdk.Error) {
+	var params QueryDelegationParams
+	if err := sdk.UnmarshalQueryParams(k.cdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+	delegation, found := k.GetDelegation(ctx, params.DelegatorAddr, params.ValidatorAddr)
+	if !found {
+		return nil, types.ErrNoDelegation(k.codespace)
+	}
+	return sdk.MarshalQueryResult(k.cdc, delegation)
+}
@@ -0,0 +1,79 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/x/stake/types"
+)
+
+func TestQuerier(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 10)
+	querier := NewQuerier(keeper)
+	pool := keeper.GetPool(ctx)
+
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator, pool, _ = validator.AddTokensFromDel(pool, 10)
+	keeper.SetPool(ctx, pool)
+	validator = keeper.UpdateValidator(ctx, validator)
+	delegation := types.Delegation{
+		DelegatorAddr: addrDels[0],
+		ValidatorAddr: addrVals[0],
+		Shares:        sdk.NewRat(10),
+	}
+	keeper.SetDelegation(ctx, delegation)
+
+	query := func(endpoint string, params interface{}) ([]byte, sdk.Error) {
+		req := wrsp.RequestQuery{}
+		if params != nil {
+			data, err := keeper.cdc.MarshalJSON(params)
+			require.Nil(t, err)
+			req.Data = data
+		}
+		return querier(ctx, []string{endpoint}, req)
+	}
+
+	bz, err := query(QueryValidators, nil)
+	require.Nil(t, err)
+	var validators []types.Validator
+	require.Nil(t, keeper.cdc.UnmarshalJSON(bz, &validators))
+	require.Equal(t, 1, len(validators))
+	require.True(t, validator.Equal(validators[0]))
+
+	bz, err = query(QueryValidator, QueryValidatorParams{addrVals[0]})
+	require.Nil(t, err)
+	var resValidator types.Validator
+	require.Nil(t, keeper.cdc.UnmarshalJSON(bz, &resValidator))
+	require.True(t, validator.Equal(resValidator))
+
+	_, err = query(QueryValidator, QueryValidatorParams{addrVals[1]})
+	require.NotNil(t, err)
+
+	bz, err = query(QueryDelegatorDelegations, QueryDelegatorParams{addrDels[0]})
+	require.Nil(t, err)
+	var delegations []types.Delegation
+	require.Nil(t, keeper.cdc.UnmarshalJSON(bz, &delegations))
+	require.Equal(t, 1, len(delegations))
+	require.True(t, delegation.Equal(delegations[0]))
+
+	bz, err = query(QueryDelegation, QueryDelegationParams{addrDels[0], addrVals[0]})
+	require.Nil(t, err)
+	var resDelegation types.Delegation
+	require.Nil(t, keeper.cdc.UnmarshalJSON(bz, &resDelegation))
+	require.True(t, delegation.Equal(resDelegation))
+
+	_, err = query(QueryDelegation, QueryDelegationParams{addrDels[1], addrVals[0]})
+	require.NotNil(t, err)
+
+	bz, err = query(QueryParameters, nil)
+	require.Nil(t, err)
+	var params types.Params
+	require.Nil(t, keeper.cdc.UnmarshalJSON(bz, &params))
+	require.Equal(t, keeper.GetParams(ctx), params)
+
+	_, err = query("unknown", nil)
+	require.NotNil(t, err)
+}
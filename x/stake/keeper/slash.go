@@ -91,6 +91,11 @@ func (k Keeper) Slash(ctx sdk.Context, pubkey crypto.PubKey, infractionHeight in
 	pool.LooseTokens -= burned
 	// update the pool
 	k.SetPool(ctx, pool)
+	// revoke the validator if the owner's own delegation fell below the minimum self delegation
+	if !validator.Revoked && k.selfDelegationBelowMin(ctx, validator) {
+		validator.Revoked = true
+		logger.Info(fmt.Sprintf("Validator %s revoked, self delegation below the minimum", pubkey.Address()))
+	}
 	// update the validator, possibly kicking it out
 	k.UpdateValidator(ctx, validator)
 
@@ -125,11 +130,27 @@ func (k Keeper) setRevoked(ctx sdk.Context, pubkey crypto.PubKey, revoked bool)
 	if !found {
 		panic(fmt.Errorf("Validator with pubkey %s not found, cannot set revoked to %v", pubkey, revoked))
 	}
+	// a validator without enough self delegation stays revoked
+	if !revoked && k.selfDelegationBelowMin(ctx, validator) {
+		logger := ctx.Logger().With("module", "x/stake")
+		logger.Info(fmt.Sprintf("Validator %s stays revoked, self delegation below the minimum", pubkey.Address()))
+		return
+	}
 	validator.Revoked = revoked
 	k.UpdateValidator(ctx, validator) // update validator, possibly unbonding or bonding it
 	return
 }
 
+// whether the owner's own delegation to the validator is below its minimum self delegation
+func (k Keeper) selfDelegationBelowMin(ctx sdk.Context, validator types.Validator) bool {
+	selfShares := sdk.ZeroRat()
+	delegation, found := k.GetDelegation(ctx, validator.Owner, validator.Owner)
+	if found {
+		selfShares = delegation.Shares
+	}
+	return validator.SelfDelegationBelowMin(k.GetPool(ctx), selfShares)
+}
+
 // slash an unbonding delegation and update the pool
 // return the amount that would have been slashed assuming
 // the unbonding delegation had enough stake to slash
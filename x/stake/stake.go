@@ -12,9 +12,17 @@ type Keeper = keeper.Keeper
 
 var NewKeeper = keeper.NewKeeper
 
+// querier
+type QueryValidatorParams = keeper.QueryValidatorParams
+type QueryDelegatorParams = keeper.QueryDelegatorParams
+type QueryDelegationParams = keeper.QueryDelegationParams
+
+var NewQuerier = keeper.NewQuerier
+
 // types
 type Validator = types.Validator
 type Description = types.Description
+type CommissionMsg = types.CommissionMsg
 type Delegation = types.Delegation
 type UnbondingDelegation = types.UnbondingDelegation
 type Redelegation = types.Redelegation
@@ -38,6 +46,8 @@ var (
 	GetTendermintUpdatesKey      = keeper.GetTendermintUpdatesKey
 	GetDelegationKey             = keeper.GetDelegationKey
 	GetDelegationsKey            = keeper.GetDelegationsKey
+	GetDelegationByValIndexKey   = keeper.GetDelegationByValIndexKey
+	GetDelegationsByValIndexKey  = keeper.GetDelegationsByValIndexKey
 	ParamKey                     = keeper.ParamKey
 	PoolKey                      = keeper.PoolKey
 	ValidatorsKey                = keeper.ValidatorsKey
@@ -48,6 +58,7 @@ var (
 	ValidatorPowerCliffKey       = keeper.ValidatorPowerCliffKey
 	TendermintUpdatesKey         = keeper.TendermintUpdatesKey
 	DelegationKey                = keeper.DelegationKey
+	DelegationByValIndexKey      = keeper.DelegationByValIndexKey
 	IntraTxCounterKey            = keeper.IntraTxCounterKey
 	GetUBDKey                    = keeper.GetUBDKey
 	GetUBDByValIndexKey          = keeper.GetUBDByValIndexKey
@@ -68,6 +79,7 @@ var (
 	NewBondedShares     = types.NewBondedShares
 	NewValidator        = types.NewValidator
 	NewDescription      = types.NewDescription
+	NewCommissionMsg    = types.NewCommissionMsg
 	NewGenesisState     = types.NewGenesisState
 	DefaultGenesisState = types.DefaultGenesisState
 	RegisterWire        = types.RegisterWire
@@ -104,6 +116,13 @@ var (
 	ErrCommissionNegative     = types.ErrCommissionNegative
 	ErrCommissionHuge         = types.ErrCommissionHuge
 
+	ErrCommissionBeyondMax           = types.ErrCommissionBeyondMax
+	ErrCommissionChangeRateBeyondMax = types.ErrCommissionChangeRateBeyondMax
+	ErrCommissionChangeTooLarge      = types.ErrCommissionChangeTooLarge
+	ErrMinSelfDelegationInvalid      = types.ErrMinSelfDelegationInvalid
+	ErrMinSelfDelegationDecreased    = types.ErrMinSelfDelegationDecreased
+	ErrSelfDelegationBelowMinimum    = types.ErrSelfDelegationBelowMinimum
+
 	ErrNilDelegatorAddr          = types.ErrNilDelegatorAddr
 	ErrBadDenom                  = types.ErrBadDenom
 	ErrBadDelegationAmount       = types.ErrBadDelegationAmount
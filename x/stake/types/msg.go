@@ -27,18 +27,23 @@ var maximumBondingRationalDenominator sdk.Int = sdk.NewInt(int64(math.Pow10(MaxB
 // MsgCreateValidator - struct for unbonding transactions
 type MsgCreateValidator struct {
 	Description
-	ValidatorAddr  sdk.Address   `json:"address"`
-	PubKey         crypto.PubKey `json:"pubkey"`
-	SelfDelegation sdk.Coin      `json:"self_delegation"`
+	Commission        CommissionMsg `json:"commission"`
+	ValidatorAddr     sdk.Address   `json:"address"`
+	PubKey            crypto.PubKey `json:"pubkey"`
+	SelfDelegation    sdk.Coin      `json:"self_delegation"`
+	MinSelfDelegation int64         `json:"min_self_delegation"`
 }
 
 func NewMsgCreateValidator(validatorAddr sdk.Address, pubkey crypto.PubKey,
-	selfDelegation sdk.Coin, description Description) MsgCreateValidator {
+	selfDelegation sdk.Coin, description Description, commission CommissionMsg,
+	minSelfDelegation int64) MsgCreateValidator {
 	return MsgCreateValidator{
-		Description:    description,
-		ValidatorAddr:  validatorAddr,
-		PubKey:         pubkey,
-		SelfDelegation: selfDelegation,
+		Description:       description,
+		Commission:        commission,
+		ValidatorAddr:     validatorAddr,
+		PubKey:            pubkey,
+		SelfDelegation:    selfDelegation,
+		MinSelfDelegation: minSelfDelegation,
 	}
 }
 
@@ -52,13 +57,17 @@ func (msg MsgCreateValidator) GetSigners() []sdk.Address {
 func (msg MsgCreateValidator) GetSignBytes() []byte {
 	b, err := MsgCdc.MarshalJSON(struct {
 		Description
-		ValidatorAddr string   `json:"address"`
-		PubKey        string   `json:"pubkey"`
-		Bond          sdk.Coin `json:"bond"`
+		Commission        CommissionMsg `json:"commission"`
+		ValidatorAddr     string        `json:"address"`
+		PubKey            string        `json:"pubkey"`
+		Bond              sdk.Coin      `json:"bond"`
+		MinSelfDelegation int64         `json:"min_self_delegation"`
 	}{
-		Description:   msg.Description,
-		ValidatorAddr: sdk.MustBech32ifyVal(msg.ValidatorAddr),
-		PubKey:        sdk.MustBech32ifyValPub(msg.PubKey),
+		Description:       msg.Description,
+		Commission:        msg.Commission,
+		ValidatorAddr:     sdk.MustBech32ifyVal(msg.ValidatorAddr),
+		PubKey:            sdk.MustBech32ifyValPub(msg.PubKey),
+		MinSelfDelegation: msg.MinSelfDelegation,
 	})
 	if err != nil {
 		panic(err)
@@ -74,11 +83,17 @@ func (msg MsgCreateValidator) ValidateBasic() sdk.Error {
 	if !(msg.SelfDelegation.Amount.GT(sdk.ZeroInt())) {
 		return ErrBadDelegationAmount(DefaultCodespace)
 	}
+	if msg.MinSelfDelegation < 0 {
+		return ErrMinSelfDelegationInvalid(DefaultCodespace)
+	}
+	if msg.SelfDelegation.Amount.LT(sdk.NewInt(msg.MinSelfDelegation)) {
+		return ErrSelfDelegationBelowMinimum(DefaultCodespace)
+	}
 	empty := Description{}
 	if msg.Description == empty {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "description must be included")
 	}
-	return nil
+	return msg.Commission.Validate()
 }
 
 //______________________________________________________________________
@@ -87,12 +102,21 @@ func (msg MsgCreateValidator) ValidateBasic() sdk.Error {
 type MsgEditValidator struct {
 	Description
 	ValidatorAddr sdk.Address `json:"address"`
+
+	// new commission rate, nil if the commission is not modified
+	CommissionRate *sdk.Rat `json:"commission_rate"`
+
+	// new minimum self delegation, nil if it is not modified
+	MinSelfDelegation *int64 `json:"min_self_delegation"`
 }
 
-func NewMsgEditValidator(validatorAddr sdk.Address, description Description) MsgEditValidator {
+func NewMsgEditValidator(validatorAddr sdk.Address, description Description,
+	commissionRate *sdk.Rat, minSelfDelegation *int64) MsgEditValidator {
 	return MsgEditValidator{
-		Description:   description,
-		ValidatorAddr: validatorAddr,
+		Description:       description,
+		ValidatorAddr:     validatorAddr,
+		CommissionRate:    commissionRate,
+		MinSelfDelegation: minSelfDelegation,
 	}
 }
 
@@ -106,10 +130,14 @@ func (msg MsgEditValidator) GetSigners() []sdk.Address {
 func (msg MsgEditValidator) GetSignBytes() []byte {
 	b, err := MsgCdc.MarshalJSON(struct {
 		Description
-		ValidatorAddr string `json:"address"`
+		ValidatorAddr     string   `json:"address"`
+		CommissionRate    *sdk.Rat `json:"commission_rate"`
+		MinSelfDelegation *int64   `json:"min_self_delegation"`
 	}{
-		Description:   msg.Description,
-		ValidatorAddr: sdk.MustBech32ifyVal(msg.ValidatorAddr),
+		Description:       msg.Description,
+		ValidatorAddr:     sdk.MustBech32ifyVal(msg.ValidatorAddr),
+		CommissionRate:    msg.CommissionRate,
+		MinSelfDelegation: msg.MinSelfDelegation,
 	})
 	if err != nil {
 		panic(err)
@@ -123,9 +151,20 @@ func (msg MsgEditValidator) ValidateBasic() sdk.Error {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "nil validator address")
 	}
 	empty := Description{}
-	if msg.Description == empty {
+	if msg.Description == empty && msg.CommissionRate == nil && msg.MinSelfDelegation == nil {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "transaction must include some information to modify")
 	}
+	if msg.MinSelfDelegation != nil && *msg.MinSelfDelegation < 0 {
+		return ErrMinSelfDelegationInvalid(DefaultCodespace)
+	}
+	if msg.CommissionRate != nil {
+		if msg.CommissionRate.LT(sdk.ZeroRat()) {
+			return ErrCommissionNegative(DefaultCodespace)
+		}
+		if msg.CommissionRate.GT(sdk.OneRat()) {
+			return ErrCommissionHuge(DefaultCodespace)
+		}
+	}
 	return nil
 }
 
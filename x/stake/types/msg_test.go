@@ -13,6 +13,8 @@ var (
 	coinPos  = sdk.Coin{"steak", sdk.NewInt(1000)}
 	coinZero = sdk.Coin{"steak", sdk.NewInt(0)}
 	coinNeg  = sdk.Coin{"steak", sdk.NewInt(-10000)}
+
+	commissionGood = NewCommissionMsg(sdk.NewRat(1, 10), sdk.NewRat(2, 10), sdk.NewRat(1, 100))
 )
 
 // test ValidateBasic for MsgCreateValidator
@@ -22,21 +24,54 @@ func TestMsgCreateValidator(t *testing.T) {
 		validatorAddr                             sdk.Address
 		pubkey                                    crypto.PubKey
 		bond                                      sdk.Coin
+		minSelfDelegation                         int64
 		expectPass                                bool
 	}{
-		{"basic good", "a", "b", "c", "d", addr1, pk1, coinPos, true},
-		{"partial description", "", "", "c", "", addr1, pk1, coinPos, true},
-		{"empty description", "", "", "", "", addr1, pk1, coinPos, false},
-		{"empty address", "a", "b", "c", "d", emptyAddr, pk1, coinPos, false},
-		{"empty pubkey", "a", "b", "c", "d", addr1, emptyPubkey, coinPos, true},
-		{"empty bond", "a", "b", "c", "d", addr1, pk1, coinZero, false},
-		{"negative bond", "a", "b", "c", "d", addr1, pk1, coinNeg, false},
-		{"negative bond", "a", "b", "c", "d", addr1, pk1, coinNeg, false},
+		{"basic good", "a", "b", "c", "d", addr1, pk1, coinPos, 0, true},
+		{"partial description", "", "", "c", "", addr1, pk1, coinPos, 0, true},
+		{"empty description", "", "", "", "", addr1, pk1, coinPos, 0, false},
+		{"empty address", "a", "b", "c", "d", emptyAddr, pk1, coinPos, 0, false},
+		{"empty pubkey", "a", "b", "c", "d", addr1, emptyPubkey, coinPos, 0, true},
+		{"empty bond", "a", "b", "c", "d", addr1, pk1, coinZero, 0, false},
+		{"negative bond", "a", "b", "c", "d", addr1, pk1, coinNeg, 0, false},
+		{"negative bond", "a", "b", "c", "d", addr1, pk1, coinNeg, 0, false},
+		{"bond at min self delegation", "a", "b", "c", "d", addr1, pk1, coinPos, 1000, true},
+		{"bond below min self delegation", "a", "b", "c", "d", addr1, pk1, coinPos, 1001, false},
+		{"negative min self delegation", "a", "b", "c", "d", addr1, pk1, coinPos, -1, false},
 	}
 
 	for _, tc := range tests {
 		description := NewDescription(tc.moniker, tc.identity, tc.website, tc.details)
-		msg := NewMsgCreateValidator(tc.validatorAddr, tc.pubkey, tc.bond, description)
+		msg := NewMsgCreateValidator(tc.validatorAddr, tc.pubkey, tc.bond, description, commissionGood, tc.minSelfDelegation)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
+
+// test ValidateBasic for the commission of MsgCreateValidator
+func TestMsgCreateValidatorCommission(t *testing.T) {
+	tests := []struct {
+		name                         string
+		rate, maxRate, maxChangeRate sdk.Rat
+		expectPass                   bool
+	}{
+		{"basic good", sdk.NewRat(1, 10), sdk.NewRat(2, 10), sdk.NewRat(1, 100), true},
+		{"zero commission", sdk.ZeroRat(), sdk.ZeroRat(), sdk.ZeroRat(), true},
+		{"full commission", sdk.OneRat(), sdk.OneRat(), sdk.OneRat(), true},
+		{"negative rate", sdk.NewRat(-1, 10), sdk.NewRat(2, 10), sdk.NewRat(1, 100), false},
+		{"negative max change rate", sdk.NewRat(1, 10), sdk.NewRat(2, 10), sdk.NewRat(-1, 100), false},
+		{"max rate above 100%", sdk.NewRat(1, 10), sdk.NewRat(11, 10), sdk.NewRat(1, 100), false},
+		{"rate above max rate", sdk.NewRat(3, 10), sdk.NewRat(2, 10), sdk.NewRat(1, 100), false},
+		{"max change rate above max rate", sdk.NewRat(1, 10), sdk.NewRat(2, 10), sdk.NewRat(3, 10), false},
+	}
+
+	for _, tc := range tests {
+		description := NewDescription("a", "b", "c", "d")
+		commission := NewCommissionMsg(tc.rate, tc.maxRate, tc.maxChangeRate)
+		msg := NewMsgCreateValidator(addr1, pk1, coinPos, description, commission, 0)
 		if tc.expectPass {
 			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
 		} else {
@@ -47,20 +82,33 @@ func TestMsgCreateValidator(t *testing.T) {
 
 // test ValidateBasic for MsgEditValidator
 func TestMsgEditValidator(t *testing.T) {
+	commissionRate := sdk.NewRat(1, 10)
+	negativeRate := sdk.NewRat(-1, 10)
+	hugeRate := sdk.NewRat(11, 10)
+	minSelfDelegation := int64(10)
+	negativeMinSelfDelegation := int64(-1)
+
 	tests := []struct {
 		name, moniker, identity, website, details string
 		validatorAddr                             sdk.Address
+		commissionRate                            *sdk.Rat
+		minSelfDelegation                         *int64
 		expectPass                                bool
 	}{
-		{"basic good", "a", "b", "c", "d", addr1, true},
-		{"partial description", "", "", "c", "", addr1, true},
-		{"empty description", "", "", "", "", addr1, false},
-		{"empty address", "a", "b", "c", "d", emptyAddr, false},
+		{"basic good", "a", "b", "c", "d", addr1, nil, nil, true},
+		{"partial description", "", "", "c", "", addr1, nil, nil, true},
+		{"empty description", "", "", "", "", addr1, nil, nil, false},
+		{"empty address", "a", "b", "c", "d", emptyAddr, nil, nil, false},
+		{"commission only", "", "", "", "", addr1, &commissionRate, nil, true},
+		{"negative commission", "", "", "", "", addr1, &negativeRate, nil, false},
+		{"commission above 100%", "", "", "", "", addr1, &hugeRate, nil, false},
+		{"min self delegation only", "", "", "", "", addr1, nil, &minSelfDelegation, true},
+		{"negative min self delegation", "", "", "", "", addr1, nil, &negativeMinSelfDelegation, false},
 	}
 
 	for _, tc := range tests {
 		description := NewDescription(tc.moniker, tc.identity, tc.website, tc.details)
-		msg := NewMsgEditValidator(tc.validatorAddr, description)
+		msg := NewMsgEditValidator(tc.validatorAddr, description, tc.commissionRate, tc.minSelfDelegation)
 		if tc.expectPass {
 			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
 		} else {
@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"fmt"
 
 	sdk "github.com/tepleton/tepleton-sdk/types"
 )
@@ -26,6 +27,34 @@ func (p Params) Equal(p2 Params) bool {
 	return bytes.Equal(bz1, bz2)
 }
 
+// Validate checks that the params are within their valid ranges
+func (p Params) Validate() error {
+	zero, one := sdk.ZeroRat(), sdk.OneRat()
+	for name, rat := range map[string]sdk.Rat{
+		"inflation rate change": p.InflationRateChange,
+		"inflation max":         p.InflationMax,
+		"inflation min":         p.InflationMin,
+		"goal bonded":           p.GoalBonded,
+	} {
+		if rat.LT(zero) || rat.GT(one) {
+			return fmt.Errorf("%s %v is not between 0 and 1", name, rat)
+		}
+	}
+	if p.InflationMin.GT(p.InflationMax) {
+		return fmt.Errorf("inflation min %v is greater than inflation max %v", p.InflationMin, p.InflationMax)
+	}
+	if p.UnbondingTime < 0 {
+		return fmt.Errorf("unbonding time %d is negative", p.UnbondingTime)
+	}
+	if p.MaxValidators == 0 {
+		return fmt.Errorf("max validators must be positive")
+	}
+	if len(p.BondDenom) == 0 {
+		return fmt.Errorf("bond denom is empty")
+	}
+	return nil
+}
+
 // default params
 func DefaultParams() Params {
 	return Params{
@@ -29,34 +29,30 @@ type Validator struct {
 	Description        Description `json:"description"`           // description terms for the validator
 	BondHeight         int64       `json:"bond_height"`           // earliest height as a bonded validator
 	BondIntraTxCounter int16       `json:"bond_intra_tx_counter"` // block-local tx index of validator change
-	ProposerRewardPool sdk.Coins   `json:"proposer_reward_pool"`  // XXX reward pool collected from being the proposer
+	MinSelfDelegation  int64       `json:"min_self_delegation"`   // minimum tokens the owner must keep delegated to its validator
 
-	Commission            sdk.Rat `json:"commission"`              // XXX the commission rate of fees charged to any delegators
-	CommissionMax         sdk.Rat `json:"commission_max"`          // XXX maximum commission rate which this validator can ever charge
-	CommissionChangeRate  sdk.Rat `json:"commission_change_rate"`  // XXX maximum daily increase of the validator commission
-	CommissionChangeToday sdk.Rat `json:"commission_change_today"` // XXX commission rate change today, reset each day (UTC time)
-
-	// fee related
-	PrevBondedShares sdk.Rat `json:"prev_bonded_shares"` // total shares of a global hold pools
+	Commission             sdk.Rat `json:"commission"`               // the commission rate of rewards charged to any delegators
+	CommissionMax          sdk.Rat `json:"commission_max"`           // maximum commission rate which this validator can ever charge
+	CommissionChangeRate   sdk.Rat `json:"commission_change_rate"`   // maximum daily increase of the validator commission
+	CommissionIncreaseTime int64   `json:"commission_increase_time"` // block time (unix) of the last commission increase, or of the creation
 }
 
 // NewValidator - initialize a new validator
 func NewValidator(owner sdk.Address, pubKey crypto.PubKey, description Description) Validator {
 	return Validator{
-		Owner:                 owner,
-		PubKey:                pubKey,
-		Revoked:               false,
-		PoolShares:            NewUnbondedShares(sdk.ZeroRat()),
-		DelegatorShares:       sdk.ZeroRat(),
-		Description:           description,
-		BondHeight:            int64(0),
-		BondIntraTxCounter:    int16(0),
-		ProposerRewardPool:    sdk.Coins{},
-		Commission:            sdk.ZeroRat(),
-		CommissionMax:         sdk.ZeroRat(),
-		CommissionChangeRate:  sdk.ZeroRat(),
-		CommissionChangeToday: sdk.ZeroRat(),
-		PrevBondedShares:      sdk.ZeroRat(),
+		Owner:                  owner,
+		PubKey:                 pubKey,
+		Revoked:                false,
+		PoolShares:             NewUnbondedShares(sdk.ZeroRat()),
+		DelegatorShares:        sdk.ZeroRat(),
+		Description:            description,
+		BondHeight:             int64(0),
+		BondIntraTxCounter:     int16(0),
+		MinSelfDelegation:      int64(0),
+		Commission:             sdk.ZeroRat(),
+		CommissionMax:          sdk.ZeroRat(),
+		CommissionChangeRate:   sdk.ZeroRat(),
+		CommissionIncreaseTime: int64(0),
 	}
 }
 
@@ -67,14 +63,85 @@ func (v Validator) Equal(c2 Validator) bool {
 		v.PoolShares.Equal(c2.PoolShares) &&
 		v.DelegatorShares.Equal(c2.DelegatorShares) &&
 		v.Description == c2.Description &&
+		v.MinSelfDelegation == c2.MinSelfDelegation &&
 		//v.BondHeight == c2.BondHeight &&
 		//v.BondIntraTxCounter == c2.BondIntraTxCounter && // counter is always changing
-		v.ProposerRewardPool.IsEqual(c2.ProposerRewardPool) &&
 		v.Commission.Equal(c2.Commission) &&
 		v.CommissionMax.Equal(c2.CommissionMax) &&
 		v.CommissionChangeRate.Equal(c2.CommissionChangeRate) &&
-		v.CommissionChangeToday.Equal(c2.CommissionChangeToday) &&
-		v.PrevBondedShares.Equal(c2.PrevBondedShares)
+		v.CommissionIncreaseTime == c2.CommissionIncreaseTime
+}
+
+//______________________________________________________________________
+
+// number of seconds which must pass between two increases of the commission
+const commissionChangePeriod = 24 * 60 * 60
+
+// CommissionMsg - commission parameters chosen at the creation of a validator
+type CommissionMsg struct {
+	Rate          sdk.Rat `json:"rate"`            // the commission rate of rewards charged to any delegators
+	MaxRate       sdk.Rat `json:"max_rate"`        // maximum commission rate which this validator can ever charge
+	MaxChangeRate sdk.Rat `json:"max_change_rate"` // maximum daily increase of the validator commission
+}
+
+func NewCommissionMsg(rate, maxRate, maxChangeRate sdk.Rat) CommissionMsg {
+	return CommissionMsg{
+		Rate:          rate,
+		MaxRate:       maxRate,
+		MaxChangeRate: maxChangeRate,
+	}
+}
+
+// ensure the commission parameters are consistent
+func (c CommissionMsg) Validate() sdk.Error {
+	switch {
+	case c.Rate.LT(sdk.ZeroRat()) || c.MaxRate.LT(sdk.ZeroRat()) || c.MaxChangeRate.LT(sdk.ZeroRat()):
+		return ErrCommissionNegative(DefaultCodespace)
+	case c.MaxRate.GT(sdk.OneRat()):
+		return ErrCommissionHuge(DefaultCodespace)
+	case c.Rate.GT(c.MaxRate):
+		return ErrCommissionBeyondMax(DefaultCodespace)
+	case c.MaxChangeRate.GT(c.MaxRate):
+		return ErrCommissionChangeRateBeyondMax(DefaultCodespace)
+	}
+	return nil
+}
+
+// set the commission of a newly created validator, at the block time
+func (v Validator) SetInitialCommission(c CommissionMsg, blockTime int64) (Validator, sdk.Error) {
+	if err := c.Validate(); err != nil {
+		return v, err
+	}
+	v.Commission = c.Rate
+	v.CommissionMax = c.MaxRate
+	v.CommissionChangeRate = c.MaxChangeRate
+	v.CommissionIncreaseTime = blockTime
+	return v, nil
+}
+
+// update the commission rate, ensuring it never exceeds the maximum rate and
+// that it increases at most by the change rate within any 24 hours: an
+// increase is only possible a day after the previous one, or after the
+// creation of the validator. Decreases are always possible.
+func (v Validator) UpdateCommission(rate sdk.Rat, blockTime int64) (Validator, sdk.Error) {
+	switch {
+	case rate.LT(sdk.ZeroRat()):
+		return v, ErrCommissionNegative(DefaultCodespace)
+	case rate.GT(sdk.OneRat()):
+		return v, ErrCommissionHuge(DefaultCodespace)
+	case rate.GT(v.CommissionMax):
+		return v, ErrCommissionBeyondMax(DefaultCodespace)
+	}
+
+	if increase := rate.Sub(v.Commission); increase.GT(sdk.ZeroRat()) {
+		if blockTime-v.CommissionIncreaseTime < commissionChangePeriod ||
+			increase.GT(v.CommissionChangeRate) {
+			return v, ErrCommissionChangeTooLarge(DefaultCodespace)
+		}
+		v.CommissionIncreaseTime = blockTime
+	}
+	v.Commission = rate
+	return v, nil
 }
 
 // Description - description fields for a validator
@@ -278,6 +345,16 @@ func (v Validator) DelegatorShareExRate(pool Pool) sdk.Rat {
 	return eqBondedShares.Quo(v.DelegatorShares)
 }
 
+// whether the tokens of the owner's own delegation, given its shares, are
+// below the minimum self delegation
+func (v Validator) SelfDelegationBelowMin(pool Pool, selfShares sdk.Rat) bool {
+	if v.DelegatorShares.IsZero() {
+		return v.MinSelfDelegation > 0
+	}
+	selfTokens := v.PoolShares.Tokens(pool).Mul(selfShares).Quo(v.DelegatorShares)
+	return selfTokens.LT(sdk.NewRat(v.MinSelfDelegation))
+}
+
 //______________________________________________________________________
 
 // ensure fulfills the sdk validator types
@@ -292,6 +369,7 @@ func (v Validator) GetPubKey() crypto.PubKey    { return v.PubKey }
 func (v Validator) GetPower() sdk.Rat           { return v.PoolShares.Bonded() }
 func (v Validator) GetDelegatorShares() sdk.Rat { return v.DelegatorShares }
 func (v Validator) GetBondHeight() int64        { return v.BondHeight }
+func (v Validator) GetCommission() sdk.Rat      { return v.Commission }
 
 //Human Friendly pretty printer
 func (v Validator) HumanReadableString() (string, error) {
@@ -310,12 +388,11 @@ func (v Validator) HumanReadableString() (string, error) {
 	resp += fmt.Sprintf("Delegator Shares: %s\n", v.DelegatorShares.FloatString())
 	resp += fmt.Sprintf("Description: %s\n", v.Description)
 	resp += fmt.Sprintf("Bond Height: %d\n", v.BondHeight)
-	resp += fmt.Sprintf("Proposer Reward Pool: %s\n", v.ProposerRewardPool.String())
+	resp += fmt.Sprintf("Minimum Self Delegation: %d\n", v.MinSelfDelegation)
 	resp += fmt.Sprintf("Commission: %s\n", v.Commission.String())
 	resp += fmt.Sprintf("Max Commission Rate: %s\n", v.CommissionMax.String())
 	resp += fmt.Sprintf("Commission Change Rate: %s\n", v.CommissionChangeRate.String())
-	resp += fmt.Sprintf("Commission Change Today: %s\n", v.CommissionChangeToday.String())
-	resp += fmt.Sprintf("Previously Bonded Stares: %s\n", v.PrevBondedShares.String())
+	resp += fmt.Sprintf("Commission Increase Time (unix): %d\n", v.CommissionIncreaseTime)
 
 	return resp, nil
 }
@@ -141,6 +141,42 @@ func TestUpdateStatus(t *testing.T) {
 	require.Equal(t, int64(0), pool.UnbondedTokens)
 }
 
+func TestUpdateCommission(t *testing.T) {
+	val := NewValidator(addr1, pk1, Description{})
+	commission := NewCommissionMsg(sdk.NewRat(1, 10), sdk.NewRat(3, 10), sdk.NewRat(1, 10))
+	val, err := val.SetInitialCommission(commission, 1000)
+	require.Nil(t, err)
+	require.Equal(t, int64(1000), val.CommissionIncreaseTime)
+
+	// cannot go beyond the max rate, nor be negative
+	_, err = val.UpdateCommission(sdk.NewRat(4, 10), 1000+commissionChangePeriod)
+	require.NotNil(t, err)
+	_, err = val.UpdateCommission(sdk.NewRat(-1, 10), 1000+commissionChangePeriod)
+	require.NotNil(t, err)
+
+	// no increase within a day of the creation, decreases are always possible
+	_, err = val.UpdateCommission(sdk.NewRat(15, 100), 1000+commissionChangePeriod-1)
+	require.NotNil(t, err)
+	val, err = val.UpdateCommission(sdk.NewRat(5, 100), 1000)
+	require.Nil(t, err)
+
+	// an increase is limited by the change rate
+	_, err = val.UpdateCommission(sdk.NewRat(16, 100), 1000+commissionChangePeriod)
+	require.NotNil(t, err)
+	val, err = val.UpdateCommission(sdk.NewRat(15, 100), 1000+commissionChangePeriod)
+	require.Nil(t, err)
+	require.Equal(t, int64(1000+commissionChangePeriod), val.CommissionIncreaseTime)
+
+	// the next increase needs 24 hours since the last one, not a new calendar day
+	val, err = val.UpdateCommission(sdk.NewRat(1, 10), 1000+2*commissionChangePeriod-1)
+	require.Nil(t, err)
+	_, err = val.UpdateCommission(sdk.NewRat(11, 100), 1000+2*commissionChangePeriod-1)
+	require.NotNil(t, err)
+	val, err = val.UpdateCommission(sdk.NewRat(2, 10), 1000+2*commissionChangePeriod)
+	require.Nil(t, err)
+	require.Equal(t, int64(1000+2*commissionChangePeriod), val.CommissionIncreaseTime)
+}
+
 func TestPossibleOverflow(t *testing.T) {
 	poolShares := sdk.NewRat(2159)
 	delShares := sdk.NewRat(391432570689183511).Quo(sdk.NewRat(40113011844664))
@@ -0,0 +1,70 @@
+package upgrade
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// GenesisState - all upgrade state that must be provided at genesis. The
+// height of the scheduled plan is relative to the height of the export, so
+// that a chain restarted from an export halts after the same number of
+// blocks. The heights of the applied upgrades are only kept as a record,
+// they are not shifted.
+type GenesisState struct {
+	Plan         Plan          `json:"plan"`
+	DoneUpgrades []DoneUpgrade `json:"done_upgrades"`
+}
+
+// Height at which a named upgrade was applied
+type DoneUpgrade struct {
+	Name   string `json:"name"`
+	Height int64  `json:"height"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		DoneUpgrades: []DoneUpgrade{},
+	}
+}
+
+// InitGenesis - store the applied upgrades and the scheduled plan, if any
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	store := ctx.KVStore(k.storeKey)
+	for _, done := range data.DoneUpgrades {
+		store.Set(GetDoneKey(done.Name), k.cdc.MustMarshalBinary(done.Height))
+	}
+	if data.Plan.IsEmpty() {
+		return
+	}
+	plan := data.Plan
+	plan.Height += ctx.BlockHeight()
+	err := k.ScheduleUpgrade(ctx, plan)
+	if err != nil {
+		// TODO: Handle this with #870
+		panic(err)
+	}
+}
+
+// WriteGenesis - output the applied upgrades and the scheduled plan, if any
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	plan, found := k.GetUpgradePlan(ctx)
+	if found {
+		plan.Height -= ctx.BlockHeight()
+	}
+
+	doneUpgrades := []DoneUpgrade{}
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, DonePrefix)
+	for ; iterator.Valid(); iterator.Next() {
+		var height int64
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &height)
+		name := string(iterator.Key()[len(DonePrefix):])
+		doneUpgrades = append(doneUpgrades, DoneUpgrade{name, height})
+	}
+	iterator.Close()
+
+	return GenesisState{
+		Plan:         plan,
+		DoneUpgrades: doneUpgrades,
+	}
+}
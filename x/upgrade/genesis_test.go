@@ -0,0 +1,53 @@
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+func TestGenesisRoundTrip(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	keeper.SetUpgradeHandler("v1", func(ctx sdk.Context, plan Plan) {})
+
+	// apply an upgrade at height 10 and schedule the next one at height 30
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, Plan{Name: "v1", Height: 10}))
+	ctx = ctx.WithBlockHeight(10)
+	BeginBlocker(ctx, keeper)
+	ctx = ctx.WithBlockHeight(20)
+	plan := Plan{Name: "v2", Height: 30, Info: "https://example.com/v2"}
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, plan))
+
+	// the plan height is exported relative to the export height
+	genesis := WriteGenesis(ctx, keeper)
+	require.Equal(t, Plan{Name: "v2", Height: 10, Info: plan.Info}, genesis.Plan)
+	require.Equal(t, []DoneUpgrade{{"v1", 10}}, genesis.DoneUpgrades)
+
+	// import into a fresh store at a different height
+	ctx2, keeper2 := createTestInput(t)
+	ctx2 = ctx2.WithBlockHeight(5)
+	InitGenesis(ctx2, keeper2, genesis)
+	require.Equal(t, genesis, WriteGenesis(ctx2, keeper2))
+
+	got, found := keeper2.GetUpgradePlan(ctx2)
+	require.True(t, found)
+	require.Equal(t, int64(15), got.Height)
+	require.Equal(t, int64(10), keeper2.GetDoneHeight(ctx2, "v1"))
+
+	// the applied upgrade still cannot be scheduled again
+	require.NotNil(t, keeper2.ScheduleUpgrade(ctx2, Plan{Name: "v1", Height: 20}))
+}
+
+func TestGenesisWithoutPlan(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+
+	genesis := WriteGenesis(ctx, keeper)
+	require.Equal(t, DefaultGenesisState(), genesis)
+
+	ctx2, keeper2 := createTestInput(t)
+	InitGenesis(ctx2, keeper2, genesis)
+	_, found := keeper2.GetUpgradePlan(ctx2)
+	require.False(t, found)
+}
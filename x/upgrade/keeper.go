@@ -0,0 +1,99 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+)
+
+// nolint
+var (
+	PlanKey    = []byte{0x00} // key for the currently scheduled upgrade plan
+	DonePrefix = []byte{0x01} // prefix for the heights at which upgrades were applied
+)
+
+// get the key for the height at which the named upgrade was applied
+func GetDoneKey(name string) []byte {
+	return append(DonePrefix, []byte(name)...)
+}
+
+// Keeper of the upgrade store
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *wire.Codec
+	handlers map[string]Handler
+}
+
+// NewKeeper constructs an upgrade keeper
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey) Keeper {
+	return Keeper{
+		storeKey: key,
+		cdc:      cdc,
+		handlers: make(map[string]Handler),
+	}
+}
+
+// SetUpgradeHandler registers the handler for the named upgrade. Binaries
+// must register the handlers of the upgrades they implement before the first
+// block is processed.
+func (k Keeper) SetUpgradeHandler(name string, handler Handler) {
+	k.handlers[name] = handler
+}
+
+// HasUpgradeHandler returns whether a handler is registered for the named upgrade
+func (k Keeper) HasUpgradeHandler(name string) bool {
+	_, ok := k.handlers[name]
+	return ok
+}
+
+// ScheduleUpgrade stores the plan, replacing any previously scheduled plan
+func (k Keeper) ScheduleUpgrade(ctx sdk.Context, plan Plan) error {
+	if err := plan.ValidateBasic(); err != nil {
+		return err
+	}
+	if plan.Height <= ctx.BlockHeight() {
+		return fmt.Errorf("upgrade height %d must be in the future, current height is %d", plan.Height, ctx.BlockHeight())
+	}
+	if height := k.GetDoneHeight(ctx, plan.Name); height != 0 {
+		return fmt.Errorf("upgrade %s has already been applied at height %d", plan.Name, height)
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(PlanKey, k.cdc.MustMarshalBinary(plan))
+	return nil
+}
+
+// GetUpgradePlan returns the currently scheduled plan, if any
+func (k Keeper) GetUpgradePlan(ctx sdk.Context) (plan Plan, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(PlanKey)
+	if bz == nil {
+		return plan, false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &plan)
+	return plan, true
+}
+
+// ClearUpgradePlan removes the currently scheduled plan
+func (k Keeper) ClearUpgradePlan(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(PlanKey)
+}
+
+// GetDoneHeight returns the height at which the named upgrade was applied,
+// or 0 if it never was
+func (k Keeper) GetDoneHeight(ctx sdk.Context, name string) int64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetDoneKey(name))
+	if bz == nil {
+		return 0
+	}
+	var height int64
+	k.cdc.MustUnmarshalBinary(bz, &height)
+	return height
+}
+
+func (k Keeper) setDone(ctx sdk.Context, name string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetDoneKey(name), k.cdc.MustMarshalBinary(ctx.BlockHeight()))
+}
@@ -0,0 +1,79 @@
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+	"github.com/tepleton/tepleton-sdk/x/mock"
+)
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper) {
+	key := sdk.NewKVStoreKey("upgrade")
+	ctx := mock.NewTestContext(t, 0, key).WithBlockHeight(1)
+	return ctx, NewKeeper(wire.NewCodec(), key)
+}
+
+func TestScheduleUpgrade(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+
+	_, found := keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, Plan{Name: "", Height: 10}))
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, Plan{Name: "test", Height: 1}))
+
+	plan := Plan{Name: "test", Height: 10, Info: "https://example.com/v2"}
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, plan))
+	got, found := keeper.GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, plan, got)
+
+	keeper.ClearUpgradePlan(ctx)
+	_, found = keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+}
+
+func TestBeginBlockerHaltsWithoutHandler(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, Plan{Name: "test", Height: 10}))
+
+	// blocks before the upgrade height are processed normally
+	ctx = ctx.WithBlockHeight(9)
+	require.NotPanics(t, func() { BeginBlocker(ctx, keeper) })
+
+	// the old binary halts at the upgrade height
+	ctx = ctx.WithBlockHeight(10)
+	require.Panics(t, func() { BeginBlocker(ctx, keeper) })
+}
+
+func TestBeginBlockerRunsHandlerOnce(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, Plan{Name: "test", Height: 10}))
+
+	called := 0
+	keeper.SetUpgradeHandler("test", func(ctx sdk.Context, plan Plan) {
+		called++
+	})
+
+	// the new binary refuses to process blocks of the old software
+	ctx = ctx.WithBlockHeight(9)
+	require.Panics(t, func() { BeginBlocker(ctx, keeper) })
+
+	ctx = ctx.WithBlockHeight(10)
+	tags := BeginBlocker(ctx, keeper)
+	require.Equal(t, 1, called)
+	require.NotEmpty(t, tags)
+	require.Equal(t, int64(10), keeper.GetDoneHeight(ctx, "test"))
+	_, found := keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+
+	ctx = ctx.WithBlockHeight(11)
+	BeginBlocker(ctx, keeper)
+	require.Equal(t, 1, called)
+
+	// an applied upgrade cannot be scheduled again
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, Plan{Name: "test", Height: 20}))
+}
@@ -0,0 +1,38 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// Plan specifies a software upgrade scheduled at a future block height
+type Plan struct {
+	Name   string `json:"name"`   //  Name of the upgrade, must match the name of a registered upgrade handler
+	Height int64  `json:"height"` //  Height at which the chain halts unless the upgrade handler is present
+	Info   string `json:"info"`   //  Any application specific info, e.g. where to download the new binary
+}
+
+// Handler is run once, at the height of the plan, by the binary which
+// implements the upgrade. It is typically used to migrate the stores.
+type Handler func(ctx sdk.Context, plan Plan)
+
+// ValidateBasic performs stateless checks of the plan
+func (plan Plan) ValidateBasic() error {
+	if len(plan.Name) == 0 {
+		return fmt.Errorf("upgrade plan name cannot be empty")
+	}
+	if plan.Height <= 0 {
+		return fmt.Errorf("upgrade plan height must be positive, got %d", plan.Height)
+	}
+	return nil
+}
+
+// IsEmpty returns whether the plan is the zero value
+func (plan Plan) IsEmpty() bool {
+	return plan == Plan{}
+}
+
+func (plan Plan) String() string {
+	return fmt.Sprintf("Upgrade Plan\n  Name: %s\n  Height: %d\n  Info: %s", plan.Name, plan.Height, plan.Info)
+}
@@ -0,0 +1,40 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// upgrade begin block functionality. Once the height of the scheduled plan is
+// reached the registered upgrade handler is run exactly once. If this binary
+// has no handler for the plan it panics, halting the chain until the node is
+// restarted with a binary that implements the upgrade.
+func BeginBlocker(ctx sdk.Context, k Keeper) (tags sdk.Tags) {
+	plan, found := k.GetUpgradePlan(ctx)
+	if !found {
+		return
+	}
+	logger := ctx.Logger().With("module", "x/upgrade")
+
+	handler, ok := k.handlers[plan.Name]
+	if ctx.BlockHeight() < plan.Height {
+		if ok {
+			// the new binary must not run blocks of the old software
+			panic(fmt.Sprintf("binary with upgrade %s started before upgrade height %d", plan.Name, plan.Height))
+		}
+		return
+	}
+
+	if !ok {
+		msg := fmt.Sprintf("UPGRADE \"%s\" NEEDED at height %d: %s", plan.Name, plan.Height, plan.Info)
+		logger.Error(msg)
+		panic(msg)
+	}
+
+	logger.Info(fmt.Sprintf("applying upgrade \"%s\" at height %d", plan.Name, ctx.BlockHeight()))
+	handler(ctx, plan)
+	k.setDone(ctx, plan.Name)
+	k.ClearUpgradePlan(ctx)
+	return sdk.NewTags("action", []byte("upgrade"), "upgrade", []byte(plan.Name))
+}